@@ -31,7 +31,7 @@ var (
 )
 
 // EnvTest returns a shared envtest.Environment instance, initializing it on first call.
-// The environment includes CRDs for OpenShift, KubeVirt, and Tekton resources.
+// The environment includes CRDs for OpenShift, KubeVirt, Argo CD, Flux, cert-manager, Gateway API, Tekton, OLM, Velero, CSI snapshot, Kyverno, Gatekeeper, and Knative Serving resources.
 // Each test package process gets its own envtest instance with isolated etcd data directory.
 func EnvTest() *envtest.Environment {
 	envTestOnce.Do(func() {
@@ -86,6 +86,11 @@ func EnvTest() *envtest.Environment {
 				// OpenShift
 				CRD("project.openshift.io", "v1", "projects", "Project", "project", false),
 				CRD("route.openshift.io", "v1", "routes", "Route", "route", true),
+				CRD("build.openshift.io", "v1", "buildconfigs", "BuildConfig", "buildconfig", true),
+				CRD("build.openshift.io", "v1", "builds", "Build", "build", true),
+				CRD("image.openshift.io", "v1", "imagestreams", "ImageStream", "imagestream", true),
+				CRD("config.openshift.io", "v1", "clusteroperators", "ClusterOperator", "clusteroperator", false),
+				CRD("config.openshift.io", "v1", "clusterversions", "ClusterVersion", "clusterversion", false),
 				// Kubevirt
 				CRD("kubevirt.io", "v1", "virtualmachines", "VirtualMachine", "virtualmachine", true),
 				CRD("kubevirt.io", "v1", "virtualmachineinstances", "VirtualMachineInstance", "virtualmachineinstance", true),
@@ -95,6 +100,25 @@ func EnvTest() *envtest.Environment {
 				CRD("instancetype.kubevirt.io", "v1beta1", "virtualmachineinstancetypes", "VirtualMachineInstancetype", "virtualmachineinstancetype", true),
 				CRD("instancetype.kubevirt.io", "v1beta1", "virtualmachineclusterpreferences", "VirtualMachineClusterPreference", "virtualmachineclusterpreference", false),
 				CRD("instancetype.kubevirt.io", "v1beta1", "virtualmachinepreferences", "VirtualMachinePreference", "virtualmachinepreference", true),
+				// Argo CD
+				CRD("argoproj.io", "v1alpha1", "applications", "Application", "application", true),
+				CRD("argoproj.io", "v1alpha1", "applicationsets", "ApplicationSet", "applicationset", true),
+				// Flux
+				CRD("source.toolkit.fluxcd.io", "v1", "gitrepositories", "GitRepository", "gitrepository", true),
+				CRD("source.toolkit.fluxcd.io", "v1", "ocirepositories", "OCIRepository", "ocirepository", true),
+				CRD("kustomize.toolkit.fluxcd.io", "v1", "kustomizations", "Kustomization", "kustomization", true),
+				CRD("helm.toolkit.fluxcd.io", "v2", "helmreleases", "HelmRelease", "helmrelease", true),
+				// cert-manager
+				withStatusSubresource(CRD("cert-manager.io", "v1", "certificates", "Certificate", "certificate", true)),
+				CRD("cert-manager.io", "v1", "certificaterequests", "CertificateRequest", "certificaterequest", true),
+				CRD("cert-manager.io", "v1", "issuers", "Issuer", "issuer", true),
+				CRD("cert-manager.io", "v1", "clusterissuers", "ClusterIssuer", "clusterissuer", false),
+				CRD("acme.cert-manager.io", "v1", "orders", "Order", "order", true),
+				CRD("acme.cert-manager.io", "v1", "challenges", "Challenge", "challenge", true),
+				// Gateway API
+				CRD("gateway.networking.k8s.io", "v1", "gateways", "Gateway", "gateway", true),
+				CRD("gateway.networking.k8s.io", "v1", "httproutes", "HTTPRoute", "httproute", true),
+				CRD("gateway.networking.k8s.io", "v1", "grpcroutes", "GRPCRoute", "grpcroute", true),
 				// Tekton
 				CRD("tekton.dev", "v1", "pipelines", "Pipeline", "pipeline", true),
 				CRD("tekton.dev", "v1", "pipelineruns", "PipelineRun", "pipelinerun", true),
@@ -102,6 +126,26 @@ func EnvTest() *envtest.Environment {
 				CRD("tekton.dev", "v1", "taskruns", "TaskRun", "taskrun", true),
 				CRD("pipelinesascode.tekton.dev", "v1alpha1", "repositories", "Repository", "repository", true),
 				CRD("operator.tekton.dev", "v1alpha1", "tektonconfigs", "TektonConfig", "tektonconfig", false),
+				// OLM
+				CRD("operators.coreos.com", "v1alpha1", "subscriptions", "Subscription", "subscription", true),
+				CRD("operators.coreos.com", "v1alpha1", "clusterserviceversions", "ClusterServiceVersion", "clusterserviceversion", true),
+				CRD("operators.coreos.com", "v1alpha1", "installplans", "InstallPlan", "installplan", true),
+				CRD("packages.operators.coreos.com", "v1", "packagemanifests", "PackageManifest", "packagemanifest", true),
+				// Velero
+				CRD("velero.io", "v1", "backups", "Backup", "backup", true),
+				CRD("velero.io", "v1", "restores", "Restore", "restore", true),
+				CRD("velero.io", "v1", "downloadrequests", "DownloadRequest", "downloadrequest", true),
+				// CSI snapshots
+				CRD("snapshot.storage.k8s.io", "v1", "volumesnapshots", "VolumeSnapshot", "volumesnapshot", true),
+				CRD("snapshot.storage.k8s.io", "v1", "volumesnapshotclasses", "VolumeSnapshotClass", "volumesnapshotclass", false),
+				CRD("snapshot.storage.k8s.io", "v1", "volumesnapshotcontents", "VolumeSnapshotContent", "volumesnapshotcontent", false),
+				// Policy reports (Kyverno) and Gatekeeper
+				CRD("wgpolicyk8s.io", "v1alpha2", "policyreports", "PolicyReport", "policyreport", true),
+				CRD("wgpolicyk8s.io", "v1alpha2", "clusterpolicyreports", "ClusterPolicyReport", "clusterpolicyreport", false),
+				CRD("templates.gatekeeper.sh", "v1", "constrainttemplates", "ConstraintTemplate", "constrainttemplate", false),
+				// Knative Serving
+				CRD("serving.knative.dev", "v1", "services", "Service", "service", true),
+				CRD("serving.knative.dev", "v1", "revisions", "Revision", "revision", true),
 			},
 		}
 
@@ -208,6 +252,16 @@ func CRD(group, version, plural, kind, singular string, namespaced bool) *apiext
 	}
 }
 
+// withStatusSubresource enables the status subresource of a CRD, for the resources whose status is updated by the tools.
+func withStatusSubresource(crd *apiextensionsv1spec.CustomResourceDefinition) *apiextensionsv1spec.CustomResourceDefinition {
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Subresources = &apiextensionsv1spec.CustomResourceSubresources{
+			Status: &apiextensionsv1spec.CustomResourceSubresourceStatus{},
+		}
+	}
+	return crd
+}
+
 func createTestData(kc kubernetes.Interface) {
 	ctx := context.Background()
 
@@ -0,0 +1,210 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+const (
+	// AlertmanagerNamespace and AlertmanagerRoute identify the OpenShift cluster monitoring Alertmanager endpoint.
+	AlertmanagerNamespace = "openshift-monitoring"
+	AlertmanagerRoute     = "alertmanager-main"
+
+	defaultHTTPTimeout  = 30 * time.Second
+	maxResponseBodySize = 8 << 20 // 8 MiB
+)
+
+// Alertmanager is an HTTP client for the Alertmanager v2 API.
+type Alertmanager struct {
+	*observability.Client
+	maxSilenceDuration time.Duration
+}
+
+// NewAlertmanager creates a client using the toolset config and the Kubernetes REST config.
+// When no URL is configured and the cluster is OpenShift, the URL is discovered from the alertmanager-main Route.
+func NewAlertmanager(ctx context.Context, configProvider api.BaseConfig, k8s api.KubernetesClient, provider api.FilteringProvider) (*Alertmanager, error) {
+	var cfg *observability.Config
+	maxSilenceDuration := DefaultMaxSilenceDuration
+	if c, ok := configProvider.GetToolsetConfig("alertmanager"); ok {
+		if ac, ok := c.(*Config); ok && ac != nil {
+			cfg = &ac.Config
+			maxSilenceDuration = ac.GetMaxSilenceDuration()
+		}
+	}
+	client := &Alertmanager{
+		Client: observability.NewClient(observability.Options{
+			Name:                "alertmanager",
+			Timeout:             defaultHTTPTimeout,
+			MaxResponseBodySize: maxResponseBodySize,
+			TooLargeHint:        "narrow down the filter",
+		}, configProvider, k8s, cfg),
+		maxSilenceDuration: maxSilenceDuration,
+	}
+	if err := client.DiscoverURL(ctx, k8s, provider, AlertmanagerNamespace, AlertmanagerRoute); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// MaxSilenceDuration returns the longest silence that can be created with this client.
+func (a *Alertmanager) MaxSilenceDuration() time.Duration {
+	return a.maxSilenceDuration
+}
+
+// do performs a request against the Alertmanager API and decodes the JSON response into result (when not nil).
+func (a *Alertmanager) do(ctx context.Context, method, endpoint string, query url.Values, body any, result any) error {
+	statusCode, respBody, err := a.Do(ctx, method, endpoint, query, body)
+	if err != nil {
+		return err
+	}
+	if err = a.StatusError(statusCode, respBody); err != nil {
+		return err
+	}
+	if result != nil {
+		if err = json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode alertmanager API response: %w", err)
+		}
+	}
+	return nil
+}
+
+// Receiver is an Alertmanager notification receiver.
+type Receiver struct {
+	Name string `json:"name"`
+}
+
+// AlertStatus is the silencing and inhibition status of an alert.
+type AlertStatus struct {
+	State       string   `json:"state"`
+	SilencedBy  []string `json:"silencedBy"`
+	InhibitedBy []string `json:"inhibitedBy"`
+}
+
+// Alert is an alert as reported by the Alertmanager v2 API.
+type Alert struct {
+	Fingerprint  string            `json:"fingerprint"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+	Receivers    []Receiver        `json:"receivers"`
+	Status       AlertStatus       `json:"status"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// AlertsFilter restricts the alerts returned by Alerts.
+type AlertsFilter struct {
+	// Matchers in Alertmanager filter syntax, e.g. alertname="KubePodCrashLooping" or severity=~"critical|warning".
+	Matchers []string
+	// Receiver is a regular expression matching the receiver names.
+	Receiver  string
+	Silenced  bool
+	Inhibited bool
+}
+
+// Alerts returns the active alerts matching the filter.
+func (a *Alertmanager) Alerts(ctx context.Context, filter AlertsFilter) ([]Alert, error) {
+	query := url.Values{
+		"active":    {"true"},
+		"silenced":  {strconv.FormatBool(filter.Silenced)},
+		"inhibited": {strconv.FormatBool(filter.Inhibited)},
+	}
+	for _, m := range filter.Matchers {
+		query.Add("filter", m)
+	}
+	if filter.Receiver != "" {
+		query.Set("receiver", filter.Receiver)
+	}
+	var alerts []Alert
+	if err := a.do(ctx, http.MethodGet, "/api/v2/alerts", query, nil, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// Matcher is a silence label matcher.
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+// SilenceStatus is the state of a silence (active, pending, or expired).
+type SilenceStatus struct {
+	State string `json:"state"`
+}
+
+// Silence is a silence as reported by the Alertmanager v2 API.
+type Silence struct {
+	ID        string        `json:"id,omitempty"`
+	Status    SilenceStatus `json:"status"`
+	Matchers  []Matcher     `json:"matchers"`
+	StartsAt  time.Time     `json:"startsAt"`
+	EndsAt    time.Time     `json:"endsAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	CreatedBy string        `json:"createdBy"`
+	Comment   string        `json:"comment"`
+}
+
+// Silences returns the silences matching the provided matchers.
+func (a *Alertmanager) Silences(ctx context.Context, matchers []string) ([]Silence, error) {
+	query := url.Values{}
+	for _, m := range matchers {
+		query.Add("filter", m)
+	}
+	var silences []Silence
+	if err := a.do(ctx, http.MethodGet, "/api/v2/silences", query, nil, &silences); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// PostableSilence is a silence to be created.
+type PostableSilence struct {
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// CreateSilence creates the silence and returns its ID.
+// Silences without comment, or lasting longer than the configured maximum duration, are rejected.
+func (a *Alertmanager) CreateSilence(ctx context.Context, silence PostableSilence) (string, error) {
+	if len(silence.Matchers) == 0 {
+		return "", errors.New("at least one matcher is required")
+	}
+	if strings.TrimSpace(silence.Comment) == "" {
+		return "", errors.New("comment is required")
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return "", errors.New("silence must end after it starts")
+	}
+	if d := silence.EndsAt.Sub(silence.StartsAt); d > a.maxSilenceDuration {
+		return "", fmt.Errorf("silence duration %s exceeds the maximum allowed duration of %s", d, a.maxSilenceDuration)
+	}
+	var result struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := a.do(ctx, http.MethodPost, "/api/v2/silences", url.Values{}, silence, &result); err != nil {
+		return "", err
+	}
+	return result.SilenceID, nil
+}
+
+// ExpireSilence expires the silence with the provided ID.
+func (a *Alertmanager) ExpireSilence(ctx context.Context, id string) error {
+	return a.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), url.Values{}, nil, nil)
+}
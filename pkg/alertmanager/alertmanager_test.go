@@ -0,0 +1,204 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+type AlertmanagerSuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Config     *config.StaticConfig
+}
+
+func (s *AlertmanagerSuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.Config = test.Must(config.ReadToml([]byte(fmt.Sprintf(`
+		[toolset_configs.alertmanager]
+		url = "%s"
+		max_silence_duration = "2h"
+	`, s.MockServer.Config().Host))))
+}
+
+func (s *AlertmanagerSuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *AlertmanagerSuite) TestNewAlertmanager() {
+	s.Run("fails without URL on non-OpenShift clusters", func() {
+		_, err := NewAlertmanager(context.Background(), config.Default(), &fakeKubernetesClient{}, &mockFilteringProvider{})
+		s.Require().Error(err)
+		s.ErrorContains(err, "alertmanager URL not configured")
+	})
+	s.Run("discovers URL from alertmanager-main route on OpenShift", func() {
+		route := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "route.openshift.io/v1",
+			"kind":       "Route",
+			"metadata":   map[string]interface{}{"name": AlertmanagerRoute, "namespace": AlertmanagerNamespace},
+			"spec":       map[string]interface{}{"host": "alertmanager-main.apps.example.com"},
+		}}
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"}, route)}
+		client, err := NewAlertmanager(context.Background(), config.Default(), k8s, &mockFilteringProvider{openShift: true})
+		s.Require().NoError(err)
+		s.Equal("https://alertmanager-main.apps.example.com", client.URL())
+		s.Equal(DefaultMaxSilenceDuration, client.MaxSilenceDuration())
+	})
+}
+
+func (s *AlertmanagerSuite) TestAlerts() {
+	var seenQuery url.Values
+	var seenAuth string
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v2/alerts", r.URL.Path)
+		seenQuery = r.URL.Query()
+		seenAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[{"fingerprint":"abc","labels":{"alertname":"KubePodCrashLooping","severity":"warning"},` +
+			`"startsAt":"2026-01-01T00:00:00Z","receivers":[{"name":"default"}],"status":{"state":"active","silencedBy":[],"inhibitedBy":[]}}]`))
+	}))
+	client, err := NewAlertmanager(context.Background(), s.Config, &fakeKubernetesClient{token: "token-xyz"}, nil)
+	s.Require().NoError(err)
+
+	alerts, err := client.Alerts(s.T().Context(), AlertsFilter{
+		Matchers: []string{`alertname="KubePodCrashLooping"`, `namespace=~"ns-.*"`},
+		Receiver: "default",
+	})
+	s.Require().NoError(err)
+	s.Equal("Bearer token-xyz", seenAuth)
+	s.Equal([]string{`alertname="KubePodCrashLooping"`, `namespace=~"ns-.*"`}, seenQuery["filter"])
+	s.Equal("default", seenQuery.Get("receiver"))
+	s.Equal("true", seenQuery.Get("active"))
+	s.Equal("false", seenQuery.Get("silenced"))
+	s.Equal("false", seenQuery.Get("inhibited"))
+	s.Require().Len(alerts, 1)
+	s.Equal("KubePodCrashLooping", alerts[0].Labels["alertname"])
+	s.Equal("default", alerts[0].Receivers[0].Name)
+}
+
+func (s *AlertmanagerSuite) TestCreateSilence() {
+	var posted PostableSilence
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("/api/v2/silences", r.URL.Path)
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(json.Unmarshal(body, &posted))
+		_, _ = w.Write([]byte(`{"silenceID":"silence-1"}`))
+	}))
+	client, err := NewAlertmanager(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	silence := PostableSilence{
+		Matchers:  []Matcher{{Name: "alertname", Value: "Watchdog", IsEqual: ptr.To(true)}},
+		StartsAt:  start,
+		EndsAt:    start.Add(time.Hour),
+		CreatedBy: "test",
+		Comment:   "INC-1234",
+	}
+
+	id, err := client.CreateSilence(s.T().Context(), silence)
+	s.Require().NoError(err)
+	s.Equal("silence-1", id)
+	s.Equal(silence, posted)
+
+	s.Run("rejects silences longer than max_silence_duration", func() {
+		tooLong := silence
+		tooLong.EndsAt = start.Add(3 * time.Hour)
+		_, err := client.CreateSilence(s.T().Context(), tooLong)
+		s.Require().Error(err)
+		s.EqualError(err, "silence duration 3h0m0s exceeds the maximum allowed duration of 2h0m0s")
+	})
+	s.Run("rejects silences without comment", func() {
+		noComment := silence
+		noComment.Comment = " "
+		_, err := client.CreateSilence(s.T().Context(), noComment)
+		s.Require().Error(err)
+		s.EqualError(err, "comment is required")
+	})
+	s.Run("rejects silences without matchers", func() {
+		noMatchers := silence
+		noMatchers.Matchers = nil
+		_, err := client.CreateSilence(s.T().Context(), noMatchers)
+		s.Require().Error(err)
+		s.EqualError(err, "at least one matcher is required")
+	})
+}
+
+func (s *AlertmanagerSuite) TestExpireSilence() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodDelete, r.Method)
+		if r.URL.Path != "/api/v2/silence/silence-1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`"silence not found"`))
+		}
+	}))
+	client, err := NewAlertmanager(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	s.NoError(client.ExpireSilence(s.T().Context(), "silence-1"))
+	err = client.ExpireSilence(s.T().Context(), "missing")
+	s.Require().Error(err)
+	s.EqualError(err, `alertmanager API error (status 404): "silence not found"`)
+}
+
+func (s *AlertmanagerSuite) TestDo_rejectsRedirects() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://attacker.example/stolen", http.StatusFound)
+	}))
+	client, err := NewAlertmanager(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	_, err = client.Silences(s.T().Context(), nil)
+	s.Require().Error(err)
+	s.ErrorContains(err, "redirects are not allowed")
+}
+
+type fakeKubernetesClient struct {
+	api.KubernetesClient
+	token   string
+	dynamic dynamic.Interface
+}
+
+func (f *fakeKubernetesClient) RESTConfig() *rest.Config {
+	return &rest.Config{BearerToken: f.token}
+}
+
+func (f *fakeKubernetesClient) DynamicClient() dynamic.Interface {
+	return f.dynamic
+}
+
+type mockFilteringProvider struct {
+	openShift bool
+}
+
+func (m *mockFilteringProvider) IsTargetCompatibilityToolFiltersEnabled() bool {
+	return true
+}
+
+func (m *mockFilteringProvider) AnyTargetHasGVKs(_ context.Context, _ []schema.GroupVersionKind) bool {
+	return m.openShift
+}
+
+func TestAlertmanager(t *testing.T) {
+	suite.Run(t, new(AlertmanagerSuite))
+}
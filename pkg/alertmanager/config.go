@@ -0,0 +1,68 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+// Config holds Alertmanager toolset configuration.
+// The url is the Alertmanager HTTP API, discovered from the alertmanager-main Route on OpenShift when empty.
+type Config struct {
+	observability.Config
+	// MaxSilenceDuration bounds the duration of the silences created by the toolset (defaults to 24h).
+	MaxSilenceDuration string `toml:"max_silence_duration,omitempty"`
+}
+
+// DefaultMaxSilenceDuration is the longest silence that can be created when max_silence_duration is not configured.
+const DefaultMaxSilenceDuration = 24 * time.Hour
+
+// GetMaxSilenceDuration returns the configured maximum silence duration or DefaultMaxSilenceDuration.
+func (c *Config) GetMaxSilenceDuration() time.Duration {
+	if c == nil || c.MaxSilenceDuration == "" {
+		return DefaultMaxSilenceDuration
+	}
+	d, err := time.ParseDuration(c.MaxSilenceDuration)
+	if err != nil {
+		return DefaultMaxSilenceDuration
+	}
+	return d
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("alertmanager config is nil")
+	}
+	if err := c.Config.Validate(); err != nil {
+		return err
+	}
+	if c.MaxSilenceDuration != "" {
+		if d, err := time.ParseDuration(c.MaxSilenceDuration); err != nil || d <= 0 {
+			return errors.New("max_silence_duration must be a positive duration (e.g. 4h)")
+		}
+	}
+	return nil
+}
+
+func alertmanagerToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Parse(ctx, "Alertmanager"); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("alertmanager", alertmanagerToolsetParser)
+}
@@ -0,0 +1,87 @@
+package alertmanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+	tempDir string
+	caFile  string
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.tempDir = s.T().TempDir()
+	s.caFile = filepath.Join(s.tempDir, "ca.crt")
+	s.Require().NoError(os.WriteFile(s.caFile, []byte("test ca content"), 0644))
+}
+
+func (s *ConfigSuite) alertmanagerConfig(cfg *config.StaticConfig) *Config {
+	alertmanagerCfg, ok := cfg.GetToolsetConfig("alertmanager")
+	s.Require().True(ok, "Alertmanager config should be present")
+	acfg, ok := alertmanagerCfg.(*Config)
+	s.Require().True(ok, "Alertmanager config should be of type *Config")
+	return acfg
+}
+
+func (s *ConfigSuite) TestConfigParser() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.alertmanager]
+		url = "https://alertmanager.example/"
+		certificate_authority = "ca.crt"
+		max_silence_duration = "4h"
+	`), config.WithDirPath(s.tempDir)))
+
+	acfg := s.alertmanagerConfig(cfg)
+	s.Equal(s.caFile, acfg.CertificateAuthority)
+	s.Equal(4*time.Hour, acfg.GetMaxSilenceDuration())
+}
+
+func (s *ConfigSuite) TestConfigParser_DefaultMaxSilenceDuration() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.alertmanager]
+		url = "http://alertmanager.example/"
+	`)))
+
+	s.Equal(DefaultMaxSilenceDuration, s.alertmanagerConfig(cfg).GetMaxSilenceDuration())
+}
+
+func (s *ConfigSuite) TestConfigParser_RejectsInvalidConfig() {
+	s.Run("invalid max_silence_duration", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			url = "http://alertmanager.example/"
+			max_silence_duration = "forever"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "max_silence_duration must be a positive duration")
+	})
+	s.Run("https without certificate_authority", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			url = "https://alertmanager.example/"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "certificate_authority is required for https when insecure is false")
+	})
+	s.Run("HTTP URL when require_tls is enabled", func() {
+		_, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.alertmanager]
+			url = "http://alertmanager.example/"
+		`))
+		s.Require().Error(err)
+		s.ErrorContains(err, "require_tls is enabled but Alertmanager URL uses \"http\" scheme")
+	})
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
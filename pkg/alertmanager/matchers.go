@@ -0,0 +1,60 @@
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+)
+
+var matcherRegexp = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+
+// ParseMatcher parses a matcher expressed in the Alertmanager filter syntax, e.g. alertname="Watchdog",
+// severity=~"critical|warning", or namespace!="openshift-monitoring". Quotes around the value are optional.
+func ParseMatcher(s string) (Matcher, error) {
+	parts := matcherRegexp.FindStringSubmatch(s)
+	if parts == nil {
+		return Matcher{}, fmt.Errorf("invalid matcher %q: expected <label><op><value> with op one of =, !=, =~, !~", s)
+	}
+	value := parts[3]
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return Matcher{}, fmt.Errorf("invalid matcher %q: invalid quoted value: %w", s, err)
+		}
+		value = unquoted
+	}
+	m := Matcher{Name: parts[1], Value: value}
+	switch parts[2] {
+	case "=":
+		m.IsEqual = ptr.To(true)
+	case "!=":
+		m.IsEqual = ptr.To(false)
+	case "=~":
+		m.IsRegex, m.IsEqual = true, ptr.To(true)
+	case "!~":
+		m.IsRegex, m.IsEqual = true, ptr.To(false)
+	}
+	if m.IsRegex {
+		if _, err := regexp.Compile("^(?:" + m.Value + ")$"); err != nil {
+			return Matcher{}, fmt.Errorf("invalid matcher %q: invalid regular expression: %w", s, err)
+		}
+	}
+	return m, nil
+}
+
+// String returns the matcher in the Alertmanager filter syntax.
+func (m Matcher) String() string {
+	op := "="
+	switch {
+	case m.IsRegex && (m.IsEqual == nil || *m.IsEqual):
+		op = "=~"
+	case m.IsRegex:
+		op = "!~"
+	case m.IsEqual != nil && !*m.IsEqual:
+		op = "!="
+	}
+	return m.Name + op + strconv.Quote(m.Value)
+}
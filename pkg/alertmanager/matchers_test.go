@@ -0,0 +1,46 @@
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/utils/ptr"
+)
+
+type MatchersSuite struct {
+	suite.Suite
+}
+
+func (s *MatchersSuite) TestParseMatcher() {
+	for input, expected := range map[string]Matcher{
+		`alertname="Watchdog"`:             {Name: "alertname", Value: "Watchdog", IsEqual: ptr.To(true)},
+		`namespace != "openshift-ingress"`: {Name: "namespace", Value: "openshift-ingress", IsEqual: ptr.To(false)},
+		`severity=~"critical|warning"`:     {Name: "severity", Value: "critical|warning", IsRegex: true, IsEqual: ptr.To(true)},
+		`pod!~kube-.*`:                     {Name: "pod", Value: "kube-.*", IsRegex: true, IsEqual: ptr.To(false)},
+		`summary="say \"hi\""`:             {Name: "summary", Value: `say "hi"`, IsEqual: ptr.To(true)},
+	} {
+		s.Run(input, func() {
+			m, err := ParseMatcher(input)
+			s.Require().NoError(err)
+			s.Equal(expected, m)
+		})
+	}
+	s.Run("rejects invalid matchers", func() {
+		_, err := ParseMatcher("alertname")
+		s.ErrorContains(err, "invalid matcher \"alertname\"")
+		_, err = ParseMatcher(`severity=~"(critical"`)
+		s.ErrorContains(err, "invalid regular expression")
+	})
+}
+
+func (s *MatchersSuite) TestString() {
+	for _, input := range []string{`alertname="Watchdog"`, `namespace!="x"`, `severity=~"critical|warning"`, `pod!~"kube-.*"`} {
+		m, err := ParseMatcher(input)
+		s.Require().NoError(err)
+		s.Equal(input, m.String())
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	suite.Run(t, new(MatchersSuite))
+}
@@ -103,6 +103,29 @@ func optionalBool(params ToolHandlerParams, key string, defaultVal bool) (bool,
 	return b, nil
 }
 
+// optionalStringArray is the type-strict variant that powers Params.OptionalStringArray.
+// Missing or null key returns nil with no error; a value that is not an array
+// of strings returns an error.
+func optionalStringArray(params ToolHandlerParams, key string) ([]string, error) {
+	val, ok := params.GetArguments()[key]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s parameter must be an array of strings", key)
+	}
+	ret := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s parameter must be an array of strings", key)
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
 // Params wraps ToolHandlerParams with sticky-error parameter extraction, so a
 // handler can extract several arguments and check for type mismatches once at
 // the end rather than after each call. Once an extraction fails, subsequent
@@ -180,6 +203,21 @@ func (p *Params) OptionalBool(key string, defaultVal bool) bool {
 	return v
 }
 
+// OptionalStringArray extracts an optional array of strings. Missing key
+// returns nil with no sticky error; a value that is not an array of strings
+// records a sticky error and returns nil.
+func (p *Params) OptionalStringArray(key string) []string {
+	if p.err != nil {
+		return nil
+	}
+	v, err := optionalStringArray(p.ToolHandlerParams, key)
+	if err != nil {
+		p.err = err
+		return nil
+	}
+	return v
+}
+
 // OptionalInt64 extracts an optional int64 parameter. Missing key returns
 // defaultVal with no sticky error; a present-but-wrong-type value records a
 // sticky error and returns defaultVal.
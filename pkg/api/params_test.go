@@ -205,11 +205,13 @@ func (s *ParamsSuite) TestParamsWrapper() {
 			"name":    "hello",
 			"enabled": true,
 			"count":   float64(42),
+			"items":   []interface{}{"a", "b"},
 		}}}
 		p := WrapParams(params)
 		s.Equal("hello", p.RequiredString("name"))
 		s.True(p.OptionalBool("enabled", false))
 		s.Equal(int64(42), p.OptionalInt64("count", 0))
+		s.Equal([]string{"a", "b"}, p.OptionalStringArray("items"))
 		s.NoError(p.Err())
 	})
 
@@ -234,6 +236,7 @@ func (s *ParamsSuite) TestParamsWrapper() {
 		s.Equal("fallback", p.OptionalString("namespace", "fallback"))
 		s.True(p.OptionalBool("enabled", true))
 		s.Equal(int64(99), p.OptionalInt64("count", 99))
+		s.Nil(p.OptionalStringArray("items"))
 		s.NoError(p.Err())
 	})
 
@@ -252,4 +255,14 @@ func (s *ParamsSuite) TestParamsWrapper() {
 		s.Error(p.Err())
 		s.Contains(p.Err().Error(), "count parameter must be an integer")
 	})
+
+	s.Run("OptionalStringArray with wrong type sets error", func() {
+		for _, value := range []any{"a", []interface{}{"a", 1}} {
+			params := ToolHandlerParams{ToolCallRequest: &mockToolCallRequest{args: map[string]any{"items": value}}}
+			p := WrapParams(params)
+			s.Nil(p.OptionalStringArray("items"))
+			s.Error(p.Err())
+			s.Contains(p.Err().Error(), "items parameter must be an array of strings")
+		}
+	})
 }
@@ -58,6 +58,27 @@ type Toolset interface {
 	GetResourceTemplates() []ServerResourceTemplate
 }
 
+// EnabledToolsetsProvider is implemented by the configurations exposing the enabled toolsets.
+type EnabledToolsetsProvider interface {
+	Toolsets() []Toolset
+}
+
+// ManifestsProvider is implemented by the toolsets that deploy rendered manifests (e.g. Helm releases),
+// so that other toolsets can inspect the deployed objects without depending on their packages.
+type ManifestsProvider interface {
+	// DeployedManifests returns the manifests deployed in the specified namespace, or across all namespaces if empty.
+	DeployedManifests(params ToolHandlerParams, namespace string) ([]DeployedManifest, error)
+}
+
+// DeployedManifest is a multi-document YAML manifest deployed to the cluster.
+type DeployedManifest struct {
+	// Source describes what deployed the manifest, e.g. "helm release shop/web (revision 3)".
+	Source string
+	// Namespace is the namespace of the objects of the manifest that do not set one.
+	Namespace string
+	Manifest  string
+}
+
 type ToolCallRequest interface {
 	GetArguments() map[string]any
 }
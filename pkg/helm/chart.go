@@ -0,0 +1,166 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"sigs.k8s.io/yaml"
+)
+
+// readmeMaxLength is the maximum length of the README returned by Show, longer READMEs are truncated.
+const readmeMaxLength = 8000
+
+// readmeFileNames are the names of the README of a chart, case-insensitive.
+var readmeFileNames = []string{"readme.md", "readme.txt", "readme"}
+
+// chartInfo is the description of a chart returned by Show.
+type chartInfo struct {
+	Chart  *chart.Metadata `json:"chart"`
+	Values string          `json:"values,omitempty"`
+	Schema string          `json:"valuesSchema,omitempty"`
+	Readme string          `json:"readme,omitempty"`
+}
+
+// Show pulls a chart and returns its Chart.yaml metadata, default values, values.schema.json and README.
+// Charts pulled at a pinned version are cached on local disk and reused, charts without version are pulled again to resolve the latest version.
+func (h *Helm) Show(chartReference string, version string) (string, error) {
+	if err := validateChartReference(chartReference, h.config); err != nil {
+		return "", err
+	}
+	chartLoaded, err := h.pullChart(chartReference, version)
+	if err != nil {
+		return "", err
+	}
+	info := chartInfo{Chart: chartLoaded.Metadata, Schema: string(chartLoaded.Schema)}
+	for _, f := range chartLoaded.Raw {
+		if f.Name == "values.yaml" {
+			info.Values = string(f.Data)
+		}
+	}
+	for _, f := range chartLoaded.Files {
+		if slices.Contains(readmeFileNames, strings.ToLower(f.Name)) {
+			info.Readme = truncateReadme(string(f.Data))
+			break
+		}
+	}
+	ret, err := yaml.Marshal(info)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// pullChart loads a chart, local charts are used in place and remote charts are pulled through the chart cache.
+// Cached charts that cannot be loaded are dropped and pulled again.
+func (h *Helm) pullChart(chartReference string, version string) (*chart.Chart, error) {
+	if _, err := os.Stat(chartReference); err == nil {
+		return loader.Load(chartReference)
+	}
+	// Only the exact versions are cached, the charts matching a version range or the latest version change over time
+	exact := isExactVersion(version)
+	if exact {
+		cached := filepath.Join(h.chartCache, chartCacheKey(chartReference, version)+".tgz")
+		if chartLoaded, err := loader.Load(cached); err == nil {
+			return chartLoaded, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			_ = os.Remove(cached)
+		}
+	}
+	registryClient, err := registry.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	show := action.NewShow(action.ShowAll)
+	show.SetRegistryClient(registryClient)
+	show.Version = version
+	pulled, err := show.LocateChart(chartReference, cli.New())
+	if err != nil {
+		return nil, err
+	}
+	chartLoaded, err := loader.Load(pulled)
+	if err != nil {
+		return nil, err
+	}
+	if exact && chartLoaded.Metadata.Version == version {
+		cached := filepath.Join(h.chartCache, chartCacheKey(chartReference, chartLoaded.Metadata.Version)+".tgz")
+		if err = h.cacheChart(pulled, cached); err != nil {
+			return nil, fmt.Errorf("failed to cache the chart: %w", err)
+		}
+	}
+	return chartLoaded, nil
+}
+
+// isExactVersion reports whether a chart version is an exact semantic version, e.g. 1.2.3 but not ^1.2, ~2 or 1.2.
+func isExactVersion(version string) bool {
+	_, err := semver.StrictNewVersion(version)
+	return err == nil
+}
+
+// cacheChart copies a pulled chart archive into the chart cache, through a temporary file renamed into place
+// so that concurrent or interrupted pulls never leave a partially written archive in the cache.
+func (h *Helm) cacheChart(pulled string, cached string) error {
+	data, err := os.ReadFile(pulled)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(h.chartCache, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(h.chartCache, filepath.Base(cached)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cached)
+}
+
+// chartCacheKey identifies a chart reference and version in the chart cache.
+func chartCacheKey(chartReference string, version string) string {
+	sum := sha256.Sum256([]byte(chartReference + "@" + version))
+	return hex.EncodeToString(sum[:])
+}
+
+// defaultChartCache is the directory of the chart cache, in the user cache directory if available.
+func defaultChartCache() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "kubernetes-mcp-server", "helm", "charts")
+}
+
+func truncateReadme(readme string) string {
+	if len(readme) <= readmeMaxLength {
+		return readme
+	}
+	cut := readmeMaxLength
+	for cut > 0 && !utf8.RuneStart(readme[cut]) {
+		cut--
+	}
+	return readme[:cut] + fmt.Sprintf("\n\n[README truncated, %d more bytes]\n", len(readme)-cut)
+}
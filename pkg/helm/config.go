@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -15,6 +17,9 @@ import (
 type Config struct {
 	AllowedRegistries []string `toml:"allowed_registries,omitempty"`
 	StorageDriver     string   `toml:"storage_driver,omitempty"`
+	// Verify refuses to install, upgrade or render the charts whose provenance does not verify against the Keyring
+	Verify  bool   `toml:"verify,omitempty"`
+	Keyring string `toml:"keyring,omitempty"`
 }
 
 var _ api.ExtendedConfig = (*Config)(nil)
@@ -46,15 +51,31 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("unsupported Helm storage driver %q: must be \"secret\" or \"configmap\"", c.StorageDriver)
 		}
 	}
+	if c.Verify && c.Keyring == "" {
+		return fmt.Errorf("keyring is required when verify is enabled")
+	}
+	if c.Keyring != "" {
+		if _, err := os.Stat(c.Keyring); err != nil {
+			return fmt.Errorf("keyring %q cannot be read: %w", c.Keyring, err)
+		}
+	}
 
 	return nil
 }
 
-func helmToolsetParser(_ context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+func helmToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
 	var cfg Config
 	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
 		return nil, err
 	}
+
+	// If keyring is provided, resolve it relative to the config directory if it's a relative path
+	if cfg.Keyring != "" {
+		configDir := config.ConfigDirPathFromContext(ctx)
+		if configDir != "" && !filepath.IsAbs(cfg.Keyring) {
+			cfg.Keyring = filepath.Join(configDir, cfg.Keyring)
+		}
+	}
 	return &cfg, nil
 }
 
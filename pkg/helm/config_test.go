@@ -1,6 +1,8 @@
 package helm
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
@@ -102,6 +104,20 @@ func (s *ConfigSuite) TestValidate() {
 		s.Error(err)
 		s.Contains(err.Error(), "unsupported Helm storage driver")
 	})
+	s.Run("accepts verify with a keyring", func() {
+		keyring := filepath.Join(s.T().TempDir(), "pubring.gpg")
+		s.Require().NoError(os.WriteFile(keyring, []byte{}, 0o644))
+		cfg := &Config{Verify: true, Keyring: keyring}
+		s.NoError(cfg.Validate())
+	})
+	s.Run("rejects verify without keyring", func() {
+		cfg := &Config{Verify: true}
+		s.ErrorContains(cfg.Validate(), "keyring is required when verify is enabled")
+	})
+	s.Run("rejects missing keyring", func() {
+		cfg := &Config{Verify: true, Keyring: filepath.Join(s.T().TempDir(), "missing.gpg")}
+		s.ErrorContains(cfg.Validate(), "missing.gpg\" cannot be read")
+	})
 }
 
 func (s *ConfigSuite) TestParser() {
@@ -161,6 +177,29 @@ func (s *ConfigSuite) TestParser() {
 		s.Error(err)
 		s.Contains(err.Error(), "unsupported Helm storage driver")
 	})
+	s.Run("resolves relative keyring against the config directory", func() {
+		configDir := s.T().TempDir()
+		keyring := filepath.Join(configDir, "pubring.gpg")
+		s.Require().NoError(os.WriteFile(keyring, []byte{}, 0o644))
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.helm]
+			verify = true
+			keyring = "pubring.gpg"
+		`), config.WithDirPath(configDir)))
+		helmCfg, ok := cfg.GetToolsetConfig("helm")
+		s.Require().True(ok)
+		hc, ok := helmCfg.(*Config)
+		s.Require().True(ok)
+		s.Equal(keyring, hc.Keyring)
+	})
+	s.Run("rejects relative keyring missing from the config directory", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.helm]
+			verify = true
+			keyring = "pubring.gpg"
+		`), config.WithDirPath(s.T().TempDir()))
+		s.ErrorContains(err, "pubring.gpg\" cannot be read")
+	})
 }
 
 func TestConfig(t *testing.T) {
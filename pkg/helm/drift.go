@@ -0,0 +1,296 @@
+package helm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// releaseLabelSelector selects the objects carrying the labels of a release, set by the charts following the Helm best practices.
+const releaseLabelSelector = "app.kubernetes.io/managed-by=Helm,app.kubernetes.io/instance="
+
+// FieldDrift is a field of a release object whose live value differs from the value in the release manifest.
+type FieldDrift struct {
+	Path     string      `json:"path"`
+	Expected interface{} `json:"expected"`
+	Live     interface{} `json:"live"`
+}
+
+// ModifiedResource is a release object modified in the cluster.
+type ModifiedResource struct {
+	Resource string       `json:"resource"`
+	Fields   []FieldDrift `json:"fields"`
+}
+
+// driftReport is the drift of a release from its stored manifest, returned by Drift.
+type driftReport struct {
+	Release   string             `json:"release"`
+	Namespace string             `json:"namespace"`
+	Revision  int                `json:"revision"`
+	Summary   string             `json:"summary"`
+	Modified  []ModifiedResource `json:"modified,omitempty"`
+	Deleted   []string           `json:"deleted,omitempty"`
+	Extra     []string           `json:"extra,omitempty"`
+	// Unmappable are the objects of kinds the cluster does not serve, e.g. of a CRD removed after the release
+	Unmappable []string `json:"unmappable,omitempty"`
+}
+
+// Drift compares the objects of the stored manifest of a release with the live objects. Only the fields set in the manifest are
+// compared, so that the fields populated by the server are ignored. The extra objects are the objects of the kinds of the release
+// carrying its labels but missing from the manifest, the objects owned by another object (e.g. ReplicaSets) are ignored.
+func (h *Helm) Drift(ctx context.Context, name string, namespace string) (string, error) {
+	namespace = h.kubernetes.NamespaceOrDefault(namespace)
+	cfg, err := h.newAction(ctx, namespace, false)
+	if err != nil {
+		return "", err
+	}
+	rel, err := action.NewGet(cfg).Run(name)
+	if err != nil {
+		return "", err
+	}
+	restConfig, err := h.kubernetes.ToRESTConfig()
+	if err != nil {
+		return "", err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return "", err
+	}
+	mapper, err := h.kubernetes.ToRESTMapper()
+	if err != nil {
+		return "", err
+	}
+
+	report := driftReport{Release: rel.Name, Namespace: rel.Namespace, Revision: rel.Version}
+	known := map[string]bool{}
+	// kinds are the resources and namespaces of the release objects, to look for extra objects
+	kinds := map[schema.GroupVersionResource]map[string]bool{}
+	total := 0
+	for _, document := range releaseutil.SplitManifests(rel.Manifest) {
+		desired := map[string]interface{}{}
+		if err = yaml.Unmarshal([]byte(document), &desired); err != nil {
+			return "", fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(desired) == 0 {
+			continue
+		}
+		total++
+		apiVersion, _ := desired["apiVersion"].(string)
+		kind, _ := desired["kind"].(string)
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return "", err
+		}
+		metadata, _ := desired["metadata"].(map[string]interface{})
+		objName, _ := metadata["name"].(string)
+		mapping, err := mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+		if err != nil {
+			objNamespace, _ := metadata["namespace"].(string)
+			report.Unmappable = append(report.Unmappable, fmt.Sprintf("%s (%s): %v", objectKey(kind, objNamespace, objName), apiVersion, err))
+			continue
+		}
+		objNamespace := ""
+		var client dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if objNamespace, _ = metadata["namespace"].(string); objNamespace == "" {
+				objNamespace = rel.Namespace
+			}
+			client = dynamicClient.Resource(mapping.Resource).Namespace(objNamespace)
+		}
+		key := objectKey(kind, objNamespace, objName)
+		known[key] = true
+		if kinds[mapping.Resource] == nil {
+			kinds[mapping.Resource] = map[string]bool{}
+		}
+		kinds[mapping.Resource][objNamespace] = true
+
+		live, err := client.Get(ctx, objName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			report.Deleted = append(report.Deleted, key)
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("failed to get %s: %w", key, err)
+		}
+		fields, err := compareObject(desired, live.Object)
+		if err != nil {
+			return "", err
+		}
+		if len(fields) > 0 {
+			report.Modified = append(report.Modified, ModifiedResource{Resource: key, Fields: fields})
+		}
+	}
+
+	for gvr, namespaces := range kinds {
+		for ns := range namespaces {
+			list, err := dynamicClient.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{LabelSelector: releaseLabelSelector + rel.Name})
+			if err != nil {
+				return "", fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+			}
+			for _, item := range list.Items {
+				key := objectKey(item.GetKind(), item.GetNamespace(), item.GetName())
+				if !known[key] && len(item.GetOwnerReferences()) == 0 {
+					report.Extra = append(report.Extra, key)
+				}
+			}
+		}
+	}
+	slices.SortFunc(report.Modified, func(a, b ModifiedResource) int { return strings.Compare(a.Resource, b.Resource) })
+	slices.Sort(report.Deleted)
+	slices.Sort(report.Extra)
+	slices.Sort(report.Unmappable)
+
+	switch {
+	case len(report.Modified)+len(report.Deleted)+len(report.Extra) > 0:
+		report.Summary = fmt.Sprintf("Release %s has drifted from its manifest: %d of %d objects modified, %d deleted, %d extra objects carrying the release labels",
+			rel.Name, len(report.Modified), total, len(report.Deleted), len(report.Extra))
+	case len(report.Unmappable) > 0:
+		report.Summary = fmt.Sprintf("No drift found in the %d objects of release %s the cluster serves", total-len(report.Unmappable), rel.Name)
+	default:
+		report.Summary = fmt.Sprintf("No drift: the %d objects of release %s match the live objects", total, rel.Name)
+	}
+	if len(report.Unmappable) > 0 {
+		report.Summary += fmt.Sprintf(", %d objects of kinds the cluster does not serve could not be compared", len(report.Unmappable))
+	}
+	ret, err := yaml.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// objectKey identifies an object, e.g. "Deployment default/web".
+func objectKey(kind, namespace, name string) string {
+	if namespace != "" {
+		return kind + " " + namespace + "/" + name
+	}
+	return kind + " " + name
+}
+
+// compareObject returns the fields set in the desired object whose live value differs, the values of Secrets are redacted.
+func compareObject(desired map[string]interface{}, live map[string]interface{}) ([]FieldDrift, error) {
+	// Compare the JSON representations so that the numbers of both objects have the same type
+	data, err := json.Marshal(live)
+	if err != nil {
+		return nil, err
+	}
+	normalized := map[string]interface{}{}
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	desired = maps.Clone(desired)
+	delete(desired, "status")
+	isSecret := desired["kind"] == "Secret"
+	if stringData, ok := desired["stringData"].(map[string]interface{}); ok && isSecret {
+		// The API server merges the stringData into the data of Secrets
+		merged := map[string]interface{}{}
+		if d, ok := desired["data"].(map[string]interface{}); ok {
+			maps.Copy(merged, d)
+		}
+		for k, v := range stringData {
+			merged[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(v)))
+		}
+		desired["data"] = merged
+		delete(desired, "stringData")
+	}
+	var drifts []FieldDrift
+	compareValue("", desired, normalized, &drifts)
+	if isSecret {
+		for i := range drifts {
+			if drifts[i].Path == "data" || strings.HasPrefix(drifts[i].Path, "data.") || strings.HasPrefix(drifts[i].Path, "data[") {
+				drifts[i].Expected, drifts[i].Live = redacted, redacted
+			}
+		}
+	}
+	return drifts, nil
+}
+
+func compareValue(path string, desired interface{}, live interface{}, drifts *[]FieldDrift) {
+	switch d := desired.(type) {
+	case nil:
+		// Null values in the manifest are dropped by the API server
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+		if len(d) > 0 && l == nil {
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: d, Live: live})
+			return
+		}
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			compareValue(fieldPath(path, k), d[k], l[k], drifts)
+		}
+	case []interface{}:
+		l, _ := live.([]interface{})
+		if len(d) != len(l) {
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: d, Live: live})
+			return
+		}
+		for i := range d {
+			compareValue(path+"["+strconv.Itoa(i)+"]", d[i], l[i], drifts)
+		}
+	default:
+		if !equalValues(desired, live) {
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: desired, Live: live})
+		}
+	}
+}
+
+// equalValues compares two scalar values, the quantities are compared by value, e.g. 0.5 and 500m are equal.
+func equalValues(desired interface{}, live interface{}) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	if live == nil {
+		// The API server omits the empty values
+		return desired == "" || desired == false || desired == float64(0)
+	}
+	liveString, ok := live.(string)
+	if !ok {
+		return false
+	}
+	var desiredString string
+	switch d := desired.(type) {
+	case string:
+		desiredString = d
+	case float64:
+		desiredString = strconv.FormatFloat(d, 'f', -1, 64)
+	default:
+		return false
+	}
+	desiredQuantity, err := resource.ParseQuantity(desiredString)
+	if err != nil {
+		return false
+	}
+	liveQuantity, err := resource.ParseQuantity(liveString)
+	return err == nil && desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+// fieldPath appends a key to a field path, keys containing dots are quoted, e.g. metadata.labels["app.kubernetes.io/name"].
+func fieldPath(path string, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
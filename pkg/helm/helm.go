@@ -2,13 +2,21 @@ package helm
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/url"
+	"os"
 	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/registry"
@@ -27,11 +35,13 @@ type Kubernetes interface {
 type Helm struct {
 	kubernetes Kubernetes
 	config     *Config
+	// chartCache is the directory where the charts pulled by Show are cached
+	chartCache string
 }
 
 // NewHelm creates a new Helm instance
 func NewHelm(kubernetes Kubernetes, config *Config) *Helm {
-	return &Helm{kubernetes: kubernetes, config: config}
+	return &Helm{kubernetes: kubernetes, config: config, chartCache: defaultChartCache()}
 }
 
 func (h *Helm) Install(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string) (string, error) {
@@ -54,26 +64,275 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 	install.Timeout = 5 * time.Minute
 	install.DryRun = false
 
-	chartRequested, err := install.LocateChart(chart, cli.New())
+	chartLoaded, err := h.loadChart(&install.ChartPathOptions, chart)
 	if err != nil {
 		return "", err
 	}
-	chartLoaded, err := loader.Load(chartRequested)
+
+	installedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
 	if err != nil {
 		return "", err
 	}
+	ret, err := yaml.Marshal(simplify(installedRelease))
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
 
-	installedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
+// UpgradeOptions holds the options of a release upgrade.
+type UpgradeOptions struct {
+	// Version is the chart version to upgrade to, latest if empty
+	Version string
+	// ReuseValues merges the provided values into the values of the current release
+	ReuseValues bool
+	// ResetValues discards the values of the current release and only uses the chart defaults and the provided values
+	ResetValues bool
+	// Atomic rolls the release back to the previous revision if the upgrade fails, it implies Wait
+	Atomic bool
+	Wait   bool
+	// Timeout bounds the wait for the upgraded resources to be ready
+	Timeout time.Duration
+	// Preview renders the upgrade without applying it and returns the diff against the current release manifest
+	Preview bool
+}
+
+// upgradePreview is the result of an upgrade preview.
+type upgradePreview struct {
+	Release map[string]interface{} `json:"release"`
+	Summary string                 `json:"summary"`
+	Changes []ResourceDiff         `json:"changes,omitempty"`
+}
+
+// Upgrade upgrades a release to the provided chart and values, or previews the upgrade if opts.Preview is set.
+func (h *Helm) Upgrade(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string, opts UpgradeOptions) (string, error) {
+	if err := validateChartReference(chart, h.config); err != nil {
+		return "", err
+	}
+	if opts.ReuseValues && opts.ResetValues {
+		return "", fmt.Errorf("reuse_values and reset_values are mutually exclusive")
+	}
+	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
 	if err != nil {
 		return "", err
 	}
-	ret, err := yaml.Marshal(simplify(installedRelease))
+	var current *release.Release
+	if opts.Preview {
+		if current, err = action.NewGet(cfg).Run(name); err != nil {
+			return "", err
+		}
+	}
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+	upgrade.Version = opts.Version
+	upgrade.ReuseValues = opts.ReuseValues
+	upgrade.ResetValues = opts.ResetValues
+	upgrade.Atomic = opts.Atomic
+	upgrade.Wait = opts.Wait
+	upgrade.Timeout = opts.Timeout
+	if upgrade.Timeout == 0 {
+		upgrade.Timeout = 5 * time.Minute
+	}
+	upgrade.DryRun = opts.Preview
+
+	chartLoaded, err := h.loadChart(&upgrade.ChartPathOptions, chart)
+	if err != nil {
+		return "", err
+	}
+
+	upgradedRelease, err := upgrade.RunWithContext(ctx, name, chartLoaded, values)
+	if err != nil {
+		return "", err
+	}
+	if !opts.Preview {
+		ret, err := yaml.Marshal(simplify(upgradedRelease))
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	}
+
+	changes, unchanged, err := diffManifests(current.Manifest, upgradedRelease.Manifest)
+	if err != nil {
+		return "", err
+	}
+	preview := upgradePreview{Release: simplify(upgradedRelease)[0], Changes: changes}
+	counts := map[string]int{}
+	for _, c := range changes {
+		counts[c.Change]++
+	}
+	preview.Summary = fmt.Sprintf("Preview of the upgrade of release %s from revision %d: %d added, %d changed, %d removed, %d unchanged resources. Nothing was applied",
+		name, current.Version, counts["added"], counts["changed"], counts["removed"], unchanged)
+	ret, err := yaml.Marshal(preview)
 	if err != nil {
 		return "", err
 	}
 	return string(ret), nil
 }
 
+// TemplateOptions holds the options of a chart rendering.
+type TemplateOptions struct {
+	// Version is the chart version to render, latest if empty
+	Version string
+	// ServerSide renders the chart in server-side dry-run mode, so that the lookup functions query the cluster
+	ServerSide bool
+	// Summary returns the rendered resources grouped by kind instead of the manifests
+	Summary bool
+}
+
+// templateSummary is the per-kind summary of a rendered chart.
+type templateSummary struct {
+	Release   string        `json:"release"`
+	Namespace string        `json:"namespace"`
+	Chart     string        `json:"chart"`
+	Resources []KindSummary `json:"resources"`
+	Hooks     []KindSummary `json:"hooks,omitempty"`
+}
+
+// Template renders a chart with the provided values without installing it, the values are validated against the
+// values.schema.json of the chart. The data of the rendered Secrets is redacted.
+func (h *Helm) Template(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string, opts TemplateOptions) (string, error) {
+	if err := validateChartReference(chart, h.config); err != nil {
+		return "", err
+	}
+	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	install := action.NewInstall(cfg)
+	install.ReleaseName = name
+	if install.ReleaseName == "" {
+		install.ReleaseName = "release-name"
+	}
+	install.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+	install.Version = opts.Version
+	install.DryRun = true
+	install.DryRunOption = "client"
+	install.ClientOnly = !opts.ServerSide
+	if opts.ServerSide {
+		install.DryRunOption = "server"
+	}
+	// Skip the release name check, like helm template
+	install.Replace = true
+	install.IncludeCRDs = true
+
+	chartLoaded, err := h.loadChart(&install.ChartPathOptions, chart)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := install.RunWithContext(ctx, chartLoaded, values)
+	if err != nil {
+		return "", err
+	}
+	var hooks strings.Builder
+	for _, hook := range rendered.Hooks {
+		_, _ = fmt.Fprintf(&hooks, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+	if !opts.Summary {
+		ret, err := maskManifest(rendered.Manifest + hooks.String())
+		if err != nil {
+			return "", err
+		}
+		if ret == "" {
+			return fmt.Sprintf("Chart %s rendered no resources", chart), nil
+		}
+		return ret, nil
+	}
+
+	summary := templateSummary{Release: rendered.Name, Namespace: rendered.Namespace, Chart: rendered.Chart.Metadata.Name + "-" + rendered.Chart.Metadata.Version}
+	if summary.Resources, err = summarizeManifest(rendered.Manifest); err != nil {
+		return "", err
+	}
+	if summary.Hooks, err = summarizeManifest(hooks.String()); err != nil {
+		return "", err
+	}
+	ret, err := yaml.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// The parts of a release returned by Get.
+const (
+	GetValues   = "values"
+	GetManifest = "manifest"
+	GetNotes    = "notes"
+	GetHooks    = "hooks"
+)
+
+// Get returns the values, manifest, notes or hooks of a release at the provided revision, or at the latest revision if 0.
+// The credentials found in the values and the data of the Secrets are redacted.
+func (h *Helm) Get(ctx context.Context, name string, namespace string, what string, revision int, allValues bool) (string, error) {
+	if !slices.Contains([]string{GetValues, GetManifest, GetNotes, GetHooks}, what) {
+		return "", fmt.Errorf("invalid value %q to get, must be one of %s, %s, %s or %s", what, GetValues, GetManifest, GetNotes, GetHooks)
+	}
+	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	if what == GetValues {
+		getValues := action.NewGetValues(cfg)
+		getValues.Version = revision
+		getValues.AllValues = allValues
+		values, err := getValues.Run(name)
+		if err != nil {
+			return "", err
+		}
+		if len(values) == 0 && allValues {
+			return fmt.Sprintf("Release %s has no values", name), nil
+		} else if len(values) == 0 {
+			return fmt.Sprintf("Release %s has no user-supplied values", name), nil
+		}
+		ret, err := yaml.Marshal(maskValues(values))
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	}
+
+	get := action.NewGet(cfg)
+	get.Version = revision
+	rel, err := get.Run(name)
+	if err != nil {
+		return "", err
+	}
+	switch what {
+	case GetNotes:
+		if rel.Info == nil || rel.Info.Notes == "" {
+			return fmt.Sprintf("Release %s has no notes", name), nil
+		}
+		return rel.Info.Notes, nil
+	case GetHooks:
+		if len(rel.Hooks) == 0 {
+			return fmt.Sprintf("Release %s has no hooks", name), nil
+		}
+		hooks := make([]string, 0, len(rel.Hooks))
+		for _, hook := range rel.Hooks {
+			manifest, err := maskManifest(hook.Manifest)
+			if err != nil {
+				return "", err
+			}
+			events := make([]string, 0, len(hook.Events))
+			for _, event := range hook.Events {
+				events = append(events, event.String())
+			}
+			header := fmt.Sprintf("# Source: %s\n# Events: %s", hook.Path, strings.Join(events, ", "))
+			if hook.LastRun.Phase != "" {
+				header += "\n# Last run: " + hook.LastRun.Phase.String()
+			}
+			hooks = append(hooks, header+"\n"+strings.TrimPrefix(manifest, "---\n"))
+		}
+		return "---\n" + strings.Join(hooks, "\n---\n"), nil
+	default:
+		if strings.TrimSpace(rel.Manifest) == "" {
+			return fmt.Sprintf("Release %s has no manifest", name), nil
+		}
+		return maskManifest(rel.Manifest)
+	}
+}
+
 // List lists all the releases for the specified namespace (or current namespace if). Or allNamespaces is true, it lists all releases across all namespaces.
 func (h *Helm) List(ctx context.Context, namespace string, allNamespaces bool) (string, error) {
 	cfg, err := h.newAction(ctx, namespace, allNamespaces)
@@ -95,6 +354,34 @@ func (h *Helm) List(ctx context.Context, namespace string, allNamespaces bool) (
 	return string(ret), nil
 }
 
+// ReleaseManifest is the rendered manifest of the deployed revision of a Helm release.
+type ReleaseManifest struct {
+	Name      string
+	Namespace string
+	Revision  int
+	Manifest  string
+}
+
+// ReleaseManifests returns the rendered manifests of the deployed releases in the specified namespace (or current namespace if empty),
+// or across all namespaces if allNamespaces is true.
+func (h *Helm) ReleaseManifests(ctx context.Context, namespace string, allNamespaces bool) ([]ReleaseManifest, error) {
+	cfg, err := h.newAction(ctx, namespace, allNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	list := action.NewList(cfg)
+	list.AllNamespaces = allNamespaces
+	releases, err := list.Run()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]ReleaseManifest, 0, len(releases))
+	for _, r := range releases {
+		ret = append(ret, ReleaseManifest{Name: r.Name, Namespace: r.Namespace, Revision: r.Version, Manifest: r.Manifest})
+	}
+	return ret, nil
+}
+
 func (h *Helm) Uninstall(ctx context.Context, name string, namespace string) (string, error) {
 	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
 	if err != nil {
@@ -113,6 +400,62 @@ func (h *Helm) Uninstall(ctx context.Context, name string, namespace string) (st
 	return fmt.Sprintf("Uninstalled release %s %s", uninstalledRelease.Release.Name, uninstalledRelease.Info), nil
 }
 
+// History lists the revisions of a release, the most recent first, limited to max revisions when max is positive.
+func (h *Helm) History(ctx context.Context, name string, namespace string, max int) (string, error) {
+	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	revisions, err := action.NewHistory(cfg).Run(name)
+	if err != nil {
+		return "", err
+	}
+	ret, err := yaml.Marshal(history(revisions, max))
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// RollbackOptions holds the options of a release rollback.
+type RollbackOptions struct {
+	// Revision is the revision to roll back to, the previous revision if 0
+	Revision int
+	Wait     bool
+	// CleanupOnFail deletes the resources created by the rollback if it fails
+	CleanupOnFail bool
+	// Timeout bounds the wait for the rolled back resources to be ready
+	Timeout time.Duration
+}
+
+// Rollback rolls a release back to a previous revision, creating a new revision of the release.
+func (h *Helm) Rollback(ctx context.Context, name string, namespace string, opts RollbackOptions) (string, error) {
+	cfg, err := h.newAction(ctx, h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	rollback := action.NewRollback(cfg)
+	rollback.Version = opts.Revision
+	rollback.Wait = opts.Wait
+	rollback.CleanupOnFail = opts.CleanupOnFail
+	rollback.Timeout = opts.Timeout
+	if rollback.Timeout == 0 {
+		rollback.Timeout = 5 * time.Minute
+	}
+	if err = rollback.Run(name); err != nil {
+		return "", err
+	}
+	rolledBack, err := action.NewGet(cfg).Run(name)
+	if err != nil {
+		return "", err
+	}
+	target := "the previous revision"
+	if opts.Revision > 0 {
+		target = fmt.Sprintf("revision %d", opts.Revision)
+	}
+	return fmt.Sprintf("Rolled back release %s to %s, the release is now at revision %d (%s)", name, target, rolledBack.Version, rolledBack.Info.Status), nil
+}
+
 func (h *Helm) newAction(ctx context.Context, namespace string, allNamespaces bool) (*action.Configuration, error) {
 	storageDriver := ""
 	if h.config != nil {
@@ -136,6 +479,141 @@ func (h *Helm) newAction(ctx context.Context, namespace string, allNamespaces bo
 	})
 }
 
+// loadChart locates and loads a chart, verifying its provenance against the keyring when verify is configured.
+func (h *Helm) loadChart(opts *action.ChartPathOptions, chartReference string) (*chart.Chart, error) {
+	if h.config != nil && h.config.Verify {
+		opts.Verify = true
+		opts.Keyring = h.config.Keyring
+	}
+	settings := cli.New()
+	cached := cachedProvenanceFiles(chartReference, settings.RepositoryCache)
+	chartPath, err := opts.LocateChart(chartReference, settings)
+	if err != nil && opts.Verify {
+		provenanceFile := pulledProvenanceFile(chartReference, opts.Version, settings.RepositoryCache, cached)
+		return nil, verificationError(opts.Keyring, chartReference, provenanceFile, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return loader.Load(chartPath)
+}
+
+// verificationError describes a chart refused by the provenance verification, naming the key that signed it
+// when its provenance file is available.
+func verificationError(keyring, chartReference, provenanceFile string, err error) error {
+	if provenanceFile != "" {
+		if key, keyErr := signingKey(provenanceFile); keyErr == nil {
+			return fmt.Errorf("chart %q signed by key %s could not be verified against the keyring %s: %w", chartReference, key, keyring, err)
+		}
+	}
+	return fmt.Errorf("chart %q could not be verified against the keyring %s: %w", chartReference, keyring, err)
+}
+
+// cachedProvenanceFiles lists the provenance files of a remote chart present in the repository cache.
+func cachedProvenanceFiles(chartReference, repositoryCache string) map[string]os.FileInfo {
+	files := map[string]os.FileInfo{}
+	if _, err := os.Stat(chartReference); err == nil {
+		return files
+	}
+	entries, err := os.ReadDir(repositoryCache)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isChartProvenanceFile(chartReference, entry.Name()) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			files[filepath.Join(repositoryCache, entry.Name())] = info
+		}
+	}
+	return files
+}
+
+// chartArchiveName returns the name of a remote chart reference without its version, e.g. "nginx" for "bitnami/nginx"
+// or "oci://ghcr.io/charts/nginx:1.2.3", and the name of the archive for the references to an archive URL.
+func chartArchiveName(chartReference string) string {
+	name := path.Base(chartReference)
+	if strings.HasPrefix(chartReference, "oci://") {
+		if i := strings.LastIndexByte(name, ':'); i > 0 {
+			name = name[:i]
+		}
+	}
+	return strings.TrimSuffix(name, ".tgz")
+}
+
+// isChartProvenanceFile reports whether a file of the repository cache is the provenance of a version of a chart,
+// named "<name>-<version>.tgz.prov" as pulled by Helm, or "<archive>.tgz.prov" for the references to an archive URL.
+func isChartProvenanceFile(chartReference, file string) bool {
+	name := chartArchiveName(chartReference)
+	stem, ok := strings.CutSuffix(file, ".tgz.prov")
+	if !ok {
+		return false
+	}
+	if stem == name {
+		return true
+	}
+	version, ok := strings.CutPrefix(stem, name+"-")
+	if !ok {
+		return false
+	}
+	_, err := semver.NewVersion(version)
+	return err == nil
+}
+
+// pulledProvenanceFile returns the provenance file read by the verification of a chart: the one next to a local chart,
+// or for remote charts the one the verified pull wrote into the repository cache, which Helm replaces atomically.
+// The file of the requested version is preferred; when it cannot be told which of several changed files was pulled,
+// e.g. for concurrent pulls of other versions, none is returned.
+// The repository cache is shared with the helm CLI and only read.
+func pulledProvenanceFile(chartReference, version, repositoryCache string, cached map[string]os.FileInfo) string {
+	if _, err := os.Stat(chartReference); err == nil {
+		return chartReference + ".prov"
+	}
+	var changed []string
+	for file, info := range cachedProvenanceFiles(chartReference, repositoryCache) {
+		if previous, ok := cached[file]; !ok || !os.SameFile(previous, info) {
+			changed = append(changed, file)
+		}
+	}
+	if version != "" {
+		requested := filepath.Join(repositoryCache, chartArchiveName(chartReference)+"-"+strings.TrimPrefix(version, "v")+".tgz.prov")
+		if slices.Contains(changed, requested) {
+			return requested
+		}
+	}
+	if len(changed) == 1 {
+		return changed[0]
+	}
+	return ""
+}
+
+// signingKey returns the fingerprint, or the key ID for signatures without fingerprint, of the key that signed a provenance file.
+func signingKey(provenanceFile string) (string, error) {
+	data, err := os.ReadFile(provenanceFile)
+	if err != nil {
+		return "", err
+	}
+	block, _ := clearsign.Decode(data)
+	if block == nil || block.ArmoredSignature == nil {
+		return "", fmt.Errorf("no signature found in %s", provenanceFile)
+	}
+	p, err := packet.Read(block.ArmoredSignature.Body)
+	if err != nil {
+		return "", err
+	}
+	signature, ok := p.(*packet.Signature)
+	switch {
+	case !ok:
+		return "", fmt.Errorf("no signature found in %s", provenanceFile)
+	case len(signature.IssuerFingerprint) > 0:
+		return strings.ToUpper(hex.EncodeToString(signature.IssuerFingerprint)), nil
+	case signature.IssuerKeyId != nil:
+		return fmt.Sprintf("%016X", *signature.IssuerKeyId), nil
+	default:
+		return "", fmt.Errorf("no signing key found in %s", provenanceFile)
+	}
+}
+
 // validateChartReference blocks chart references using dangerous URL schemes.
 // Only oci:// and https:// URLs are allowed. Non-URL references (e.g. "stable/grafana")
 // are permitted as they resolve through Helm's local repo configuration.
@@ -175,6 +653,31 @@ func validateChartReference(chart string, cfg *Config) error {
 	}
 }
 
+// history describes the revisions of a release, the most recent first, limited to max revisions when max is positive.
+func history(revisions []*release.Release, max int) []map[string]interface{} {
+	sorted := slices.Clone(revisions)
+	slices.SortFunc(sorted, func(a, b *release.Release) int { return b.Version - a.Version })
+	if max > 0 && len(sorted) > max {
+		sorted = sorted[:max]
+	}
+	ret := make([]map[string]interface{}, len(sorted))
+	for i, r := range sorted {
+		ret[i] = map[string]interface{}{"revision": r.Version}
+		if r.Chart != nil && r.Chart.Metadata != nil {
+			ret[i]["chart"] = r.Chart.Metadata.Name + "-" + r.Chart.Metadata.Version
+			ret[i]["appVersion"] = r.Chart.Metadata.AppVersion
+		}
+		if r.Info != nil {
+			ret[i]["status"] = r.Info.Status.String()
+			ret[i]["description"] = r.Info.Description
+			if !r.Info.LastDeployed.IsZero() {
+				ret[i]["updated"] = r.Info.LastDeployed.Format(time.RFC1123Z)
+			}
+		}
+	}
+	return ret
+}
+
 func simplify(release ...*release.Release) []map[string]interface{} {
 	ret := make([]map[string]interface{}, len(release))
 	for i, r := range release {
@@ -0,0 +1,262 @@
+package helm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+)
+
+// redacted replaces the sensitive values, e.g. the data of Secrets, in the returned manifests.
+const redacted = "[REDACTED]"
+
+// ResourceDiff is the change of a resource between two rendered manifests of a release.
+type ResourceDiff struct {
+	Resource string `json:"resource"`
+	Change   string `json:"change"`
+	Diff     string `json:"diff,omitempty"`
+}
+
+// manifestResource is a resource of a rendered manifest.
+type manifestResource struct {
+	// masked is the normalized YAML of the resource with the Secret values redacted
+	masked string
+	// normalized is the normalized YAML of the resource
+	normalized string
+}
+
+// splitManifest splits a rendered manifest into its resources, keyed by kind, namespace and name.
+func splitManifest(manifest string) (map[string]manifestResource, error) {
+	ret := map[string]manifestResource{}
+	for _, document := range releaseutil.SplitManifests(manifest) {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		normalized, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		masked, err := yaml.Marshal(maskSecret(obj))
+		if err != nil {
+			return nil, err
+		}
+		ret[resourceKey(obj)] = manifestResource{masked: string(masked), normalized: string(normalized)}
+	}
+	return ret, nil
+}
+
+// resourceKey identifies a resource of a manifest, e.g. "Deployment default/web".
+func resourceKey(obj map[string]interface{}) string {
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if namespace, _ := metadata["namespace"].(string); namespace != "" {
+		return kind + " " + namespace + "/" + name
+	}
+	return kind + " " + name
+}
+
+// maskSecret returns a copy of the object with the values of the data and stringData of a Secret redacted, other objects are returned as is.
+func maskSecret(obj map[string]interface{}) map[string]interface{} {
+	if obj["kind"] != "Secret" {
+		return obj
+	}
+	ret := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		ret[k] = v
+	}
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := obj[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		masked := make(map[string]interface{}, len(data))
+		for k := range data {
+			masked[k] = redacted
+		}
+		ret[field] = masked
+	}
+	return ret
+}
+
+// diffManifests returns the resources added, removed and changed between the current and the target manifests of a release,
+// and the number of unchanged resources. Secret values are never included in the diff.
+func diffManifests(current, target string) ([]ResourceDiff, int, error) {
+	currentResources, err := splitManifest(current)
+	if err != nil {
+		return nil, 0, err
+	}
+	targetResources, err := splitManifest(target)
+	if err != nil {
+		return nil, 0, err
+	}
+	keys := make([]string, 0, len(currentResources)+len(targetResources))
+	for key := range currentResources {
+		keys = append(keys, key)
+	}
+	for key := range targetResources {
+		if _, ok := currentResources[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var diffs []ResourceDiff
+	unchanged := 0
+	for _, key := range keys {
+		before, inCurrent := currentResources[key]
+		after, inTarget := targetResources[key]
+		switch {
+		case !inCurrent:
+			diffs = append(diffs, ResourceDiff{Resource: key, Change: "added"})
+		case !inTarget:
+			diffs = append(diffs, ResourceDiff{Resource: key, Change: "removed"})
+		case before.normalized == after.normalized:
+			unchanged++
+		case before.masked == after.masked:
+			diffs = append(diffs, ResourceDiff{Resource: key, Change: "changed", Diff: "Secret values changed (" + redacted + ")"})
+		default:
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(before.masked),
+				B:        difflib.SplitLines(after.masked),
+				FromFile: "current",
+				ToFile:   "upgrade",
+				Context:  3,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			diffs = append(diffs, ResourceDiff{Resource: key, Change: "changed", Diff: strings.TrimSuffix(diff, "\n")})
+		}
+	}
+	return diffs, unchanged, nil
+}
+
+// maskManifest redacts the data of the Secrets of a rendered manifest, the other resources are returned as is.
+func maskManifest(manifest string) (string, error) {
+	documents := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(documents))
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+	ret := make([]string, 0, len(keys))
+	for _, key := range keys {
+		document := documents[key]
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil {
+			return "", fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if obj["kind"] == "Secret" {
+			masked, err := yaml.Marshal(maskSecret(obj))
+			if err != nil {
+				return "", err
+			}
+			// Keep the "# Source:" comments of the document
+			var comments []string
+			for _, line := range strings.Split(document, "\n") {
+				if !strings.HasPrefix(line, "#") {
+					break
+				}
+				comments = append(comments, line)
+			}
+			document = strings.Join(append(comments, strings.TrimSuffix(string(masked), "\n")), "\n")
+		}
+		ret = append(ret, "---\n"+strings.TrimSpace(document))
+	}
+	return strings.Join(ret, "\n"), nil
+}
+
+// sensitiveValueKey matches the keys of the values holding credentials, e.g. password, apiToken, tls.key, DB_PASS or an auth block.
+var sensitiveValueKey = regexp.MustCompile(`(?i)(password|passwd|passphrase|secret|token|api_?key|access_?key|private_?key|credential|auth(entication)?$|(^|[_.-])pass$|\.key$|^key$)`)
+
+// maskValues returns a copy of release values with the credentials redacted: every value under a sensitive key,
+// including the whole content of sensitive maps and lists, and the data of the Secrets embedded in the values, e.g. in an extraObjects list.
+func maskValues(values map[string]interface{}) map[string]interface{} {
+	ret, _ := maskValue("", values, false).(map[string]interface{})
+	return ret
+}
+
+// maskValue redacts the value of a key, sensitive is set when the value is nested under a sensitive key.
+func maskValue(key string, value interface{}, sensitive bool) interface{} {
+	// Keys such as existingSecret reference a Secret by name and are kept
+	if strings.HasPrefix(strings.ToLower(key), "existing") {
+		return value
+	}
+	sensitive = sensitive || sensitiveValueKey.MatchString(key)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, nested := range v {
+			ret[k] = maskValue(k, nested, sensitive)
+		}
+		return maskSecret(ret)
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, nested := range v {
+			ret[i] = maskValue(key, nested, sensitive)
+		}
+		return ret
+	case nil:
+		return v
+	case string:
+		// Empty values are kept, they show that the credential is not set
+		if v != "" && sensitive {
+			return redacted
+		}
+		return v
+	default:
+		if sensitive {
+			return redacted
+		}
+		return v
+	}
+}
+
+// KindSummary lists the resources of a kind of a rendered manifest.
+type KindSummary struct {
+	Kind      string   `json:"kind"`
+	Count     int      `json:"count"`
+	Resources []string `json:"resources"`
+}
+
+// summarizeManifest groups the resources of a rendered manifest by kind, sorted by kind, keeping the manifest order of the resources.
+func summarizeManifest(manifest string) ([]KindSummary, error) {
+	documents := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(documents))
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+	kinds := map[string]*KindSummary{}
+	for _, key := range keys {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(documents[key]), &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		kind, _ := obj["kind"].(string)
+		if kinds[kind] == nil {
+			kinds[kind] = &KindSummary{Kind: kind}
+		}
+		kinds[kind].Count++
+		kinds[kind].Resources = append(kinds[kind].Resources, strings.TrimPrefix(resourceKey(obj), kind+" "))
+	}
+	ret := make([]KindSummary, 0, len(kinds))
+	for _, k := range kinds {
+		ret = append(ret, *k)
+	}
+	slices.SortFunc(ret, func(a, b KindSummary) int { return strings.Compare(a.Kind, b.Kind) })
+	return ret, nil
+}
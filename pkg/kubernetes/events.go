@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	v1 "k8s.io/api/core/v1"
@@ -28,26 +29,37 @@ func (c *Core) EventsList(ctx context.Context, namespace string, options api.Lis
 		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
 			return eventMap, err
 		}
-		timestamp := event.EventTime.Time
-		if timestamp.IsZero() && event.Series != nil {
-			timestamp = event.Series.LastObservedTime.Time
-		} else if timestamp.IsZero() && event.Count > 1 {
-			timestamp = event.LastTimestamp.Time
-		} else if timestamp.IsZero() {
-			timestamp = event.FirstTimestamp.Time
-		}
-		eventMap = append(eventMap, map[string]any{
-			"Namespace": event.Namespace,
-			"Timestamp": timestamp.String(),
-			"Type":      event.Type,
-			"Reason":    event.Reason,
-			"InvolvedObject": map[string]string{
-				"apiVersion": event.InvolvedObject.APIVersion,
-				"Kind":       event.InvolvedObject.Kind,
-				"Name":       event.InvolvedObject.Name,
-			},
-			"Message": strings.TrimSpace(event.Message),
-		})
+		eventMap = append(eventMap, EventMap(event))
 	}
 	return eventMap, nil
 }
+
+// EventMap returns the fields of an event reported by the tools, with the timestamp from EventTimestamp.
+func EventMap(event *v1.Event) map[string]any {
+	return map[string]any{
+		"Namespace": event.Namespace,
+		"Timestamp": EventTimestamp(event).String(),
+		"Type":      event.Type,
+		"Reason":    event.Reason,
+		"InvolvedObject": map[string]string{
+			"apiVersion": event.InvolvedObject.APIVersion,
+			"Kind":       event.InvolvedObject.Kind,
+			"Name":       event.InvolvedObject.Name,
+		},
+		"Message": strings.TrimSpace(event.Message),
+	}
+}
+
+// EventTimestamp returns the timestamp of an event: its EventTime, or for the events that only set the legacy
+// fields, the last observed time of its series, its last timestamp when repeated or else its first timestamp.
+func EventTimestamp(event *v1.Event) time.Time {
+	timestamp := event.EventTime.Time
+	if timestamp.IsZero() && event.Series != nil {
+		timestamp = event.Series.LastObservedTime.Time
+	} else if timestamp.IsZero() && event.Count > 1 {
+		timestamp = event.LastTimestamp.Time
+	} else if timestamp.IsZero() {
+		timestamp = event.FirstTimestamp.Time
+	}
+	return timestamp
+}
@@ -0,0 +1,53 @@
+package loki
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+const (
+	// DefaultLokiStackNamespace and DefaultLokiStackName identify the LokiStack deployed by OpenShift Logging.
+	DefaultLokiStackNamespace = "openshift-logging"
+	DefaultLokiStackName      = "logging-loki"
+)
+
+// Config holds Loki toolset configuration.
+// The url is the Loki HTTP API or the LokiStack gateway, the LokiStack gateway Route is discovered on OpenShift when empty.
+type Config struct {
+	observability.Config
+	// Gateway is true when Url points to a LokiStack gateway, queries are then sent to the tenant selected by the tools.
+	Gateway bool `toml:"gateway,omitempty"`
+	// LokiStackNamespace and LokiStackName identify the LokiStack whose gateway Route is discovered on OpenShift.
+	LokiStackNamespace string `toml:"lokistack_namespace,omitempty"`
+	LokiStackName      string `toml:"lokistack_name,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("loki config is nil")
+	}
+	return c.Config.Validate()
+}
+
+func lokiToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Parse(ctx, "Loki"); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("loki", lokiToolsetParser)
+}
@@ -0,0 +1,132 @@
+package loki
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+	tempDir string
+	caFile  string
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.tempDir = s.T().TempDir()
+	s.caFile = filepath.Join(s.tempDir, "ca.crt")
+	s.Require().NoError(os.WriteFile(s.caFile, []byte("test ca content"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "token"), []byte("file-token"), 0600))
+}
+
+func (s *ConfigSuite) lokiConfig(cfg *config.StaticConfig) *Config {
+	lokiCfg, ok := cfg.GetToolsetConfig("loki")
+	s.Require().True(ok, "Loki config should be present")
+	lcfg, ok := lokiCfg.(*Config)
+	s.Require().True(ok, "Loki config should be of type *Config")
+	return lcfg
+}
+
+func (s *ConfigSuite) TestConfigParser_ResolvesRelativePaths() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.loki]
+		url = "https://loki.example/"
+		certificate_authority = "ca.crt"
+		bearer_token_file = "token"
+	`), config.WithDirPath(s.tempDir)))
+
+	lcfg := s.lokiConfig(cfg)
+	s.Equal(s.caFile, lcfg.CertificateAuthority)
+	s.Equal(filepath.Join(s.tempDir, "token"), lcfg.BearerTokenFile)
+}
+
+func (s *ConfigSuite) TestConfigParser_AllowsEmptyURL() {
+	// The URL is discovered from the LokiStack gateway Route on OpenShift
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.loki]
+	`)))
+
+	s.Empty(s.lokiConfig(cfg).Url)
+}
+
+func (s *ConfigSuite) TestConfigParser_LokiStack() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.loki]
+		url = "http://loki-gateway.example/"
+		gateway = true
+		lokistack_namespace = "logging"
+		lokistack_name = "lokistack"
+	`)))
+
+	lcfg := s.lokiConfig(cfg)
+	s.True(lcfg.Gateway)
+	s.Equal("logging", lcfg.LokiStackNamespace)
+	s.Equal("lokistack", lcfg.LokiStackName)
+}
+
+func (s *ConfigSuite) TestConfigParser_RejectsInvalidConfig() {
+	s.Run("invalid URL", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.loki]
+			url = "not-a-url"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "url must be a valid URL")
+	})
+	s.Run("https without certificate_authority", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.loki]
+			url = "https://loki.example/"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "certificate_authority is required for https when insecure is false")
+	})
+	s.Run("missing bearer_token_file", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.loki]
+			url = "http://loki.example/"
+			bearer_token_file = "missing-token"
+		`), config.WithDirPath(s.tempDir))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "bearer_token_file must be a valid file path")
+	})
+}
+
+func (s *ConfigSuite) TestConfigParser_RequireTLS() {
+	s.Run("rejects HTTP URL", func() {
+		_, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.loki]
+			url = "http://loki.example/"
+		`))
+		s.Require().Error(err)
+		s.ErrorContains(err, "require_tls is enabled but Loki URL uses \"http\" scheme")
+	})
+	s.Run("rejects insecure", func() {
+		_, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.loki]
+			insecure = true
+		`))
+		s.Require().Error(err)
+		s.ErrorContains(err, "require_tls is enabled but Loki insecure=true disables certificate verification")
+	})
+	s.Run("accepts HTTPS URL", func() {
+		cfg, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.loki]
+			url = "https://loki.example/"
+			certificate_authority = "ca.crt"
+		`), config.WithDirPath(s.tempDir))
+		s.Require().NoError(err)
+		s.Equal("https://loki.example/", s.lokiConfig(cfg).Url)
+	})
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
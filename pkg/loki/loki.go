@@ -0,0 +1,223 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+const (
+	// DefaultTenant is the LokiStack tenant holding the application (non-infrastructure) logs.
+	DefaultTenant = "application"
+
+	defaultHTTPTimeout  = 60 * time.Second
+	maxResponseBodySize = 16 << 20 // 16 MiB
+)
+
+// Loki is an HTTP client for the Loki query API, either served directly by Loki or through a LokiStack gateway.
+type Loki struct {
+	*observability.Client
+	gateway bool
+}
+
+// NewLoki creates a client using the toolset config and the Kubernetes REST config.
+// When no URL is configured and the cluster is OpenShift, the URL of the LokiStack gateway is discovered from its Route.
+func NewLoki(ctx context.Context, configProvider api.BaseConfig, k8s api.KubernetesClient, provider api.FilteringProvider) (*Loki, error) {
+	var cfg *observability.Config
+	gateway := false
+	lokiStackNamespace, lokiStackName := DefaultLokiStackNamespace, DefaultLokiStackName
+	if c, ok := configProvider.GetToolsetConfig("loki"); ok {
+		if lc, ok := c.(*Config); ok && lc != nil {
+			cfg = &lc.Config
+			gateway = lc.Gateway
+			if lc.LokiStackNamespace != "" {
+				lokiStackNamespace = lc.LokiStackNamespace
+			}
+			if lc.LokiStackName != "" {
+				lokiStackName = lc.LokiStackName
+			}
+		}
+	}
+	client := &Loki{
+		Client: observability.NewClient(observability.Options{
+			Name:                "loki",
+			Timeout:             defaultHTTPTimeout,
+			MaxResponseBodySize: maxResponseBodySize,
+			TooLargeHint:        "narrow down the query or reduce the limit",
+		}, configProvider, k8s, cfg),
+		gateway: gateway,
+	}
+	if client.URL() == "" {
+		if err := client.DiscoverURL(ctx, k8s, provider, lokiStackNamespace, lokiStackName); err != nil {
+			return nil, err
+		}
+		client.gateway = true
+	}
+	return client, nil
+}
+
+// get performs a GET request against the Loki HTTP API, prefixing the endpoint with the tenant path
+// when querying a LokiStack gateway, and decodes the response data into result.
+func (l *Loki) get(ctx context.Context, tenant, endpoint string, query url.Values, result any) error {
+	if l.gateway {
+		if tenant == "" {
+			tenant = DefaultTenant
+		}
+		if strings.ContainsAny(tenant, "/?#") || tenant == "." || tenant == ".." {
+			return fmt.Errorf("invalid tenant %q", tenant)
+		}
+		endpoint = "/api/logs/v1/" + tenant + endpoint
+	}
+	statusCode, body, err := l.Do(ctx, http.MethodGet, endpoint, query, nil)
+	if err != nil {
+		return err
+	}
+	if err = l.StatusError(statusCode, body); err != nil {
+		return err
+	}
+	var envelope struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("failed to decode loki API response: %w", err)
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("loki API error: status %q", envelope.Status)
+	}
+	if err = json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("failed to decode loki API response data: %w", err)
+	}
+	return nil
+}
+
+// Entry is a single log line of a stream.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Line string    `json:"line"`
+}
+
+// UnmarshalJSON decodes the Loki ["<unix_nano_time>", "<line>"] entry representation.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 2 {
+		return fmt.Errorf("invalid log entry %s", string(data))
+	}
+	var ts string
+	if err := json.Unmarshal(raw[0], &ts); err != nil {
+		return fmt.Errorf("invalid log entry timestamp: %w", err)
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid log entry timestamp: %w", err)
+	}
+	e.Time = time.Unix(0, nanos).UTC()
+	return json.Unmarshal(raw[1], &e.Line)
+}
+
+// Stream is a set of log entries sharing the same labels.
+type Stream struct {
+	Labels  map[string]string `json:"stream"`
+	Entries []Entry           `json:"values"`
+}
+
+// QueryResult is the decoded result of a log (streams) or metric (matrix) range query.
+type QueryResult struct {
+	ResultType string
+	Streams    []Stream
+	Matrix     []observability.RangeSeries
+}
+
+func (r *QueryResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.ResultType = raw.ResultType
+	switch raw.ResultType {
+	case "streams":
+		return json.Unmarshal(raw.Result, &r.Streams)
+	case "matrix":
+		return json.Unmarshal(raw.Result, &r.Matrix)
+	default:
+		return fmt.Errorf("unsupported result type %q", raw.ResultType)
+	}
+}
+
+// QueryRangeOptions are the parameters of a LogQL range query.
+type QueryRangeOptions struct {
+	Start time.Time
+	End   time.Time
+	// Limit is the maximum number of log lines returned (log queries only).
+	Limit int
+	// Forward returns the oldest log lines first instead of the newest ones.
+	Forward bool
+	// Step is the resolution of metric queries, computed by Loki when zero.
+	Step time.Duration
+}
+
+// QueryRange evaluates a LogQL query over the provided range.
+func (l *Loki) QueryRange(ctx context.Context, tenant, query string, opts QueryRangeOptions) (*QueryResult, error) {
+	values := url.Values{
+		"query":     {query},
+		"start":     {strconv.FormatInt(opts.Start.UnixNano(), 10)},
+		"end":       {strconv.FormatInt(opts.End.UnixNano(), 10)},
+		"direction": {"backward"},
+	}
+	if opts.Forward {
+		values.Set("direction", "forward")
+	}
+	if opts.Limit > 0 {
+		values.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Step > 0 {
+		values.Set("step", strconv.FormatFloat(opts.Step.Seconds(), 'f', -1, 64))
+	}
+	result := &QueryResult{}
+	if err := l.get(ctx, tenant, "/loki/api/v1/query_range", values, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Labels returns the label names, optionally restricted to the streams matching the provided selector.
+func (l *Loki) Labels(ctx context.Context, tenant, selector string, start, end time.Time) ([]string, error) {
+	var labels []string
+	err := l.get(ctx, tenant, "/loki/api/v1/labels", labelValues(selector, start, end), &labels)
+	return labels, err
+}
+
+// LabelValues returns the values of the provided label, optionally restricted to the streams matching the provided selector.
+func (l *Loki) LabelValues(ctx context.Context, tenant, label, selector string, start, end time.Time) ([]string, error) {
+	var values []string
+	err := l.get(ctx, tenant, "/loki/api/v1/label/"+url.PathEscape(label)+"/values", labelValues(selector, start, end), &values)
+	return values, err
+}
+
+func labelValues(selector string, start, end time.Time) url.Values {
+	values := url.Values{}
+	if selector != "" {
+		values.Set("query", selector)
+	}
+	if !start.IsZero() {
+		values.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	}
+	if !end.IsZero() {
+		values.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+	}
+	return values
+}
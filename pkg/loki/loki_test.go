@@ -0,0 +1,228 @@
+package loki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+type LokiSuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Config     *config.StaticConfig
+}
+
+func (s *LokiSuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.Config = test.Must(config.ReadToml([]byte(fmt.Sprintf(`
+		[toolset_configs.loki]
+		url = "%s/loki-gateway"
+		gateway = true
+	`, s.MockServer.Config().Host))))
+}
+
+func (s *LokiSuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *LokiSuite) TestNewLoki() {
+	s.Run("fails without URL on non-OpenShift clusters", func() {
+		_, err := NewLoki(context.Background(), config.Default(), &fakeKubernetesClient{}, &mockFilteringProvider{})
+		s.Require().Error(err)
+		s.ErrorContains(err, "loki URL not configured")
+	})
+	s.Run("discovers LokiStack gateway URL from route on OpenShift", func() {
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"}, gatewayRoute(DefaultLokiStackNamespace, DefaultLokiStackName))}
+		client, err := NewLoki(context.Background(), config.Default(), k8s, &mockFilteringProvider{openShift: true})
+		s.Require().NoError(err)
+		s.Equal("https://logging-loki.apps.example.com", client.URL())
+		s.True(client.gateway)
+	})
+	s.Run("discovers configured LokiStack gateway route on OpenShift", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.loki]
+			lokistack_namespace = "logging"
+			lokistack_name = "lokistack"
+		`)))
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"}, gatewayRoute("logging", "lokistack"))}
+		client, err := NewLoki(context.Background(), cfg, k8s, &mockFilteringProvider{openShift: true})
+		s.Require().NoError(err)
+		s.Equal("https://lokistack.apps.example.com", client.URL())
+	})
+	s.Run("fails when LokiStack gateway route is missing on OpenShift", func() {
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"})}
+		_, err := NewLoki(context.Background(), config.Default(), k8s, &mockFilteringProvider{openShift: true})
+		s.Require().Error(err)
+		s.ErrorContains(err, "failed to discover loki URL from route openshift-logging/logging-loki")
+	})
+}
+
+func (s *LokiSuite) TestQueryRange() {
+	var seenPath string
+	var seenQuery url.Values
+	var seenAuth string
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenPath = r.URL.Path
+		seenQuery = r.URL.Query()
+		seenAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[` +
+			`{"stream":{"kubernetes_pod_name":"web-1"},"values":[["1700000000500000000","connection refused"],["1700000000000000000","starting"]]}]}}`))
+	}))
+	client, err := NewLoki(context.Background(), s.Config, &fakeKubernetesClient{token: "token-xyz"}, nil)
+	s.Require().NoError(err)
+
+	result, err := client.QueryRange(s.T().Context(), "infrastructure", `{kubernetes_namespace_name="web"}`, QueryRangeOptions{
+		Start: time.Unix(1700000000, 0),
+		End:   time.Unix(1700003600, 0),
+		Limit: 50,
+	})
+	s.Require().NoError(err)
+	s.Equal("/loki-gateway/api/logs/v1/infrastructure/loki/api/v1/query_range", seenPath)
+	s.Equal(`{kubernetes_namespace_name="web"}`, seenQuery.Get("query"))
+	s.Equal("1700000000000000000", seenQuery.Get("start"))
+	s.Equal("1700003600000000000", seenQuery.Get("end"))
+	s.Equal("50", seenQuery.Get("limit"))
+	s.Equal("backward", seenQuery.Get("direction"))
+	s.False(seenQuery.Has("step"))
+	s.Equal("Bearer token-xyz", seenAuth)
+	s.Equal("streams", result.ResultType)
+	s.Require().Len(result.Streams, 1)
+	s.Equal("web-1", result.Streams[0].Labels["kubernetes_pod_name"])
+	s.Require().Len(result.Streams[0].Entries, 2)
+	s.Equal(Entry{Time: time.Unix(1700000000, 500000000).UTC(), Line: "connection refused"}, result.Streams[0].Entries[0])
+
+	s.Run("uses default tenant", func() {
+		_, err := client.QueryRange(s.T().Context(), "", `{job="a"}`, QueryRangeOptions{})
+		s.Require().NoError(err)
+		s.Equal("/loki-gateway/api/logs/v1/application/loki/api/v1/query_range", seenPath)
+	})
+	s.Run("rejects invalid tenant", func() {
+		_, err := client.QueryRange(s.T().Context(), "../admin", `{job="a"}`, QueryRangeOptions{})
+		s.Require().Error(err)
+		s.ErrorContains(err, `invalid tenant "../admin"`)
+	})
+	s.Run("decodes metric queries", func() {
+		s.MockServer.ResetHandlers()
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seenQuery = r.URL.Query()
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"level":"error"},"values":[[1700000000,"3"],[1700000060,"5"]]}]}}`))
+		}))
+		result, err := client.QueryRange(s.T().Context(), "", `count_over_time({job="a"}[1m])`, QueryRangeOptions{Forward: true, Step: 90 * time.Second})
+		s.Require().NoError(err)
+		s.Equal("forward", seenQuery.Get("direction"))
+		s.Equal("90", seenQuery.Get("step"))
+		s.Equal("matrix", result.ResultType)
+		s.Require().Len(result.Matrix, 1)
+		s.Equal("error", result.Matrix[0].Metric["level"])
+		s.Len(result.Matrix[0].Values, 2)
+	})
+	s.Run("returns Loki API errors", func() {
+		s.MockServer.ResetHandlers()
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("parse error at line 1, col 2: syntax error: unexpected IDENTIFIER\n"))
+		}))
+		_, err := client.QueryRange(s.T().Context(), "", "{", QueryRangeOptions{})
+		s.Require().Error(err)
+		s.EqualError(err, "loki API error (status 400): parse error at line 1, col 2: syntax error: unexpected IDENTIFIER")
+	})
+}
+
+func (s *LokiSuite) TestLabels() {
+	cfg := test.Must(config.ReadToml([]byte(fmt.Sprintf(`
+		[toolset_configs.loki]
+		url = "%s/loki"
+	`, s.MockServer.Config().Host))))
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/loki/loki/api/v1/labels":
+			s.Equal(`{job="a"}`, r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"status":"success","data":["job","level"]}`))
+		case "/loki/loki/api/v1/label/level/values":
+			_, _ = w.Write([]byte(`{"status":"success","data":["error","info"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client, err := NewLoki(context.Background(), cfg, nil, nil)
+	s.Require().NoError(err)
+
+	labels, err := client.Labels(s.T().Context(), "audit", `{job="a"}`, time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Equal([]string{"job", "level"}, labels)
+
+	values, err := client.LabelValues(s.T().Context(), "", "level", "", time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Equal([]string{"error", "info"}, values)
+}
+
+func (s *LokiSuite) TestGet_rejectsRedirects() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://attacker.example/stolen", http.StatusFound)
+	}))
+	client, err := NewLoki(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	_, err = client.Labels(s.T().Context(), "", "", time.Time{}, time.Time{})
+	s.Require().Error(err)
+	s.ErrorContains(err, "redirects are not allowed")
+}
+
+func gatewayRoute(namespace, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec":       map[string]interface{}{"host": name + ".apps.example.com"},
+	}}
+}
+
+type fakeKubernetesClient struct {
+	api.KubernetesClient
+	token   string
+	dynamic dynamic.Interface
+}
+
+func (f *fakeKubernetesClient) RESTConfig() *rest.Config {
+	return &rest.Config{BearerToken: f.token}
+}
+
+func (f *fakeKubernetesClient) DynamicClient() dynamic.Interface {
+	return f.dynamic
+}
+
+type mockFilteringProvider struct {
+	openShift bool
+}
+
+func (m *mockFilteringProvider) IsTargetCompatibilityToolFiltersEnabled() bool {
+	return true
+}
+
+func (m *mockFilteringProvider) AnyTargetHasGVKs(_ context.Context, gvks []schema.GroupVersionKind) bool {
+	return m.openShift
+}
+
+func TestLoki(t *testing.T) {
+	suite.Run(t, new(LokiSuite))
+}
@@ -0,0 +1,96 @@
+package loki
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// variableTokens are masked so that log lines only differing by variable parts share the same pattern.
+// Order matters: the most specific tokens are masked first.
+var variableTokens = []struct {
+	re   *regexp.Regexp
+	mask func(re *regexp.Regexp, s string) string
+}{
+	{regexp.MustCompile(`\b([IWEF])\d{4} \d{2}:\d{2}:\d{2}\.\d+\b`), placeholder("${1}<time>")},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`), placeholder("<time>")},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), placeholder("<uuid>")},
+	{regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b`), placeholder("<ip>")},
+	{regexp.MustCompile(`(?i)\b(?:0x)?[0-9a-f]{6,}\b`), maskHex},
+	{regexp.MustCompile(`-?\b\d+(?:\.\d+)?(?:[a-zA-Zµ]{1,3})?\b`), placeholder("<num>")},
+}
+
+func placeholder(p string) func(re *regexp.Regexp, s string) string {
+	return func(re *regexp.Regexp, s string) string {
+		return re.ReplaceAllString(s, p)
+	}
+}
+
+// maskHex masks hexadecimal identifiers (hashes, container IDs), leaving plain words and numbers untouched.
+func maskHex(re *regexp.Regexp, s string) string {
+	return re.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.IndexFunc(m, unicode.IsDigit) < 0 || strings.IndexFunc(m, unicode.IsLetter) < 0 {
+			return m
+		}
+		return "<hex>"
+	})
+}
+
+// Pattern is a group of log lines sharing the same structure once their variable parts are masked.
+type Pattern struct {
+	Pattern   string    `json:"pattern"`
+	Count     int       `json:"count"`
+	Streams   int       `json:"streams"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Example   string    `json:"example"`
+}
+
+// ExtractPattern masks the variable parts (timestamps, UUIDs, IPs, hexadecimal identifiers, and numbers) of a log line.
+func ExtractPattern(line string) string {
+	pattern := strings.TrimSpace(line)
+	for _, t := range variableTokens {
+		pattern = t.mask(t.re, pattern)
+	}
+	return pattern
+}
+
+// Patterns groups the log lines of the streams by pattern, sorted by decreasing number of occurrences.
+func Patterns(streams []Stream) []Pattern {
+	type aggregate struct {
+		Pattern
+		streams map[int]struct{}
+	}
+	aggregates := map[string]*aggregate{}
+	for i, stream := range streams {
+		for _, entry := range stream.Entries {
+			key := ExtractPattern(entry.Line)
+			a, ok := aggregates[key]
+			if !ok {
+				a = &aggregate{Pattern: Pattern{Pattern: key, FirstSeen: entry.Time, LastSeen: entry.Time, Example: entry.Line}, streams: map[int]struct{}{}}
+				aggregates[key] = a
+			}
+			a.Count++
+			a.streams[i] = struct{}{}
+			if entry.Time.Before(a.FirstSeen) {
+				a.FirstSeen = entry.Time
+			}
+			if entry.Time.After(a.LastSeen) {
+				a.LastSeen = entry.Time
+				a.Example = entry.Line
+			}
+		}
+	}
+	patterns := make([]Pattern, 0, len(aggregates))
+	for _, a := range aggregates {
+		a.Streams = len(a.streams)
+		patterns = append(patterns, a.Pattern)
+	}
+	slices.SortFunc(patterns, func(a, b Pattern) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), b.LastSeen.Compare(a.LastSeen), cmp.Compare(a.Pattern, b.Pattern))
+	})
+	return patterns
+}
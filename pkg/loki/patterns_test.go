@@ -0,0 +1,82 @@
+package loki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PatternsSuite struct {
+	suite.Suite
+}
+
+func (s *PatternsSuite) TestExtractPattern() {
+	cases := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name:     "logfmt with timestamp, IP and numbers",
+			line:     `2026-01-02T03:04:05.123Z level=error msg="failed to connect to 10.0.0.12:5432" attempt=3 duration=12ms`,
+			expected: `<time> level=error msg="failed to connect to <ip>" attempt=<num> duration=<num>`,
+		},
+		{
+			name:     "klog header and UUID",
+			line:     `E0102 03:04:05.123456       1 reflector.go:158] pod 5f0c7d8e-1a2b-4c3d-9e8f-0a1b2c3d4e5f not found`,
+			expected: `E<time>       <num> reflector.go:<num>] pod <uuid> not found`,
+		},
+		{
+			name:     "hexadecimal identifiers",
+			line:     `GET /api/v1/users/42 200 0.532s sha=deadbeef1234`,
+			expected: `GET /api/v1/users/<num> <num> <num> sha=<hex>`,
+		},
+		{
+			name:     "words made of hexadecimal letters are kept",
+			line:     `  decade facade accepted  `,
+			expected: `decade facade accepted`,
+		},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, ExtractPattern(c.line))
+		})
+	}
+}
+
+func (s *PatternsSuite) TestPatterns() {
+	t0 := time.Unix(1700000000, 0).UTC()
+	streams := []Stream{
+		{Labels: map[string]string{"pod": "a"}, Entries: []Entry{
+			{Time: t0.Add(3 * time.Second), Line: "request 3 failed"},
+			{Time: t0.Add(2 * time.Second), Line: "ready"},
+			{Time: t0, Line: "request 1 failed"},
+		}},
+		{Labels: map[string]string{"pod": "b"}, Entries: []Entry{
+			{Time: t0.Add(time.Second), Line: "request 2 failed"},
+			{Time: t0.Add(4 * time.Second), Line: "shutting down"},
+		}},
+	}
+
+	patterns := Patterns(streams)
+	s.Require().Len(patterns, 3)
+	s.Run("groups lines by pattern across streams", func() {
+		s.Equal(Pattern{
+			Pattern:   "request <num> failed",
+			Count:     3,
+			Streams:   2,
+			FirstSeen: t0,
+			LastSeen:  t0.Add(3 * time.Second),
+			Example:   "request 3 failed",
+		}, patterns[0])
+	})
+	s.Run("sorts by count and then by most recent occurrence", func() {
+		s.Equal("shutting down", patterns[1].Pattern)
+		s.Equal("ready", patterns[2].Pattern)
+	})
+}
+
+func TestPatterns(t *testing.T) {
+	suite.Run(t, new(PatternsSuite))
+}
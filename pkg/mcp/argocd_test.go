@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var argoCDTestApplicationGVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+type ArgoCDMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *ArgoCDMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "argocd")
+	s.namespace = fmt.Sprintf("argocd-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *ArgoCDMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *ArgoCDMcpSuite) TestApplicationSync() {
+	s.Run("sets the sync operation", func() {
+		s.createApplication("guestbook", nil, nil)
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "guestbook",
+			"revision":  "v1.2.0",
+			"prune":     true,
+			"resources": []interface{}{"apps:Deployment:guestbook", ":Service:" + s.namespace + "/guestbook"},
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("Sync of Application 'guestbook' in namespace '%s' requested (revision: v1.2.0, prune: true, resources: 2). "+
+			"Use argocd_application_diff to follow the operation", s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+
+		app := s.getApplication("guestbook")
+		s.Equal("kubernetes-mcp-server", test.FieldString(app, "operation.initiatedBy.username"))
+		s.Equal("v1.2.0", test.FieldString(app, "operation.sync.revision"))
+		s.Equal(true, test.FieldValue(app, "operation.sync.prune"))
+		s.False(test.FieldExists(app, "operation.sync.dryRun"))
+		s.Equal("apps", test.FieldString(app, "operation.sync.resources[0].group"))
+		s.Equal("Deployment", test.FieldString(app, "operation.sync.resources[0].kind"))
+		s.Equal("guestbook", test.FieldString(app, "operation.sync.resources[0].name"))
+		s.Equal("Service", test.FieldString(app, "operation.sync.resources[1].kind"))
+		s.Equal(s.namespace, test.FieldString(app, "operation.sync.resources[1].namespace"))
+	})
+
+	s.Run("sets a dry-run sync operation", func() {
+		s.createApplication("dry-run", nil, nil)
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "dry-run",
+			"namespace": s.namespace,
+			"dry_run":   true,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "Dry-run sync of Application 'dry-run'")
+
+		app := s.getApplication("dry-run")
+		s.Equal(true, test.FieldValue(app, "operation.sync.dryRun"))
+		s.False(test.FieldExists(app, "operation.sync.revision"))
+	})
+
+	s.Run("refuses to sync while an operation is requested", func() {
+		s.createApplication("requested", map[string]interface{}{
+			"initiatedBy": map[string]interface{}{"username": "admin"},
+			"sync":        map[string]interface{}{"revision": "main"},
+		}, nil)
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "requested",
+			"namespace": s.namespace,
+			"revision":  "v2.0.0",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Equal(fmt.Sprintf("failed to sync application %s/requested: another operation is already in progress", s.namespace),
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal("main", test.FieldString(s.getApplication("requested"), "operation.sync.revision"))
+	})
+
+	s.Run("refuses to sync while an operation is running", func() {
+		s.createApplication("running", nil, map[string]interface{}{
+			"operationState": map[string]interface{}{"phase": "Running"},
+		})
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "running",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "another operation is already in progress")
+		s.False(test.FieldExists(s.getApplication("running"), "operation"))
+	})
+
+	s.Run("syncs once the previous operation succeeded", func() {
+		s.createApplication("succeeded", nil, map[string]interface{}{
+			"operationState": map[string]interface{}{"phase": "Succeeded"},
+		})
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "succeeded",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.True(test.FieldExists(s.getApplication("succeeded"), "operation.sync"))
+	})
+
+	s.Run("rejects invalid resources", func() {
+		s.createApplication("invalid-resources", nil, nil)
+
+		toolResult, err := s.CallTool("argocd_application_sync", map[string]interface{}{
+			"name":      "invalid-resources",
+			"namespace": s.namespace,
+			"resources": []interface{}{"Deployment/guestbook"},
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `invalid resource "Deployment/guestbook"`)
+		s.False(test.FieldExists(s.getApplication("invalid-resources"), "operation"))
+	})
+}
+
+func (s *ArgoCDMcpSuite) TestApplicationRefresh() {
+	s.createApplication("refresh-me", nil, nil)
+
+	toolResult, err := s.CallTool("argocd_application_refresh", map[string]interface{}{
+		"name": "refresh-me",
+		"hard": true,
+	})
+	s.Require().NoError(err)
+	s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+	s.Equal("hard", s.getApplication("refresh-me").GetAnnotations()["argocd.argoproj.io/refresh"])
+}
+
+func (s *ArgoCDMcpSuite) createApplication(name string, operation, status map[string]interface{}) {
+	app := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": s.namespace,
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        "https://github.com/argoproj/argocd-example-apps.git",
+				"path":           "guestbook",
+				"targetRevision": "HEAD",
+			},
+			"destination": map[string]interface{}{
+				"server":    "https://kubernetes.default.svc",
+				"namespace": s.namespace,
+			},
+		},
+	}}
+	if operation != nil {
+		app.Object["operation"] = operation
+	}
+	if status != nil {
+		app.Object["status"] = status
+	}
+	_, err := s.dynamic.Resource(argoCDTestApplicationGVR).Namespace(s.namespace).Create(s.T().Context(), app, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *ArgoCDMcpSuite) getApplication(name string) *unstructured.Unstructured {
+	app, err := s.dynamic.Resource(argoCDTestApplicationGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return app
+}
+
+func TestArgoCD(t *testing.T) {
+	suite.Run(t, new(ArgoCDMcpSuite))
+}
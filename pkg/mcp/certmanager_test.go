@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var certManagerTestCertificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+type CertManagerMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *CertManagerMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "certmanager")
+	s.namespace = fmt.Sprintf("certmanager-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *CertManagerMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *CertManagerMcpSuite) TestCertificateRenew() {
+	s.Run("sets the Issuing condition", func() {
+		s.createCertificate("web-tls", map[string]interface{}{
+			"type":               "Ready",
+			"status":             "True",
+			"reason":             "Ready",
+			"lastTransitionTime": "2026-01-01T00:00:00Z",
+		})
+
+		toolResult, err := s.CallTool("certmanager_certificate_renew", map[string]interface{}{
+			"name":      "web-tls",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("Renewal of Certificate 'web-tls' in namespace '%s' triggered, use certmanager_certificate_trace to follow the issuance", s.namespace),
+			toolResult.Content[0].(*mcp.TextContent).Text)
+
+		certificate := s.getCertificate("web-tls")
+		s.Equal("Ready", test.FieldString(certificate, "status.conditions[0].type"), "expected the other conditions to be kept")
+		s.Equal("Issuing", test.FieldString(certificate, "status.conditions[1].type"))
+		s.Equal("True", test.FieldString(certificate, "status.conditions[1].status"))
+		s.Equal("ManuallyTriggered", test.FieldString(certificate, "status.conditions[1].reason"))
+		s.Equal(certificate.GetGeneration(), test.FieldInt(certificate, "status.conditions[1].observedGeneration"))
+	})
+
+	s.Run("replaces a False Issuing condition", func() {
+		s.createCertificate("failed-tls", map[string]interface{}{
+			"type":               "Issuing",
+			"status":             "False",
+			"reason":             "Failed",
+			"lastTransitionTime": "2026-01-01T00:00:00Z",
+		})
+
+		toolResult, err := s.CallTool("certmanager_certificate_renew", map[string]interface{}{
+			"name":      "failed-tls",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+
+		certificate := s.getCertificate("failed-tls")
+		s.False(test.FieldExists(certificate, "status.conditions[1]"))
+		s.Equal("True", test.FieldString(certificate, "status.conditions[0].status"))
+		s.Equal("ManuallyTriggered", test.FieldString(certificate, "status.conditions[0].reason"))
+	})
+
+	s.Run("leaves certificates already being issued unchanged", func() {
+		s.createCertificate("issuing-tls", map[string]interface{}{
+			"type":               "Issuing",
+			"status":             "True",
+			"reason":             "Renewing",
+			"lastTransitionTime": "2026-01-01T00:00:00Z",
+		})
+
+		toolResult, err := s.CallTool("certmanager_certificate_renew", map[string]interface{}{
+			"name":      "issuing-tls",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal(fmt.Sprintf("Certificate 'issuing-tls' in namespace '%s' is already being issued, use certmanager_certificate_trace to follow the issuance", s.namespace),
+			toolResult.Content[0].(*mcp.TextContent).Text)
+
+		certificate := s.getCertificate("issuing-tls")
+		s.Equal("Renewing", test.FieldString(certificate, "status.conditions[0].reason"))
+		s.Equal("2026-01-01T00:00:00Z", test.FieldString(certificate, "status.conditions[0].lastTransitionTime"))
+	})
+
+	s.Run("fails for missing certificates", func() {
+		toolResult, err := s.CallTool("certmanager_certificate_renew", map[string]interface{}{
+			"name":      "missing-tls",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("failed to get certificate %s/missing-tls", s.namespace))
+	})
+}
+
+// createCertificate creates a Certificate and sets its status condition, the status subresource ignores the status on creation.
+func (s *CertManagerMcpSuite) createCertificate(name string, condition map[string]interface{}) {
+	certificates := s.dynamic.Resource(certManagerTestCertificateGVR).Namespace(s.namespace)
+	certificate, err := certificates.Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"secretName": name,
+			"dnsNames":   []interface{}{name + ".example.com"},
+			"issuerRef":  map[string]interface{}{"kind": "Issuer", "name": "selfsigned"},
+		},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.Require().NoError(unstructured.SetNestedSlice(certificate.Object, []interface{}{condition}, "status", "conditions"))
+	_, err = certificates.UpdateStatus(s.T().Context(), certificate, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *CertManagerMcpSuite) getCertificate(name string) *unstructured.Unstructured {
+	certificate, err := s.dynamic.Resource(certManagerTestCertificateGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return certificate
+}
+
+func TestCertManager(t *testing.T) {
+	suite.Run(t, new(CertManagerMcpSuite))
+}
@@ -0,0 +1,233 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	fluxTestGitRepositoryGVR = schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"}
+	fluxTestOCIRepositoryGVR = schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "ocirepositories"}
+	fluxTestKustomizationGVR = schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+)
+
+const fluxTestRequestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+
+type FluxMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *FluxMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "flux")
+	s.namespace = fmt.Sprintf("flux-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *FluxMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *FluxMcpSuite) TestReconcile() {
+	s.createGitRepository("podinfo")
+
+	s.Run("requests the reconciliation of the object", func() {
+		s.createKustomization("apps", false)
+
+		toolResult, err := s.CallTool("flux_reconcile", map[string]interface{}{
+			"kind":      "Kustomization",
+			"name":      "apps",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("Reconciliation of Kustomization 'apps' in namespace '%s' requested at ", s.namespace))
+
+		requestedAt := s.get(fluxTestKustomizationGVR, "apps").GetAnnotations()[fluxTestRequestedAtAnnotation]
+		_, err = time.Parse(time.RFC3339Nano, requestedAt)
+		s.NoError(err, "expected an RFC3339 requestedAt annotation, got %q", requestedAt)
+		s.NotContains(s.get(fluxTestGitRepositoryGVR, "podinfo").GetAnnotations(), fluxTestRequestedAtAnnotation)
+	})
+
+	s.Run("requests the reconciliation of the source first", func() {
+		s.createKustomization("infra", false)
+
+		toolResult, err := s.CallTool("flux_reconcile", map[string]interface{}{
+			"kind":        "Kustomization",
+			"name":        "infra",
+			"namespace":   s.namespace,
+			"with_source": true,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("Reconciliation of source GitRepository %s/podinfo requested. ", s.namespace))
+
+		kustomizationRequestedAt := s.get(fluxTestKustomizationGVR, "infra").GetAnnotations()[fluxTestRequestedAtAnnotation]
+		s.NotEmpty(kustomizationRequestedAt)
+		s.Equal(kustomizationRequestedAt, s.get(fluxTestGitRepositoryGVR, "podinfo").GetAnnotations()[fluxTestRequestedAtAnnotation])
+	})
+
+	s.Run("refuses to reconcile a suspended object", func() {
+		s.createKustomization("suspended", true)
+
+		toolResult, err := s.CallTool("flux_reconcile", map[string]interface{}{
+			"kind":      "Kustomization",
+			"name":      "suspended",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Equal(fmt.Sprintf("failed to reconcile Kustomization %s/suspended: the object is suspended, resume it with flux_resume", s.namespace),
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		s.NotContains(s.get(fluxTestKustomizationGVR, "suspended").GetAnnotations(), fluxTestRequestedAtAnnotation)
+	})
+
+	s.Run("rejects unsupported kinds", func() {
+		toolResult, err := s.CallTool("flux_reconcile", map[string]interface{}{
+			"kind":      "Bucket",
+			"name":      "podinfo",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `unsupported kind "Bucket"`)
+	})
+}
+
+func (s *FluxMcpSuite) TestSuspendResume() {
+	s.createKustomization("apps", false)
+
+	s.Run("suspend sets spec.suspend", func() {
+		toolResult, err := s.CallTool("flux_suspend", map[string]interface{}{
+			"kind":      "Kustomization",
+			"name":      "apps",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("Kustomization 'apps' in namespace '%s' suspended", s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(true, test.FieldValue(s.get(fluxTestKustomizationGVR, "apps"), "spec.suspend"))
+	})
+
+	s.Run("resume removes spec.suspend and requests the reconciliation", func() {
+		toolResult, err := s.CallTool("flux_resume", map[string]interface{}{
+			"kind":      "Kustomization",
+			"name":      "apps",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		kustomization := s.get(fluxTestKustomizationGVR, "apps")
+		s.False(test.FieldExists(kustomization, "spec.suspend"))
+		s.Equal("./apps", test.FieldString(kustomization, "spec.path"), "expected the rest of the spec to be kept")
+		s.NotEmpty(kustomization.GetAnnotations()[fluxTestRequestedAtAnnotation])
+	})
+
+	s.Run("fails for missing objects", func() {
+		toolResult, err := s.CallTool("flux_suspend", map[string]interface{}{
+			"kind":      "Kustomization",
+			"name":      "missing",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("failed to suspend Kustomization %s/missing", s.namespace))
+	})
+}
+
+func (s *FluxMcpSuite) TestResourcesList() {
+	s.createGitRepository("podinfo")
+	_, err := s.dynamic.Resource(fluxTestOCIRepositoryGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "OCIRepository",
+		"metadata":   map[string]interface{}{"name": "manifests", "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"interval": "5m",
+			"url":      "oci://ghcr.io/stefanprodan/manifests/podinfo",
+			"ref":      map[string]interface{}{"tag": "latest"},
+		},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+
+	s.Run("lists the OCIRepositories served at v1", func() {
+		toolResult, err := s.CallTool("flux_resources_list", map[string]interface{}{
+			"kind":      "OCIRepository",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name: manifests")
+		s.Contains(text, "source: oci://ghcr.io/stefanprodan/manifests/podinfo tag=latest")
+	})
+
+	s.Run("lists all the kinds", func() {
+		toolResult, err := s.CallTool("flux_resources_list", map[string]interface{}{"namespace": s.namespace})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "kind: GitRepository")
+		s.Contains(text, "kind: OCIRepository")
+		s.NotContains(text, "not served by the cluster")
+	})
+}
+
+func (s *FluxMcpSuite) createGitRepository(name string) {
+	_, err := s.dynamic.Resource(fluxTestGitRepositoryGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "GitRepository",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"interval": "1m",
+			"url":      "https://github.com/stefanprodan/podinfo",
+			"ref":      map[string]interface{}{"branch": "master"},
+		},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *FluxMcpSuite) createKustomization(name string, suspended bool) {
+	spec := map[string]interface{}{
+		"interval":  "10m",
+		"path":      "./" + name,
+		"prune":     true,
+		"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": "podinfo"},
+	}
+	if suspended {
+		spec["suspend"] = true
+	}
+	_, err := s.dynamic.Resource(fluxTestKustomizationGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec":       spec,
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *FluxMcpSuite) get(gvr schema.GroupVersionResource, name string) *unstructured.Unstructured {
+	obj, err := s.dynamic.Resource(gvr).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return obj
+}
+
+func TestFlux(t *testing.T) {
+	suite.Run(t, new(FluxMcpSuite))
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/base64"
 	"flag"
+	"fmt"
+	"maps"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -12,9 +15,14 @@ import (
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -120,6 +128,438 @@ func (s *HelmSuite) TestHelmInstallDenied() {
 	})
 }
 
+func (s *HelmSuite) TestHelmTemplate() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-web")
+	s.Run("helm_template(chart=helm-chart-web)", func() {
+		toolResult, err := s.CallTool("helm_template", map[string]interface{}{
+			"chart":  chartPath,
+			"name":   "web",
+			"values": map[string]interface{}{"password": "hunter2"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns rendered manifests", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# Source: web/templates/configmap.yaml")
+			s.Contains(text, "name: web-config")
+		})
+		s.Run("redacts Secret data", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "password: '[REDACTED]'")
+			s.NotContains(text, "hunter2")
+		})
+		s.Run("does not install the chart", func() {
+			list, err := s.CallTool("helm_list", map[string]interface{}{})
+			s.Nilf(err, "call tool failed %v", err)
+			s.Equal("No Helm releases found", list.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("helm_template(chart=helm-chart-web, summary=true)", func() {
+		toolResult, err := s.CallTool("helm_template", map[string]interface{}{"chart": chartPath, "summary": true})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns resources by kind", func() {
+			var decoded map[string]interface{}
+			s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded))
+			s.Equal("web-0.1.0", decoded["chart"])
+			s.Equal("release-name", decoded["release"])
+			s.Equal([]interface{}{
+				map[string]interface{}{"kind": "ConfigMap", "count": float64(1), "resources": []interface{}{"release-name-config"}},
+				map[string]interface{}{"kind": "Secret", "count": float64(1), "resources": []interface{}{"release-name-credentials"}},
+			}, decoded["resources"])
+		})
+	})
+	s.Run("helm_template(chart=helm-chart-web, server_side=true)", func() {
+		toolResult, err := s.CallTool("helm_template", map[string]interface{}{"chart": chartPath, "server_side": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: release-name-config")
+	})
+	s.Run("helm_template(chart=helm-chart-web) with values not matching the schema", func() {
+		toolResult, _ := s.CallTool("helm_template", map[string]interface{}{"chart": chartPath, "values": map[string]interface{}{"replicas": 0}})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Truef(strings.HasPrefix(text, "failed to render helm chart"), "expected descriptive error, got %v", text)
+		s.Contains(text, "values don't meet the specifications of the schema")
+	})
+}
+
+func (s *HelmSuite) TestHelmShow() {
+	s.InitMcpClient()
+	s.Run("helm_show(chart=helm-chart-web)", func() {
+		_, file, _, _ := runtime.Caller(0)
+		toolResult, err := s.CallTool("helm_show", map[string]interface{}{
+			"chart": filepath.Join(filepath.Dir(file), "testdata", "helm-chart-web"),
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns chart metadata, default values and schema", func() {
+			var decoded map[string]interface{}
+			s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded))
+			s.Equal("web", decoded["chart"].(map[string]interface{})["name"])
+			s.Equal("0.1.0", decoded["chart"].(map[string]interface{})["version"])
+			s.Contains(decoded["values"], "replicas: 1")
+			s.Contains(decoded["valuesSchema"], `"minimum": 1`)
+		})
+	})
+	s.Run("helm_show(chart=http://example.com/web.tgz)", func() {
+		toolResult, _ := s.CallTool("helm_show", map[string]interface{}{"chart": "http://example.com/web.tgz"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "http:// scheme is blocked")
+	})
+}
+
+func (s *HelmSuite) TestHelmUpgrade() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+	installResult, err := s.CallTool("helm_install", map[string]interface{}{
+		"chart": chartPath,
+		"name":  "release-to-upgrade",
+	})
+	s.Require().NoError(err)
+	s.Require().Falsef(installResult.IsError, "install failed %v", installResult.Content)
+	s.Run("helm_upgrade_preview(name=release-to-upgrade)", func() {
+		toolResult, err := s.CallTool("helm_upgrade_preview", map[string]interface{}{
+			"name":  "release-to-upgrade",
+			"chart": chartPath,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns preview summary", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+				"Preview of the upgrade of release release-to-upgrade from revision 1: 0 added, 0 changed, 0 removed, 0 unchanged resources. Nothing was applied")
+		})
+	})
+	s.Run("helm_upgrade(name=release-to-upgrade)", func() {
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{
+			"name":  "release-to-upgrade",
+			"chart": chartPath,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns upgraded release", func() {
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded)
+			s.Require().NoErrorf(err, "invalid tool result content %v", err)
+			s.Require().Len(decoded, 1)
+			s.Equal("release-to-upgrade", decoded[0]["name"])
+			s.Equal("deployed", decoded[0]["status"])
+			s.Equal(float64(2), decoded[0]["revision"])
+		})
+	})
+	s.Run("helm_upgrade(name=release-to-upgrade, reuse_values=true, reset_values=true)", func() {
+		toolResult, _ := s.CallTool("helm_upgrade", map[string]interface{}{
+			"name":         "release-to-upgrade",
+			"chart":        chartPath,
+			"reuse_values": true,
+			"reset_values": true,
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "reuse_values and reset_values are mutually exclusive")
+	})
+}
+
+func (s *HelmSuite) TestHelmUpgradeNotFound() {
+	s.InitMcpClient()
+	s.Run("helm_upgrade(name=missing-release) with no releases", func() {
+		_, file, _, _ := runtime.Caller(0)
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{
+			"name":  "missing-release",
+			"chart": filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op"),
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes missing release", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to upgrade helm release 'missing-release'")
+		})
+	})
+}
+
+func (s *HelmSuite) TestHelmVerify() {
+	_, file, _, _ := runtime.Caller(0)
+	chartLoaded, err := loader.Load(filepath.Join(filepath.Dir(file), "testdata", "helm-chart-web"))
+	s.Require().NoError(err)
+	unsigned, err := chartutil.Save(chartLoaded, s.T().TempDir())
+	s.Require().NoError(err)
+	signed, err := chartutil.Save(chartLoaded, s.T().TempDir())
+	s.Require().NoError(err)
+	signer, err := openpgp.NewEntity("Chart Signer", "", "signer@example.com", nil)
+	s.Require().NoError(err)
+	prov, err := (&provenance.Signatory{Entity: signer}).ClearSign(signed)
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(signed+".prov", []byte(prov), 0o644))
+	trusted, err := openpgp.NewEntity("Trusted Signer", "", "trusted@example.com", nil)
+	s.Require().NoError(err)
+	keyring := filepath.Join(s.T().TempDir(), "pubring.gpg")
+	f, err := os.Create(keyring)
+	s.Require().NoError(err)
+	s.Require().NoError(trusted.Serialize(f))
+	s.Require().NoError(f.Close())
+	// toolset_configs requires the two-phase parsing performed by config.ReadToml,
+	// so we replace s.Cfg and restore the runtime fields the suite already set.
+	cfg, err := config.ReadToml([]byte(fmt.Sprintf(`
+		toolsets = ["helm"]
+		[toolset_configs.helm]
+		verify = true
+		keyring = %q
+	`, keyring)))
+	s.Require().NoError(err, "failed to parse helm toolset config")
+	cfg.KubeConfig = s.Cfg.KubeConfig
+	cfg.ListOutput = s.Cfg.ListOutput
+	s.Cfg = cfg
+	s.InitMcpClient()
+
+	for _, tc := range []struct {
+		tool   string
+		args   map[string]interface{}
+		prefix string
+	}{
+		{tool: "helm_install", args: map[string]interface{}{"name": "verified-release"}, prefix: "failed to install helm chart"},
+		{tool: "helm_upgrade", args: map[string]interface{}{"name": "verified-release"}, prefix: "failed to upgrade helm release 'verified-release'"},
+		{tool: "helm_template", args: map[string]interface{}{}, prefix: "failed to render helm chart"},
+	} {
+		s.Run(tc.tool+" refuses charts signed by a key outside of the keyring", func() {
+			args := map[string]interface{}{"chart": signed}
+			maps.Copy(args, tc.args)
+			toolResult, err := s.CallTool(tc.tool, args)
+			s.Require().NoError(err)
+			s.Require().True(toolResult.IsError, "call tool should fail")
+			msg := toolResult.Content[0].(*mcp.TextContent).Text
+			s.True(strings.HasPrefix(msg, tc.prefix), "expected descriptive error, got %v", msg)
+			s.Contains(msg, fmt.Sprintf("chart %q signed by key", signed))
+			s.Contains(msg, signer.PrimaryKey.KeyIdString()+" could not be verified against the keyring "+keyring)
+		})
+		s.Run(tc.tool+" refuses unsigned charts", func() {
+			args := map[string]interface{}{"chart": unsigned}
+			maps.Copy(args, tc.args)
+			toolResult, err := s.CallTool(tc.tool, args)
+			s.Require().NoError(err)
+			s.Require().True(toolResult.IsError, "call tool should fail")
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("chart %q could not be verified against the keyring %s", unsigned, keyring))
+		})
+	}
+	s.Run("nothing was installed", func() {
+		_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Secrets("default").
+			Get(s.T().Context(), "sh.helm.release.v1.verified-release.v1", metav1.GetOptions{})
+		s.True(errors.IsNotFound(err), "expected no release to be installed, got %v", err)
+	})
+}
+
+func (s *HelmSuite) TestHelmHistoryAndRollback() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+	_, err := s.CallTool("helm_install", map[string]interface{}{"chart": chartPath, "name": "release-to-roll-back"})
+	s.Require().NoError(err)
+	_, err = s.CallTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "release-to-roll-back"})
+	s.Require().NoError(err)
+	s.Run("helm_rollback(name=release-to-roll-back, revision=1)", func() {
+		toolResult, err := s.CallTool("helm_rollback", map[string]interface{}{
+			"name":     "release-to-roll-back",
+			"revision": 1,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns rolled back release", func() {
+			s.Equal("Rolled back release release-to-roll-back to revision 1, the release is now at revision 3 (deployed)",
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("helm_history(name=release-to-roll-back)", func() {
+		toolResult, err := s.CallTool("helm_history", map[string]interface{}{
+			"name": "release-to-roll-back",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns revisions, most recent first", func() {
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded)
+			s.Require().NoErrorf(err, "invalid tool result content %v", err)
+			s.Require().Len(decoded, 3)
+			s.Equal(float64(3), decoded[0]["revision"])
+			s.Equal("deployed", decoded[0]["status"])
+			s.Equal("Rollback to 1", decoded[0]["description"])
+			s.Equal("no-op-1.33.7", decoded[0]["chart"])
+			s.Equal("superseded", decoded[1]["status"])
+		})
+	})
+	s.Run("helm_history(name=release-to-roll-back, max=1)", func() {
+		toolResult, err := s.CallTool("helm_history", map[string]interface{}{
+			"name": "release-to-roll-back",
+			"max":  1,
+		})
+		s.Require().NoError(err)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded))
+		s.Len(decoded, 1)
+	})
+}
+
+func (s *HelmSuite) TestHelmHistoryNotFound() {
+	s.InitMcpClient()
+	s.Run("helm_history(name=missing-release) with no releases", func() {
+		toolResult, err := s.CallTool("helm_history", map[string]interface{}{
+			"name": "missing-release",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes missing release", func() {
+			s.Equal("failed to get history of helm release 'missing-release': release: not found", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+}
+
+func (s *HelmSuite) TestHelmGet() {
+	kc := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kc.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sh.helm.release.v1.release-to-get.v1",
+			Labels: map[string]string{"owner": "helm", "name": "release-to-get"},
+		},
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+				"\"name\":\"release-to-get\"," +
+				"\"version\":1," +
+				"\"info\":{\"status\":\"deployed\",\"notes\":\"Visit https://web.example.com\"}," +
+				"\"config\":{\"auth\":{\"password\":\"hunter2\",\"username\":\"admin\"}}," +
+				"\"manifest\":\"apiVersion: v1\\nkind: Secret\\nmetadata:\\n  name: web\\ndata:\\n  password: aHVudGVyMg==\\n\"" +
+				"}"))),
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+	s.Run("helm_get(name=release-to-get, what=values)", func() {
+		toolResult, err := s.CallTool("helm_get", map[string]interface{}{"name": "release-to-get", "what": "values"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("redacts credentials", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "password: '[REDACTED]'")
+			s.Contains(text, "username: admin")
+			s.NotContains(text, "hunter2")
+		})
+	})
+	s.Run("helm_get(name=release-to-get, what=manifest)", func() {
+		toolResult, err := s.CallTool("helm_get", map[string]interface{}{"name": "release-to-get", "what": "manifest", "revision": 1})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("redacts Secret data", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "kind: Secret")
+			s.Contains(text, "password: '[REDACTED]'")
+			s.NotContains(text, "aHVudGVyMg==")
+		})
+	})
+	s.Run("helm_get(name=release-to-get, what=notes)", func() {
+		toolResult, err := s.CallTool("helm_get", map[string]interface{}{"name": "release-to-get", "what": "notes"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("Visit https://web.example.com", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("helm_get(name=release-to-get, what=hooks)", func() {
+		toolResult, err := s.CallTool("helm_get", map[string]interface{}{"name": "release-to-get", "what": "hooks"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("Release release-to-get has no hooks", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("helm_get(name=release-to-get, what=values, revision=2) with missing revision", func() {
+		toolResult, _ := s.CallTool("helm_get", map[string]interface{}{"name": "release-to-get", "what": "values", "revision": 2})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get values of helm release 'release-to-get'")
+	})
+}
+
+func (s *HelmSuite) TestHelmDrift() {
+	kc := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kc.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sh.helm.release.v1.release-drift.v1",
+			Labels: map[string]string{"owner": "helm", "name": "release-drift"},
+		},
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+				"\"name\":\"release-drift\"," +
+				"\"namespace\":\"default\"," +
+				"\"version\":1," +
+				"\"info\":{\"status\":\"deployed\"}," +
+				"\"manifest\":\"---\\napiVersion: v1\\nkind: ConfigMap\\nmetadata:\\n  name: drift-config\\ndata:\\n  mode: production\\n" +
+				"---\\napiVersion: v1\\nkind: ConfigMap\\nmetadata:\\n  name: drift-deleted\\n" +
+				"---\\napiVersion: example.com/v1\\nkind: Widget\\nmetadata:\\n  name: drift-widget\\n  namespace: default\\n\"" +
+				"}"))),
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	releaseLabels := map[string]string{"app.kubernetes.io/managed-by": "Helm", "app.kubernetes.io/instance": "release-drift"}
+	_, err = kc.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "drift-config", Labels: releaseLabels},
+		Data:       map[string]string{"mode": "debug"},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	_, err = kc.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "drift-extra", Labels: releaseLabels},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.T().Cleanup(func() {
+		_ = kc.CoreV1().ConfigMaps("default").Delete(context.Background(), "drift-config", metav1.DeleteOptions{})
+		_ = kc.CoreV1().ConfigMaps("default").Delete(context.Background(), "drift-extra", metav1.DeleteOptions{})
+	})
+	s.InitMcpClient()
+	s.Run("helm_drift(name=release-drift)", func() {
+		toolResult, err := s.CallTool("helm_drift", map[string]interface{}{"name": "release-drift"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		var decoded map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded))
+		s.Run("reports modified fields", func() {
+			s.Equal([]interface{}{map[string]interface{}{
+				"resource": "ConfigMap default/drift-config",
+				"fields":   []interface{}{map[string]interface{}{"path": "data.mode", "expected": "production", "live": "debug"}},
+			}}, decoded["modified"])
+		})
+		s.Run("reports deleted objects", func() {
+			s.Equal([]interface{}{"ConfigMap default/drift-deleted"}, decoded["deleted"])
+		})
+		s.Run("reports extra objects", func() {
+			s.Equal([]interface{}{"ConfigMap default/drift-extra"}, decoded["extra"])
+		})
+		s.Run("reports objects of kinds the cluster does not serve", func() {
+			s.Require().Len(decoded["unmappable"], 1)
+			s.Contains(decoded["unmappable"].([]interface{})[0], "Widget default/drift-widget (example.com/v1)")
+			s.Contains(decoded["summary"], "1 objects of kinds the cluster does not serve could not be compared")
+		})
+	})
+	s.Run("helm_drift(name=release-not-found)", func() {
+		toolResult, _ := s.CallTool("helm_drift", map[string]interface{}{"name": "release-not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to detect drift of helm release 'release-not-found'")
+	})
+}
+
 func (s *HelmSuite) TestHelmListNoReleases() {
 	s.InitMcpClient()
 	s.Run("helm_list() with no releases", func() {
@@ -0,0 +1,212 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	knativeTestServiceGVR  = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+	knativeTestRevisionGVR = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "revisions"}
+)
+
+type KnativeMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *KnativeMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "knative")
+	s.namespace = fmt.Sprintf("knative-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *KnativeMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *KnativeMcpSuite) TestTrafficSet() {
+	s.createService("web")
+	s.createRevision("web-00001", "web", "True")
+	s.createRevision("web-00002", "web", "True")
+	s.createRevision("web-00003", "web", "False")
+	s.createRevision("api-00001", "api", "True")
+
+	s.Run("replaces the traffic block of the Service", func() {
+		toolResult, err := s.CallTool("knative_traffic_set", map[string]interface{}{
+			"namespace": s.namespace,
+			"service":   "web",
+			"traffic": []interface{}{
+				map[string]interface{}{"revision": "web-00002", "percent": 90},
+				map[string]interface{}{"revision": "web-00001", "percent": 10, "tag": "previous"},
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("Traffic of Knative Service 'web' in namespace '%s' set to: web-00002 90%%, web-00001 10%% (tag previous). "+
+			"Use knative_services_list to check that the Route has applied the new split", s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+
+		service := s.getService("web")
+		s.Equal("web-00002", test.FieldString(service, "spec.traffic[0].revisionName"))
+		s.Equal(int64(90), test.FieldInt(service, "spec.traffic[0].percent"))
+		s.Equal("web-00001", test.FieldString(service, "spec.traffic[1].revisionName"))
+		s.Equal(int64(10), test.FieldInt(service, "spec.traffic[1].percent"))
+		s.Equal("previous", test.FieldString(service, "spec.traffic[1].tag"))
+		s.False(test.FieldExists(service, "spec.traffic[2]"), "expected the previous traffic block to be replaced")
+		s.Equal("ghcr.io/knative/helloworld-go:latest", test.FieldString(service, "spec.template.spec.containers[0].image"), "expected the rest of the spec to be kept")
+	})
+
+	s.Run("follows the latest ready Revision", func() {
+		toolResult, err := s.CallTool("knative_traffic_set", map[string]interface{}{
+			"namespace": s.namespace,
+			"service":   "web",
+			"traffic":   []interface{}{map[string]interface{}{"latest": true, "percent": 100}},
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+
+		service := s.getService("web")
+		s.Equal(true, test.FieldValue(service, "spec.traffic[0].latestRevision"))
+		s.Equal(int64(100), test.FieldInt(service, "spec.traffic[0].percent"))
+		s.False(test.FieldExists(service, "spec.traffic[0].revisionName"))
+		s.False(test.FieldExists(service, "spec.traffic[1]"))
+	})
+
+	s.Run("refuses invalid splits", func() {
+		for _, tc := range []struct {
+			name    string
+			traffic []interface{}
+			err     string
+		}{
+			{
+				name:    "percentages not adding up to 100",
+				traffic: []interface{}{map[string]interface{}{"revision": "web-00002", "percent": 90}},
+				err:     "traffic percentages add up to 90, they must add up to 100",
+			},
+			{
+				name: "revisions that are not ready",
+				traffic: []interface{}{
+					map[string]interface{}{"revision": "web-00003", "percent": 50},
+					map[string]interface{}{"revision": "web-00002", "percent": 50},
+				},
+				err: "revision web-00003 is not ready and cannot receive traffic: RevisionFailed: Container failed to start",
+			},
+			{
+				name:    "revisions of another Service",
+				traffic: []interface{}{map[string]interface{}{"revision": "api-00001", "percent": 100}},
+				err:     "revision api-00001 not found in the revisions of the service",
+			},
+			{
+				name: "duplicated tags",
+				traffic: []interface{}{
+					map[string]interface{}{"revision": "web-00002", "percent": 50, "tag": "candidate"},
+					map[string]interface{}{"revision": "web-00001", "percent": 50, "tag": "candidate"},
+				},
+				err: "tag candidate is used by several traffic targets",
+			},
+		} {
+			s.Run(tc.name, func() {
+				resourceVersion := s.getService("web").GetResourceVersion()
+
+				toolResult, err := s.CallTool("knative_traffic_set", map[string]interface{}{
+					"namespace": s.namespace,
+					"service":   "web",
+					"traffic":   tc.traffic,
+				})
+				s.Require().NoError(err)
+				s.True(toolResult.IsError)
+				s.Equal(fmt.Sprintf("failed to set knative traffic of %s/web: %s", s.namespace, tc.err), toolResult.Content[0].(*mcp.TextContent).Text)
+				s.Equal(resourceVersion, s.getService("web").GetResourceVersion(), "expected the Service to be left unchanged")
+			})
+		}
+	})
+
+	s.Run("refuses targets with both a revision and latest", func() {
+		toolResult, err := s.CallTool("knative_traffic_set", map[string]interface{}{
+			"namespace": s.namespace,
+			"service":   "web",
+			"traffic":   []interface{}{map[string]interface{}{"revision": "web-00002", "latest": true, "percent": 100}},
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Equal("failed to set knative traffic: traffic target 1: provide either a revision or latest", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+
+	s.Run("fails for missing Services", func() {
+		toolResult, err := s.CallTool("knative_traffic_set", map[string]interface{}{
+			"namespace": s.namespace,
+			"service":   "missing",
+			"traffic":   []interface{}{map[string]interface{}{"latest": true, "percent": 100}},
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("failed to get knative service %s/missing", s.namespace))
+	})
+}
+
+func (s *KnativeMcpSuite) createService(name string) {
+	_, err := s.dynamic.Resource(knativeTestServiceGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.knative.dev/v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"image": "ghcr.io/knative/helloworld-go:latest"}},
+				},
+			},
+			"traffic": []interface{}{
+				map[string]interface{}{"revisionName": name + "-00001", "percent": int64(50)},
+				map[string]interface{}{"revisionName": name + "-00002", "percent": int64(40)},
+				map[string]interface{}{"latestRevision": true, "percent": int64(10)},
+			},
+		},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *KnativeMcpSuite) createRevision(name, service, ready string) {
+	condition := map[string]interface{}{"type": "Ready", "status": ready}
+	if ready != "True" {
+		condition["reason"] = "RevisionFailed"
+		condition["message"] = "Container failed to start"
+	}
+	_, err := s.dynamic.Resource(knativeTestRevisionGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.knative.dev/v1",
+		"kind":       "Revision",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": s.namespace,
+			"labels":    map[string]interface{}{"serving.knative.dev/service": service},
+		},
+		"status": map[string]interface{}{"conditions": []interface{}{condition}},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *KnativeMcpSuite) getService(name string) *unstructured.Unstructured {
+	service, err := s.dynamic.Resource(knativeTestServiceGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return service
+}
+
+func TestKnative(t *testing.T) {
+	suite.Run(t, new(KnativeMcpSuite))
+}
@@ -38,6 +38,8 @@ type Configuration struct {
 	toolsets   []api.Toolset
 }
 
+var _ api.EnabledToolsetsProvider = (*Configuration)(nil)
+
 func (c *Configuration) Toolsets() []api.Toolset {
 	if c.toolsets == nil {
 		for _, toolset := range c.StaticConfig.Toolsets {
@@ -1,12 +1,25 @@
 package mcp
 
 import (
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/alertmanager"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/argocd"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/flux"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/gateway"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kcp"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kiali"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/knative"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/loki"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/netobserv"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/olm"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/openshift"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/policy"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/prometheus"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/tekton"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/velero"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/volumesnapshot"
 )
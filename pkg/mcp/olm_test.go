@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var olmTestInstallPlanGVR = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "installplans"}
+
+type OLMMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *OLMMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "olm")
+	s.namespace = fmt.Sprintf("olm-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *OLMMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *OLMMcpSuite) TestInstallPlanApprove() {
+	s.Run("approves an InstallPlan requiring approval", func() {
+		s.createInstallPlan("install-pending", false, "RequiresApproval")
+
+		toolResult, err := s.CallTool("olm_installplan_approve", map[string]interface{}{
+			"name":      "install-pending",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("InstallPlan 'install-pending' in namespace '%s' approved, OLM installs the ClusterServiceVersions: etcdoperator.v0.9.4\n"+
+			"Use olm_subscriptions_list to follow the installation", s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+
+		installPlan := s.getInstallPlan("install-pending")
+		s.Equal(true, test.FieldValue(installPlan, "spec.approved"))
+		s.Equal("Manual", test.FieldString(installPlan, "spec.approval"), "expected the rest of the spec to be kept")
+		s.Equal("RequiresApproval", test.FieldString(installPlan, "status.phase"), "expected the status to be left to OLM")
+	})
+
+	s.Run("leaves approved InstallPlans unchanged", func() {
+		s.createInstallPlan("install-approved", true, "Complete")
+		resourceVersion := s.getInstallPlan("install-approved").GetResourceVersion()
+
+		toolResult, err := s.CallTool("olm_installplan_approve", map[string]interface{}{
+			"name":      "install-approved",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal(fmt.Sprintf("InstallPlan 'install-approved' in namespace '%s' is already approved (phase: Complete)", s.namespace),
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(resourceVersion, s.getInstallPlan("install-approved").GetResourceVersion())
+	})
+
+	s.Run("fails for missing InstallPlans", func() {
+		toolResult, err := s.CallTool("olm_installplan_approve", map[string]interface{}{
+			"name":      "install-missing",
+			"namespace": s.namespace,
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("failed to get installplan %s/install-missing", s.namespace))
+	})
+}
+
+func (s *OLMMcpSuite) createInstallPlan(name string, approved bool, phase string) {
+	_, err := s.dynamic.Resource(olmTestInstallPlanGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "InstallPlan",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"approval":                   "Manual",
+			"approved":                   approved,
+			"clusterServiceVersionNames": []interface{}{"etcdoperator.v0.9.4"},
+		},
+		"status": map[string]interface{}{"phase": phase},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *OLMMcpSuite) getInstallPlan(name string) *unstructured.Unstructured {
+	installPlan, err := s.dynamic.Resource(olmTestInstallPlanGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return installPlan
+}
+
+func TestOLM(t *testing.T) {
+	suite.Run(t, new(OLMMcpSuite))
+}
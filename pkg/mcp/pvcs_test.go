@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type PVCsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *PVCsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	client := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := client.CoreV1().PersistentVolumeClaims("ns-1").Create(s.T().Context(), &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pending-claim"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create persistentvolumeclaim")
+	s.T().Cleanup(func() {
+		_ = client.CoreV1().PersistentVolumeClaims("ns-1").Delete(s.T().Context(), "a-pending-claim", metav1.DeleteOptions{})
+	})
+}
+
+func (s *PVCsSuite) TestPVCsDiagnose() {
+	s.InitMcpClient()
+	s.Run("pvcs_diagnose(name=a-pending-claim, namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("pvcs_diagnose", map[string]interface{}{
+			"name":      "a-pending-claim",
+			"namespace": "ns-1",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns report header", func() {
+			s.Contains(text, "# PersistentVolumeClaim Diagnostic Report: ns-1/a-pending-claim")
+		})
+		s.Run("detects missing default StorageClass", func() {
+			s.Contains(text, "the cluster has no default StorageClass")
+		})
+	})
+	s.Run("pvcs_diagnose(name=non-existent)", func() {
+		toolResult, err := s.CallTool("pvcs_diagnose", map[string]interface{}{
+			"name": "non-existent",
+		})
+		s.Run("has error", func() {
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Nilf(err, "call tool should not return error object")
+		})
+		s.Run("describes failure", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get persistentvolumeclaim default/non-existent")
+		})
+	})
+}
+
+func (s *PVCsSuite) TestPVCTroubleshootPrompt() {
+	s.InitMcpClient()
+	s.Run("pvc-troubleshoot(name=a-pending-claim, namespace=ns-1)", func() {
+		result, err := s.GetPrompt("pvc-troubleshoot", map[string]string{
+			"namespace": "ns-1",
+			"name":      "a-pending-claim",
+		})
+		s.Require().NoError(err, "failed to get prompt")
+		s.Require().Len(result.Messages, 2)
+		text := result.Messages[0].Content.(*mcp.TextContent).Text
+		s.Contains(text, "# PersistentVolumeClaim Troubleshooting Guide")
+		s.Contains(text, "# PersistentVolumeClaim Diagnostic Report: ns-1/a-pending-claim")
+	})
+}
+
+func TestPVCs(t *testing.T) {
+	suite.Run(t, new(PVCsSuite))
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ServicesSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ServicesSuite) TestServicesDiagnose() {
+	client := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := client.CoreV1().Services("ns-1").Create(s.T().Context(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-service-without-pods"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "does-not-exist"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create service")
+	for _, event := range []*corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "a-service-without-pods.service"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Service", Name: "a-service-without-pods", Namespace: "ns-1"},
+			Reason:         "UpdatedLoadBalancer",
+			Message:        "Updated load balancer with new hosts",
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "a-service-without-pods.deployment"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Name: "a-service-without-pods", Namespace: "ns-1"},
+			Reason:         "ScalingReplicaSet",
+			Message:        "Scaled up replica set",
+		},
+	} {
+		_, err = client.CoreV1().Events("ns-1").Create(s.T().Context(), event, metav1.CreateOptions{})
+		s.Require().NoError(err, "failed to create event")
+	}
+	s.T().Cleanup(func() {
+		_ = client.CoreV1().Services("ns-1").Delete(s.T().Context(), "a-service-without-pods", metav1.DeleteOptions{})
+		_ = client.CoreV1().Events("ns-1").Delete(s.T().Context(), "a-service-without-pods.service", metav1.DeleteOptions{})
+		_ = client.CoreV1().Events("ns-1").Delete(s.T().Context(), "a-service-without-pods.deployment", metav1.DeleteOptions{})
+	})
+	s.InitMcpClient()
+	s.Run("services_diagnose(name=a-service-without-pods, namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("services_diagnose", map[string]interface{}{
+			"name":      "a-service-without-pods",
+			"namespace": "ns-1",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns report header", func() {
+			s.Contains(text, "# Service Diagnostic Report: ns-1/a-service-without-pods")
+		})
+		s.Run("detects selector matching no pods", func() {
+			s.Contains(text, "- **CRITICAL**: Service selector \"app=does-not-exist\" matches no pods in namespace \"ns-1\".")
+		})
+		s.Run("detects missing EndpointSlices", func() {
+			s.Contains(text, "No EndpointSlices exist for this Service")
+		})
+		s.Run("reports the events of the Service only", func() {
+			s.Contains(text, "Updated load balancer with new hosts")
+			s.NotContains(text, "Scaled up replica set", "expected the events of other kinds of objects with the same name to be left out")
+		})
+	})
+	s.Run("services_diagnose(name=non-existent)", func() {
+		toolResult, err := s.CallTool("services_diagnose", map[string]interface{}{
+			"name": "non-existent",
+		})
+		s.Run("has error", func() {
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Nilf(err, "call tool should not return error object")
+		})
+		s.Run("describes failure", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get service default/non-existent")
+		})
+	})
+	s.Run("services_diagnose(missing name)", func() {
+		toolResult, err := s.CallTool("services_diagnose", map[string]interface{}{})
+		s.Run("has error", func() {
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Nilf(err, "call tool should not return error object")
+		})
+		s.Run("describes missing name", func() {
+			s.Equal("failed to diagnose service: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+}
+
+func TestServices(t *testing.T) {
+	suite.Run(t, new(ServicesSuite))
+}
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
 var tektonTestAPIs = []schema.GroupVersionResource{
@@ -202,6 +203,104 @@ func (s *TektonMcpSuite) TestTaskRunLogStepFilter() {
 	})
 }
 
+func (s *TektonMcpSuite) TestPipelineRunList() {
+	s.createRun(tektonTestPipelineRunGVR, "PipelineRun", "build-failed", map[string]interface{}{"tekton.dev/pipeline": "build"},
+		"False", "Failed", "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0", 10*time.Minute)
+	s.createRun(tektonTestPipelineRunGVR, "PipelineRun", "build-succeeded", map[string]interface{}{"tekton.dev/pipeline": "build"},
+		"True", "Succeeded", "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0", 20*time.Minute)
+	s.createRun(tektonTestPipelineRunGVR, "PipelineRun", "build-failed-yesterday", map[string]interface{}{"tekton.dev/pipeline": "build"},
+		"False", "Failed", "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0", 24*time.Hour)
+	s.createRun(tektonTestPipelineRunGVR, "PipelineRun", "deploy-failed", map[string]interface{}{"tekton.dev/pipeline": "deploy"},
+		"False", "Failed", "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0", 5*time.Minute)
+	s.createRun(tektonTestTaskRunGVR, "TaskRun", "build-failed-compile", map[string]interface{}{
+		"tekton.dev/pipeline": "build", "tekton.dev/pipelineRun": "build-failed", "tekton.dev/pipelineTask": "compile", "tekton.dev/task": "golang-build",
+	}, "False", "Failed", `"step-build" exited with code 1`, 9*time.Minute)
+
+	s.Run("lists what failed in the last hour for a pipeline", func() {
+		toolResult, err := s.CallTool("tekton_pipelinerun_list", map[string]interface{}{
+			"namespace": s.namespace,
+			"pipeline":  "build",
+			"status":    "Failed",
+			"since":     "1h",
+		})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		var runs []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &runs))
+		s.Require().Len(runs, 1)
+		s.Equal("build-failed", runs[0]["name"])
+		s.Equal("build", runs[0]["pipeline"])
+		s.Equal("Failed", runs[0]["status"])
+		s.Equal("Failed", runs[0]["reason"])
+		s.Equal("2m0s", runs[0]["duration"])
+		s.Equal([]interface{}{"compile"}, runs[0]["failedTasks"])
+		s.Equal(`compile: "step-build" exited with code 1`, runs[0]["error"])
+	})
+
+	s.Run("lists the runs most recent first", func() {
+		toolResult, err := s.CallTool("tekton_pipelinerun_list", map[string]interface{}{"namespace": s.namespace, "limit": 2})
+		s.Require().NoError(err)
+		var runs []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &runs))
+		s.Require().Len(runs, 2)
+		s.Equal("deploy-failed", runs[0]["name"])
+		s.Equal("build-failed", runs[1]["name"])
+	})
+
+	s.Run("reports when no run matches", func() {
+		toolResult, err := s.CallTool("tekton_pipelinerun_list", map[string]interface{}{"namespace": s.namespace, "status": "Running"})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal(fmt.Sprintf("No PipelineRuns found in namespace '%s' matching the filters", s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+
+	s.Run("rejects invalid time windows", func() {
+		toolResult, err := s.CallTool("tekton_pipelinerun_list", map[string]interface{}{"namespace": s.namespace, "since": "yesterday"})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `invalid since "yesterday"`)
+	})
+}
+
+func (s *TektonMcpSuite) TestTaskRunList() {
+	s.createRun(tektonTestTaskRunGVR, "TaskRun", "build-compile", map[string]interface{}{"tekton.dev/pipeline": "build", "tekton.dev/task": "golang-build"},
+		"False", "Failed", `"step-build" exited with code 1`, 10*time.Minute)
+	s.createRun(tektonTestTaskRunGVR, "TaskRun", "build-lint", map[string]interface{}{"tekton.dev/pipeline": "build", "tekton.dev/task": "golangci-lint"},
+		"False", "Failed", `"step-lint" exited with code 2`, 10*time.Minute)
+	s.createRun(tektonTestTaskRunGVR, "TaskRun", "release-compile", map[string]interface{}{"tekton.dev/pipeline": "release", "tekton.dev/task": "golang-build"},
+		"True", "Succeeded", "All Steps have completed executing", 10*time.Minute)
+
+	s.Run("filters by task and status", func() {
+		toolResult, err := s.CallTool("tekton_taskrun_list", map[string]interface{}{
+			"namespace": s.namespace,
+			"task":      "golang-build",
+			"status":    "Failed",
+		})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		var runs []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &runs))
+		s.Require().Len(runs, 1)
+		s.Equal("build-compile", runs[0]["name"])
+		s.Equal("golang-build", runs[0]["task"])
+		s.Equal(`"step-build" exited with code 1`, runs[0]["error"])
+	})
+
+	s.Run("filters by pipeline and label selector", func() {
+		toolResult, err := s.CallTool("tekton_taskrun_list", map[string]interface{}{
+			"namespace":      s.namespace,
+			"pipeline":       "build",
+			"label_selector": "tekton.dev/task!=golang-build",
+		})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name: build-lint")
+		s.NotContains(text, "name: build-compile")
+		s.NotContains(text, "name: release-compile")
+	})
+}
+
 func (s *TektonMcpSuite) TestPipelineTroubleshootPrompt() {
 	s.Run("returns gathered PipelineRun data", func() {
 		s.createPipeline("demo-pipeline", "diagnostic-task")
@@ -475,6 +574,30 @@ func (s *TektonMcpSuite) createLogTaskRunWithStepStates(name, pipelineRun, pipel
 	s.Require().NoError(err)
 }
 
+func (s *TektonMcpSuite) createRun(gvr schema.GroupVersionResource, kind, name string, labels map[string]interface{}, status, reason, message string, startedAgo time.Duration) {
+	_, err := s.dynamic.Resource(gvr).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": s.namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{},
+		"status": map[string]interface{}{
+			"startTime":      time.Now().Add(-startedAgo).UTC().Format(time.RFC3339),
+			"completionTime": time.Now().Add(-startedAgo + 2*time.Minute).UTC().Format(time.RFC3339),
+			"conditions": []interface{}{map[string]interface{}{
+				"type":    "Succeeded",
+				"status":  status,
+				"reason":  reason,
+				"message": message,
+			}},
+		},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
 func (s *TektonMcpSuite) createEvent(name, involvedKind, involvedName, reason string) {
 	s.createEventWithType(name, involvedKind, involvedName, reason, corev1.EventTypeWarning)
 }
@@ -13,13 +13,26 @@ import (
 	configuration "github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/alertmanager"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/argocd"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/flux"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/gateway"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kcp"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kiali"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/knative"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/loki"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/olm"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/openshift"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/policy"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/prometheus"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/tekton"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/velero"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/volumesnapshot"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -214,12 +227,25 @@ func (s *ToolsetsSuite) TestDefaultToolsetsPromptsInMultiCluster() {
 
 func (s *ToolsetsSuite) TestGranularToolsetsTools() {
 	testCases := []api.Toolset{
+		&alertmanager.Toolset{},
+		&argocd.Toolset{},
+		&certmanager.Toolset{},
 		&core.Toolset{},
 		&config.Toolset{},
+		&flux.Toolset{},
+		&gateway.Toolset{},
 		&helm.Toolset{},
 		&kiali.Toolset{},
+		&knative.Toolset{},
 		&kubevirt.Toolset{},
+		&loki.Toolset{},
+		&olm.Toolset{},
+		&openshift.Toolset{},
+		&policy.Toolset{},
+		&prometheus.Toolset{},
 		&tekton.Toolset{},
+		&velero.Toolset{},
+		&volumesnapshot.Toolset{},
 	}
 	for _, testCase := range testCases {
 		s.Run("Toolset "+testCase.GetName(), func() {
@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	veleroTestBackupGVR  = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"}
+	veleroTestRestoreGVR = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "restores"}
+)
+
+type VeleroMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	dynamic   dynamic.Interface
+}
+
+func (s *VeleroMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "velero")
+	s.namespace = fmt.Sprintf("velero-mcp-%d", time.Now().UnixNano())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *VeleroMcpSuite) TearDownTest() {
+	_ = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig()).CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *VeleroMcpSuite) TestBackupCreate() {
+	s.Run("creates a Backup with the provided spec", func() {
+		toolResult, err := s.CallTool("velero_backup_create", map[string]interface{}{
+			"namespace":           s.namespace,
+			"name":                "nightly",
+			"included_namespaces": []interface{}{"shop", "payments"},
+			"excluded_namespaces": []interface{}{"kube-system"},
+			"label_selector":      "app=web,tier!=cache",
+			"ttl":                 "72h",
+			"storage_location":    "aws-default",
+			"snapshot_volumes":    false,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(fmt.Sprintf("Backup 'nightly' of namespaces [shop payments] (resources matching app=web,tier!=cache) created in namespace '%s', use velero_describe to follow it",
+			s.namespace), toolResult.Content[0].(*mcp.TextContent).Text)
+
+		backup := s.get(veleroTestBackupGVR, "nightly")
+		s.Equal([]interface{}{"shop", "payments"}, test.FieldValue(backup, "spec.includedNamespaces"))
+		s.Equal([]interface{}{"kube-system"}, test.FieldValue(backup, "spec.excludedNamespaces"))
+		s.Equal("web", test.FieldString(backup, "spec.labelSelector.matchLabels.app"))
+		s.Equal("tier", test.FieldString(backup, "spec.labelSelector.matchExpressions[0].key"))
+		s.Equal("NotIn", test.FieldString(backup, "spec.labelSelector.matchExpressions[0].operator"))
+		s.Equal("72h0m0s", test.FieldString(backup, "spec.ttl"))
+		s.Equal("aws-default", test.FieldString(backup, "spec.storageLocation"))
+		s.True(test.FieldExists(backup, "spec.snapshotVolumes"))
+		s.Equal(false, test.FieldValue(backup, "spec.snapshotVolumes"))
+	})
+
+	s.Run("generates the name of the Backup", func() {
+		toolResult, err := s.CallTool("velero_backup_create", map[string]interface{}{"namespace": s.namespace})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "of all namespaces created")
+
+		backups, err := s.dynamic.Resource(veleroTestBackupGVR).Namespace(s.namespace).List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+		var generated *unstructured.Unstructured
+		for i := range backups.Items {
+			if strings.HasPrefix(backups.Items[i].GetName(), "backup-") {
+				generated = &backups.Items[i]
+			}
+		}
+		s.Require().NotNil(generated, "expected a Backup with a generated name")
+		s.False(test.FieldExists(generated, "spec.includedNamespaces"))
+		s.False(test.FieldExists(generated, "spec.snapshotVolumes"))
+	})
+
+	s.Run("rejects invalid ttl", func() {
+		toolResult, err := s.CallTool("velero_backup_create", map[string]interface{}{
+			"namespace": s.namespace,
+			"name":      "invalid-ttl",
+			"ttl":       "3 days",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `failed to create backup: invalid ttl "3 days"`)
+		_, err = s.dynamic.Resource(veleroTestBackupGVR).Namespace(s.namespace).Get(s.T().Context(), "invalid-ttl", metav1.GetOptions{})
+		s.Error(err)
+	})
+}
+
+func (s *VeleroMcpSuite) TestRestoreCreate() {
+	s.Run("creates a Restore of a completed Backup", func() {
+		s.createBackup("completed", "Completed")
+
+		toolResult, err := s.CallTool("velero_restore_create", map[string]interface{}{
+			"namespace":           s.namespace,
+			"backup_name":         "completed",
+			"included_namespaces": []interface{}{"shop"},
+			"namespace_mapping":   map[string]interface{}{"shop": "shop-restored"},
+			"restore_pvs":         true,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "Restore 'completed-")
+
+		restores, err := s.dynamic.Resource(veleroTestRestoreGVR).Namespace(s.namespace).List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+		s.Require().Len(restores.Items, 1)
+		restore := &restores.Items[0]
+		s.True(strings.HasPrefix(restore.GetName(), "completed-"))
+		s.Equal("completed", test.FieldString(restore, "spec.backupName"))
+		s.Equal([]interface{}{"shop"}, test.FieldValue(restore, "spec.includedNamespaces"))
+		s.Equal("shop-restored", test.FieldString(restore, "spec.namespaceMapping.shop"))
+		s.Equal(true, test.FieldValue(restore, "spec.restorePVs"))
+	})
+
+	s.Run("refuses to restore a Backup in progress", func() {
+		s.createBackup("in-progress", "InProgress")
+
+		toolResult, err := s.CallTool("velero_restore_create", map[string]interface{}{
+			"namespace":   s.namespace,
+			"backup_name": "in-progress",
+			"name":        "restore-in-progress",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Equal(`failed to create restore: backup in-progress is in phase "InProgress", only Completed or PartiallyFailed Backups can be restored`,
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		_, err = s.dynamic.Resource(veleroTestRestoreGVR).Namespace(s.namespace).Get(s.T().Context(), "restore-in-progress", metav1.GetOptions{})
+		s.Error(err)
+	})
+
+	s.Run("fails for missing Backups", func() {
+		toolResult, err := s.CallTool("velero_restore_create", map[string]interface{}{
+			"namespace":   s.namespace,
+			"backup_name": "missing",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("failed to get backup %s/missing", s.namespace))
+	})
+}
+
+func (s *VeleroMcpSuite) createBackup(name, phase string) {
+	_, err := s.dynamic.Resource(veleroTestBackupGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Backup",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec":       map[string]interface{}{"includedNamespaces": []interface{}{"shop"}},
+		"status":     map[string]interface{}{"phase": phase},
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *VeleroMcpSuite) get(gvr schema.GroupVersionResource, name string) *unstructured.Unstructured {
+	obj, err := s.dynamic.Resource(gvr).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return obj
+}
+
+func TestVelero(t *testing.T) {
+	suite.Run(t, new(VeleroMcpSuite))
+}
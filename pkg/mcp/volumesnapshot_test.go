@@ -0,0 +1,273 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	volumeSnapshotTestGVR      = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+	volumeSnapshotTestClassGVR = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotclasses"}
+)
+
+type VolumeSnapshotMcpSuite struct {
+	BaseMcpSuite
+	namespace string
+	// driver is unique to each test so that the cluster-scoped VolumeSnapshotClasses of the other tests are ignored
+	driver  string
+	classes []string
+	pvs     []string
+	client  kubernetes.Interface
+	dynamic dynamic.Interface
+}
+
+func (s *VolumeSnapshotMcpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = append(s.Cfg.Toolsets, "volumesnapshot")
+	s.namespace = fmt.Sprintf("volumesnapshot-mcp-%d", time.Now().UnixNano())
+	s.driver = s.namespace + ".csi.example.com"
+	s.classes, s.pvs = nil, nil
+	s.client = kubernetes.NewForConfigOrDie(test.EnvTestRestConfig())
+	s.dynamic = dynamic.NewForConfigOrDie(test.EnvTestRestConfig())
+	_, err := s.client.CoreV1().Namespaces().Create(s.T().Context(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+}
+
+func (s *VolumeSnapshotMcpSuite) TearDownTest() {
+	for _, class := range s.classes {
+		_ = s.dynamic.Resource(volumeSnapshotTestClassGVR).Delete(s.T().Context(), class, metav1.DeleteOptions{})
+	}
+	for _, pv := range s.pvs {
+		_ = s.client.CoreV1().PersistentVolumes().Delete(s.T().Context(), pv, metav1.DeleteOptions{})
+	}
+	_ = s.client.CoreV1().Namespaces().Delete(s.T().Context(), s.namespace, metav1.DeleteOptions{})
+	s.BaseMcpSuite.TearDownTest()
+}
+
+func (s *VolumeSnapshotMcpSuite) TestSnapshotCreate() {
+	s.createBoundPVC("data")
+	s.createSnapshotClass("fast", s.driver, false)
+	s.createSnapshotClass("standard", s.driver, true)
+	s.createSnapshotClass("other", "other.csi.example.com", false)
+
+	s.Run("creates a VolumeSnapshot with the default class of the CSI driver", func() {
+		toolResult, err := s.CallTool("volumesnapshot_create", map[string]interface{}{
+			"namespace": s.namespace,
+			"pvc":       "data",
+			"name":      "data-before-migration",
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("with VolumeSnapshotClass '%s-standard' (CSI driver %s)", s.namespace, s.driver))
+
+		snapshot := s.getSnapshot("data-before-migration")
+		s.Equal("data", test.FieldString(snapshot, "spec.source.persistentVolumeClaimName"))
+		s.Equal(s.namespace+"-standard", test.FieldString(snapshot, "spec.volumeSnapshotClassName"))
+	})
+
+	s.Run("creates a VolumeSnapshot with the requested class and a generated name", func() {
+		toolResult, err := s.CallTool("volumesnapshot_create", map[string]interface{}{
+			"namespace":      s.namespace,
+			"pvc":            "data",
+			"snapshot_class": s.namespace + "-fast",
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+
+		snapshots, err := s.dynamic.Resource(volumeSnapshotTestGVR).Namespace(s.namespace).List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+		var generated *unstructured.Unstructured
+		for i := range snapshots.Items {
+			if strings.HasPrefix(snapshots.Items[i].GetName(), "data-snapshot-") {
+				generated = &snapshots.Items[i]
+			}
+		}
+		s.Require().NotNil(generated, "expected a VolumeSnapshot with a generated name")
+		s.Equal(s.namespace+"-fast", test.FieldString(generated, "spec.volumeSnapshotClassName"))
+	})
+
+	s.Run("refuses to snapshot unbound claims", func() {
+		_, err := s.client.CoreV1().PersistentVolumeClaims(s.namespace).Create(s.T().Context(), &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: s.namespace},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources:   corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+			},
+		}, metav1.CreateOptions{})
+		s.Require().NoError(err)
+
+		toolResult, err := s.CallTool("volumesnapshot_create", map[string]interface{}{
+			"namespace": s.namespace,
+			"pvc":       "pending",
+			"name":      "pending-snapshot",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "only bound claims can be snapshotted")
+		s.snapshotNotFound("pending-snapshot")
+	})
+
+	s.Run("refuses classes of another CSI driver", func() {
+		toolResult, err := s.CallTool("volumesnapshot_create", map[string]interface{}{
+			"namespace":      s.namespace,
+			"pvc":            "data",
+			"name":           "wrong-class-snapshot",
+			"snapshot_class": s.namespace + "-other",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			fmt.Sprintf("volumesnapshotclass %s-other is for CSI driver other.csi.example.com, the volume is provisioned by %s", s.namespace, s.driver))
+		s.snapshotNotFound("wrong-class-snapshot")
+	})
+}
+
+func (s *VolumeSnapshotMcpSuite) TestSnapshotRestore() {
+	s.createBoundPVC("data")
+
+	s.Run("creates a PersistentVolumeClaim from a ready VolumeSnapshot", func() {
+		s.createSnapshot("data-ready", "data", map[string]interface{}{"readyToUse": true, "restoreSize": "2Gi"})
+
+		toolResult, err := s.CallTool("volumesnapshot_restore", map[string]interface{}{
+			"namespace":    s.namespace,
+			"snapshot":     "data-ready",
+			"name":         "data-restored",
+			"access_modes": []interface{}{"ReadWriteOncePod"},
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError, toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			fmt.Sprintf("PersistentVolumeClaim 'data-restored' of 2Gi created in namespace '%s' from VolumeSnapshot 'data-ready' (storage class: csi-standard)", s.namespace))
+
+		pvc, err := s.client.CoreV1().PersistentVolumeClaims(s.namespace).Get(s.T().Context(), "data-restored", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Require().NotNil(pvc.Spec.DataSource)
+		s.Equal("snapshot.storage.k8s.io", ptr.Deref(pvc.Spec.DataSource.APIGroup, ""))
+		s.Equal("VolumeSnapshot", pvc.Spec.DataSource.Kind)
+		s.Equal("data-ready", pvc.Spec.DataSource.Name)
+		s.Equal("csi-standard", ptr.Deref(pvc.Spec.StorageClassName, ""), "expected the storage class of the snapshotted claim")
+		s.Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}, pvc.Spec.AccessModes)
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		s.Equal("2Gi", size.String(), "expected the restore size, larger than the snapshotted claim")
+	})
+
+	s.Run("refuses to restore a VolumeSnapshot still being taken", func() {
+		s.createSnapshot("data-in-progress", "data", map[string]interface{}{"readyToUse": false})
+
+		toolResult, err := s.CallTool("volumesnapshot_restore", map[string]interface{}{
+			"namespace": s.namespace,
+			"snapshot":  "data-in-progress",
+			"name":      "data-in-progress-restored",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Equal("failed to restore volumesnapshot: volumesnapshot data-in-progress is not ready to use: the snapshot is still being taken",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		_, err = s.client.CoreV1().PersistentVolumeClaims(s.namespace).Get(s.T().Context(), "data-in-progress-restored", metav1.GetOptions{})
+		s.Error(err)
+	})
+
+	s.Run("refuses sizes smaller than the restore size", func() {
+		s.createSnapshot("data-large", "data", map[string]interface{}{"readyToUse": true, "restoreSize": "5Gi"})
+
+		toolResult, err := s.CallTool("volumesnapshot_restore", map[string]interface{}{
+			"namespace": s.namespace,
+			"snapshot":  "data-large",
+			"name":      "data-large-restored",
+			"size":      "1Gi",
+		})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "size 1Gi is smaller than the snapshot restore size 5Gi")
+	})
+}
+
+// createBoundPVC creates a PersistentVolumeClaim bound to a CSI PersistentVolume of the test driver.
+func (s *VolumeSnapshotMcpSuite) createBoundPVC(name string) {
+	pvName := s.namespace + "-" + name
+	_, err := s.client.CoreV1().PersistentVolumes().Create(s.T().Context(), &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: "csi-standard",
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: s.driver, VolumeHandle: pvName},
+			},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.pvs = append(s.pvs, pvName)
+	pvc, err := s.client.CoreV1().PersistentVolumeClaims(s.namespace).Create(s.T().Context(), &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("csi-standard"),
+			VolumeName:       pvName,
+			Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	pvc.Status.Phase = corev1.ClaimBound
+	_, err = s.client.CoreV1().PersistentVolumeClaims(s.namespace).UpdateStatus(s.T().Context(), pvc, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *VolumeSnapshotMcpSuite) createSnapshotClass(name, driver string, isDefault bool) {
+	class := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion":     "snapshot.storage.k8s.io/v1",
+		"kind":           "VolumeSnapshotClass",
+		"metadata":       map[string]interface{}{"name": s.namespace + "-" + name},
+		"driver":         driver,
+		"deletionPolicy": "Delete",
+	}}
+	if isDefault {
+		class.SetAnnotations(map[string]string{"snapshot.storage.kubernetes.io/is-default-class": "true"})
+	}
+	_, err := s.dynamic.Resource(volumeSnapshotTestClassGVR).Create(s.T().Context(), class, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.classes = append(s.classes, class.GetName())
+}
+
+func (s *VolumeSnapshotMcpSuite) createSnapshot(name, pvc string, status map[string]interface{}) {
+	_, err := s.dynamic.Resource(volumeSnapshotTestGVR).Namespace(s.namespace).Create(s.T().Context(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata":   map[string]interface{}{"name": name, "namespace": s.namespace},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"persistentVolumeClaimName": pvc},
+		},
+		"status": status,
+	}}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+}
+
+func (s *VolumeSnapshotMcpSuite) getSnapshot(name string) *unstructured.Unstructured {
+	snapshot, err := s.dynamic.Resource(volumeSnapshotTestGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return snapshot
+}
+
+func (s *VolumeSnapshotMcpSuite) snapshotNotFound(name string) {
+	_, err := s.dynamic.Resource(volumeSnapshotTestGVR).Namespace(s.namespace).Get(s.T().Context(), name, metav1.GetOptions{})
+	s.Error(err, "expected VolumeSnapshot %s not to be created", name)
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	suite.Run(t, new(VolumeSnapshotMcpSuite))
+}
@@ -0,0 +1,69 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// Config holds the connection settings shared by the observability toolset configs, which embed it.
+type Config struct {
+	// Url of the HTTP API. When empty on OpenShift, it is discovered from the Route exposing the API.
+	Url string `toml:"url,omitempty"`
+	// BearerTokenFile overrides the request bearer token with the token read from this file.
+	BearerTokenFile      string `toml:"bearer_token_file,omitempty"`
+	Insecure             bool   `toml:"insecure,omitempty"`
+	CertificateAuthority string `toml:"certificate_authority,omitempty"`
+}
+
+// Validate checks the URL and the files of the connection settings.
+func (c *Config) Validate() error {
+	if c.Url != "" {
+		u, err := url.Parse(c.Url)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("url must be a valid URL")
+		}
+		if strings.EqualFold(u.Scheme, "https") && !c.Insecure && strings.TrimSpace(c.CertificateAuthority) == "" {
+			return errors.New("certificate_authority is required for https when insecure is false")
+		}
+	}
+	if caValue := strings.TrimSpace(c.CertificateAuthority); caValue != "" {
+		if _, err := os.Stat(caValue); err != nil {
+			return fmt.Errorf("certificate_authority must be a valid file path: %w", err)
+		}
+	}
+	if tokenFile := strings.TrimSpace(c.BearerTokenFile); tokenFile != "" {
+		if _, err := os.Stat(tokenFile); err != nil {
+			return fmt.Errorf("bearer_token_file must be a valid file path: %w", err)
+		}
+	}
+	return nil
+}
+
+// Parse resolves the file paths relative to the config directory and, when require_tls is enabled,
+// checks that the settings use TLS (discovered OpenShift Route URLs are always https).
+// The displayName of the API is used in the errors, e.g. "Prometheus".
+func (c *Config) Parse(ctx context.Context, displayName string) error {
+	configDir := config.ConfigDirPathFromContext(ctx)
+	if c.CertificateAuthority != "" && configDir != "" && !filepath.IsAbs(c.CertificateAuthority) {
+		c.CertificateAuthority = filepath.Join(configDir, c.CertificateAuthority)
+	}
+	if c.BearerTokenFile != "" && configDir != "" && !filepath.IsAbs(c.BearerTokenFile) {
+		c.BearerTokenFile = filepath.Join(configDir, c.BearerTokenFile)
+	}
+	if config.RequireTLSFromContext(ctx) {
+		if err := config.ValidateURLRequiresTLS(c.Url, displayName+" URL"); err != nil {
+			return err
+		}
+		if c.Insecure {
+			return fmt.Errorf("require_tls is enabled but %s insecure=true disables certificate verification", displayName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package observability holds the HTTP client and the helpers shared by the clients of the observability
+// APIs deployed next to the cluster: Prometheus, Alertmanager and Loki.
+package observability
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
+	"github.com/containers/kubernetes-mcp-server/pkg/tlsutil"
+)
+
+var (
+	openshiftProjectGVKs = []schema.GroupVersionKind{{Group: "project.openshift.io", Version: "v1", Kind: "Project"}}
+	// RouteGVR is the resource of the OpenShift Routes exposing the APIs.
+	RouteGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+)
+
+// Options are the API specific settings of a Client.
+type Options struct {
+	// Name of the API in the errors and logs, e.g. "prometheus", also the name of its toolset config.
+	Name                string
+	Timeout             time.Duration
+	MaxResponseBodySize int
+	// TooLargeHint tells how to get a smaller response in the error returned for responses exceeding MaxResponseBodySize.
+	TooLargeHint string
+}
+
+// Client is an HTTP client for an observability API, authenticating with the request bearer token or a configured token file.
+type Client struct {
+	Options
+	bearerToken          string
+	bearerTokenFile      string
+	baseURL              string
+	insecure             bool
+	certificateAuthority string
+	tlsMinVersion        string
+	tlsCipherSuites      []string
+	requireTLS           func() bool
+}
+
+// NewClient creates a client using the toolset config and the Kubernetes REST config.
+// The client has no URL when none is configured, DiscoverURL discovers it on OpenShift.
+func NewClient(opts Options, configProvider api.BaseConfig, k8s api.KubernetesClient, cfg *Config) *Client {
+	client := &Client{
+		Options:         opts,
+		tlsMinVersion:   configProvider.GetTLSMinVersionConfig(),
+		tlsCipherSuites: configProvider.GetTLSCipherSuitesConfig(),
+		requireTLS:      configProvider.IsRequireTLS,
+	}
+	var restConfig *rest.Config
+	if k8s != nil {
+		restConfig = k8s.RESTConfig()
+	}
+	if restConfig != nil {
+		client.bearerToken = strings.TrimSpace(restConfig.BearerToken)
+		client.bearerTokenFile = strings.TrimSpace(restConfig.BearerTokenFile)
+	}
+	if cfg != nil {
+		client.baseURL = strings.TrimSpace(cfg.Url)
+		client.insecure = cfg.Insecure
+		client.certificateAuthority = cfg.CertificateAuthority
+		// An explicitly configured token file takes precedence over the request credentials
+		if tokenFile := strings.TrimSpace(cfg.BearerTokenFile); tokenFile != "" {
+			client.bearerToken = ""
+			client.bearerTokenFile = tokenFile
+		}
+	}
+	return client
+}
+
+// URL returns the base URL of the API.
+func (c *Client) URL() string {
+	return c.baseURL
+}
+
+// DiscoverURL sets the URL of a client without configured URL to the https URL of the OpenShift Route exposing the API.
+func (c *Client) DiscoverURL(ctx context.Context, k8s api.KubernetesClient, provider api.FilteringProvider, namespace, name string) error {
+	if c.baseURL != "" {
+		return nil
+	}
+	if k8s == nil || provider == nil || !provider.AnyTargetHasGVKs(ctx, openshiftProjectGVKs) {
+		return fmt.Errorf("%s URL not configured: set url in [toolset_configs.%s]", c.Name, c.Name)
+	}
+	route, err := k8s.DynamicClient().Resource(RouteGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to discover %s URL from route %s/%s: %w", c.Name, namespace, name, err)
+	}
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	if host == "" {
+		return fmt.Errorf("route %s/%s has no host", namespace, name)
+	}
+	c.baseURL = "https://" + host
+	return nil
+}
+
+// validateAndGetURL joins the base URL with the provided relative API endpoint.
+func (c *Client) validateAndGetURL(endpoint string) (*url.URL, error) {
+	if c == nil {
+		return nil, errors.New("client not initialized")
+	}
+	if strings.TrimSpace(c.baseURL) == "" {
+		return nil, fmt.Errorf("%s client not initialized", c.Name)
+	}
+	baseURL, err := url.Parse(strings.TrimSpace(c.baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s base URL: %w", c.Name, err)
+	}
+	endpointURL, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint path: %w", err)
+	}
+	if endpointURL.Scheme != "" || endpointURL.Host != "" {
+		return nil, fmt.Errorf("endpoint must be a relative path, not an absolute URL")
+	}
+	return baseURL.JoinPath(endpointURL.Path), nil
+}
+
+func (c *Client) createHTTPClient(ctx context.Context) (*http.Client, error) {
+	var tlsOpts []tlsutil.TLSConfigOption
+	if c.insecure {
+		tlsOpts = append(tlsOpts, tlsutil.WithInsecureSkipVerify(true))
+		klogutil.LogInfo(klogutil.FromContext(ctx).V(1), "TLS verification disabled", klogutil.Field("api", c.Name))
+	}
+	if caValue := strings.TrimSpace(c.certificateAuthority); caValue != "" {
+		caPEM, err := os.ReadFile(caValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate authority %q: %w", caValue, err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse certificate authority %q", caValue)
+		}
+		tlsOpts = append(tlsOpts, tlsutil.WithRootCAs(certPool))
+	}
+	tlsConfig, err := tlsutil.BuildTLSConfig(c.tlsMinVersion, c.tlsCipherSuites, tlsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TLS config: %w", err)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:       tlsConfig,
+			ResponseHeaderTimeout: c.Timeout,
+		},
+		Timeout: c.Timeout,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return fmt.Errorf("redirects are not allowed for %s API requests", c.Name)
+		},
+	}
+	if c.requireTLS == nil {
+		return client, nil
+	}
+	return config.NewTLSEnforcingClient(client, c.requireTLS), nil
+}
+
+func (c *Client) authorizationHeader(ctx context.Context) string {
+	token := strings.TrimSpace(c.bearerToken)
+	if token == "" && c.bearerTokenFile != "" {
+		data, err := os.ReadFile(c.bearerTokenFile)
+		if err != nil {
+			klogutil.FromContext(ctx).Error(err, "failed to read bearer token file", "path", c.bearerTokenFile)
+			return ""
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return ""
+	}
+	if strings.HasPrefix(token, "Bearer ") {
+		return token
+	}
+	return "Bearer " + token
+}
+
+// Do performs a request against a relative endpoint of the API, with the JSON encoded body when not nil,
+// and returns the status code and the body of the response.
+func (c *Client) Do(ctx context.Context, method, endpoint string, query url.Values, body any) (int, []byte, error) {
+	u, err := c.validateAndGetURL(endpoint)
+	if err != nil {
+		return 0, nil, err
+	}
+	u.RawQuery = query.Encode()
+	klogutil.LogInfo(klogutil.FromContext(ctx).V(0), c.Name+" API call", klogutil.Field("method", method), klogutil.Field("url", u.Redacted()))
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	if authHeader := c.authorizationHeader(ctx); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Kubernetes-MCP-Server", "true")
+	client, err := c.createHTTPClient(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s API call to %s failed: %w", c.Name, u.Redacted(), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, int64(c.MaxResponseBodySize)+1))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(respBody) > c.MaxResponseBodySize {
+		return 0, nil, fmt.Errorf("%s API response exceeded maximum allowed size of %d bytes, %s", c.Name, c.MaxResponseBodySize, c.TooLargeHint)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// StatusError returns the error of a response with a non-2xx status code, nil for 2xx status codes.
+func (c *Client) StatusError(statusCode int, body []byte) error {
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+	if len(body) > 0 {
+		return fmt.Errorf("%s API error (status %d): %s", c.Name, statusCode, strings.TrimSpace(string(body)))
+	}
+	return fmt.Errorf("%s API error: status %d", c.Name, statusCode)
+}
@@ -0,0 +1,196 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type ObservabilitySuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Options    Options
+}
+
+func (s *ObservabilitySuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.Options = Options{Name: "example", MaxResponseBodySize: 64, TooLargeHint: "narrow down the query"}
+}
+
+func (s *ObservabilitySuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *ObservabilitySuite) newClient(k8s api.KubernetesClient, cfg *Config) *Client {
+	if cfg == nil {
+		cfg = &Config{Url: s.MockServer.Config().Host + "/example"}
+	}
+	return NewClient(s.Options, config.Default(), k8s, cfg)
+}
+
+func (s *ObservabilitySuite) TestNewClient() {
+	s.Run("uses request bearer token", func() {
+		client := s.newClient(&fakeKubernetesClient{token: "request-token"}, nil)
+		s.Equal("Bearer request-token", client.authorizationHeader(context.Background()))
+	})
+	s.Run("keeps Bearer prefixed tokens", func() {
+		client := s.newClient(&fakeKubernetesClient{token: "Bearer request-token"}, nil)
+		s.Equal("Bearer request-token", client.authorizationHeader(context.Background()))
+	})
+	s.Run("configured bearer_token_file takes precedence over request bearer token", func() {
+		tokenFile := filepath.Join(s.T().TempDir(), "token")
+		s.Require().NoError(os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+		client := s.newClient(&fakeKubernetesClient{token: "request-token"}, &Config{Url: "http://example.com", BearerTokenFile: tokenFile})
+		s.Equal("Bearer file-token", client.authorizationHeader(context.Background()))
+	})
+	s.Run("has no URL when none is configured", func() {
+		client := NewClient(s.Options, config.Default(), nil, nil)
+		s.Empty(client.URL())
+	})
+}
+
+func (s *ObservabilitySuite) TestDiscoverURL() {
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata":   map[string]interface{}{"name": "example", "namespace": "example-ns"},
+		"spec":       map[string]interface{}{"host": "example.apps.example.com"},
+	}}
+	routes := func(objects ...runtime.Object) *fakeKubernetesClient {
+		return &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{RouteGVR: "RouteList"}, objects...)}
+	}
+	s.Run("keeps the configured URL", func() {
+		client := s.newClient(nil, &Config{Url: "http://configured.example.com"})
+		s.Require().NoError(client.DiscoverURL(context.Background(), nil, nil, "example-ns", "example"))
+		s.Equal("http://configured.example.com", client.URL())
+	})
+	s.Run("fails without URL on non-OpenShift clusters", func() {
+		k8s := routes(route)
+		client := NewClient(s.Options, config.Default(), k8s, nil)
+		err := client.DiscoverURL(context.Background(), k8s, &mockFilteringProvider{}, "example-ns", "example")
+		s.EqualError(err, "example URL not configured: set url in [toolset_configs.example]")
+	})
+	s.Run("discovers URL from route on OpenShift", func() {
+		k8s := routes(route)
+		client := NewClient(s.Options, config.Default(), k8s, nil)
+		s.Require().NoError(client.DiscoverURL(context.Background(), k8s, &mockFilteringProvider{openShift: true}, "example-ns", "example"))
+		s.Equal("https://example.apps.example.com", client.URL())
+	})
+	s.Run("fails when route is missing on OpenShift", func() {
+		k8s := routes()
+		client := NewClient(s.Options, config.Default(), k8s, nil)
+		err := client.DiscoverURL(context.Background(), k8s, &mockFilteringProvider{openShift: true}, "example-ns", "example")
+		s.ErrorContains(err, "failed to discover example URL from route example-ns/example")
+	})
+	s.Run("fails when route has no host", func() {
+		k8s := routes(&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "route.openshift.io/v1",
+			"kind":       "Route",
+			"metadata":   map[string]interface{}{"name": "example", "namespace": "example-ns"},
+		}})
+		client := NewClient(s.Options, config.Default(), k8s, nil)
+		err := client.DiscoverURL(context.Background(), k8s, &mockFilteringProvider{openShift: true}, "example-ns", "example")
+		s.EqualError(err, "route example-ns/example has no host")
+	})
+}
+
+func (s *ObservabilitySuite) TestDo() {
+	var seen *http.Request
+	var seenBody []byte
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+		seenBody = make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(seenBody)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	client := s.newClient(&fakeKubernetesClient{token: "token-xyz"}, nil)
+
+	statusCode, body, err := client.Do(s.T().Context(), http.MethodPost, "/api/v1/items", map[string][]string{"q": {"a b"}}, map[string]string{"name": "item"})
+	s.Require().NoError(err)
+	s.Equal(http.StatusCreated, statusCode)
+	s.Equal(`{"id":"1"}`, string(body))
+	s.Equal("/example/api/v1/items", seen.URL.Path)
+	s.Equal("a b", seen.URL.Query().Get("q"))
+	s.Equal("Bearer token-xyz", seen.Header.Get("Authorization"))
+	s.Equal("application/json", seen.Header.Get("Content-Type"))
+	s.Equal(`{"name":"item"}`, string(seenBody))
+
+	s.Run("rejects absolute endpoints", func() {
+		_, _, err := client.Do(s.T().Context(), http.MethodGet, "https://attacker.example/stolen", nil, nil)
+		s.EqualError(err, "endpoint must be a relative path, not an absolute URL")
+	})
+	s.Run("fails without URL", func() {
+		_, _, err := NewClient(s.Options, config.Default(), nil, nil).Do(s.T().Context(), http.MethodGet, "/api", nil, nil)
+		s.EqualError(err, "example client not initialized")
+	})
+	s.Run("rejects redirects", func() {
+		s.MockServer.ResetHandlers()
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "https://attacker.example/stolen", http.StatusFound)
+		}))
+		_, _, err := client.Do(s.T().Context(), http.MethodGet, "/api", nil, nil)
+		s.ErrorContains(err, "redirects are not allowed for example API requests")
+	})
+	s.Run("rejects oversized responses", func() {
+		s.MockServer.ResetHandlers()
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(make([]byte, s.Options.MaxResponseBodySize+1))
+		}))
+		_, _, err := client.Do(s.T().Context(), http.MethodGet, "/api", nil, nil)
+		s.EqualError(err, fmt.Sprintf("example API response exceeded maximum allowed size of %d bytes, narrow down the query", s.Options.MaxResponseBodySize))
+	})
+}
+
+func (s *ObservabilitySuite) TestStatusError() {
+	client := s.newClient(nil, nil)
+	s.NoError(client.StatusError(http.StatusNoContent, nil))
+	s.EqualError(client.StatusError(http.StatusBadRequest, []byte("bad query\n")), "example API error (status 400): bad query")
+	s.EqualError(client.StatusError(http.StatusBadGateway, nil), "example API error: status 502")
+}
+
+type fakeKubernetesClient struct {
+	api.KubernetesClient
+	token   string
+	dynamic dynamic.Interface
+}
+
+func (f *fakeKubernetesClient) RESTConfig() *rest.Config {
+	return &rest.Config{BearerToken: f.token}
+}
+
+func (f *fakeKubernetesClient) DynamicClient() dynamic.Interface {
+	return f.dynamic
+}
+
+type mockFilteringProvider struct {
+	openShift bool
+}
+
+func (m *mockFilteringProvider) IsTargetCompatibilityToolFiltersEnabled() bool {
+	return true
+}
+
+func (m *mockFilteringProvider) AnyTargetHasGVKs(_ context.Context, _ []schema.GroupVersionKind) bool {
+	return m.openShift
+}
+
+func TestObservability(t *testing.T) {
+	suite.Run(t, new(ObservabilitySuite))
+}
@@ -0,0 +1,88 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Sample is a single timestamped value as returned by the Prometheus API, and by the Loki API for metric queries.
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value string    `json:"value"`
+}
+
+// UnmarshalJSON decodes the Prometheus [<unix_time>, "<value>"] sample representation.
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("invalid sample %s", string(data))
+	}
+	var ts float64
+	if err := json.Unmarshal(raw[0], &ts); err != nil {
+		return fmt.Errorf("invalid sample timestamp: %w", err)
+	}
+	if err := json.Unmarshal(raw[1], &s.Value); err != nil {
+		return fmt.Errorf("invalid sample value: %w", err)
+	}
+	s.Time = time.UnixMilli(int64(ts * 1000)).UTC()
+	return nil
+}
+
+// RangeSeries is an element of a range vector (matrix).
+type RangeSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []Sample          `json:"values"`
+}
+
+// DownsampledSeries is a range series reduced to a bounded number of points so that it fits in an LLM context.
+// Min, Max, and Last are computed over all the original points so that spikes dropped by the sampling are still reported.
+// NaN and ±Inf values (e.g. from a division by zero) are counted in NonNumericValues and left out of Min and Max,
+// they can't be represented as JSON numbers.
+type DownsampledSeries struct {
+	Metric           map[string]string `json:"metric"`
+	Points           []Sample          `json:"points"`
+	DownsampledFrom  int               `json:"downsampledFrom,omitempty"`
+	Min              *float64          `json:"min,omitempty"`
+	Max              *float64          `json:"max,omitempty"`
+	Last             string            `json:"last,omitempty"`
+	NonNumericValues int               `json:"nonNumericValues,omitempty"`
+}
+
+// Downsample reduces the series to at most maxPoints evenly spaced points, always keeping the first and the last one.
+// A maxPoints lower than 2 keeps the series untouched.
+func Downsample(series RangeSeries, maxPoints int) DownsampledSeries {
+	ret := DownsampledSeries{Metric: series.Metric, Points: series.Values}
+	n := len(series.Values)
+	if n == 0 {
+		return ret
+	}
+	ret.Last = series.Values[n-1].Value
+	for _, sample := range series.Values {
+		v, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			ret.NonNumericValues++
+			continue
+		}
+		if ret.Min == nil || v < *ret.Min {
+			ret.Min = &v
+		}
+		if ret.Max == nil || v > *ret.Max {
+			ret.Max = &v
+		}
+	}
+	if maxPoints < 2 || n <= maxPoints {
+		return ret
+	}
+	ret.DownsampledFrom = n
+	ret.Points = make([]Sample, maxPoints)
+	for i := range maxPoints {
+		ret.Points[i] = series.Values[i*(n-1)/(maxPoints-1)]
+	}
+	return ret
+}
@@ -0,0 +1,66 @@
+package observability
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+)
+
+type SeriesSuite struct {
+	suite.Suite
+}
+
+func (s *SeriesSuite) TestSampleUnmarshalJSON() {
+	var sample Sample
+	s.Require().NoError(sample.UnmarshalJSON([]byte(`[1700000000.5,"1"]`)))
+	s.Equal(time.UnixMilli(1700000000500).UTC(), sample.Time)
+	s.Equal("1", sample.Value)
+	s.Error(sample.UnmarshalJSON([]byte(`[1700000000]`)))
+}
+
+func (s *SeriesSuite) TestDownsample() {
+	series := RangeSeries{Metric: map[string]string{"pod": "a"}}
+	for i := range 100 {
+		series.Values = append(series.Values, Sample{Time: time.Unix(int64(i), 0), Value: fmt.Sprint(i)})
+	}
+	series.Values[37].Value = "1000"
+	series.Values[38].Value = "NaN"
+	series.Values[39].Value = "+Inf"
+	series.Values[40].Value = "-Inf"
+
+	s.Run("keeps series with fewer points than maxPoints", func() {
+		downsampled := Downsample(series, 100)
+		s.Len(downsampled.Points, 100)
+		s.Zero(downsampled.DownsampledFrom)
+	})
+	s.Run("reduces series to maxPoints keeping first and last points", func() {
+		downsampled := Downsample(series, 10)
+		s.Len(downsampled.Points, 10)
+		s.Equal(100, downsampled.DownsampledFrom)
+		s.Equal(series.Values[0], downsampled.Points[0])
+		s.Equal(series.Values[99], downsampled.Points[9])
+	})
+	s.Run("reports statistics over all original points", func() {
+		downsampled := Downsample(series, 10)
+		s.Equal(float64(0), *downsampled.Min)
+		s.Equal(float64(1000), *downsampled.Max)
+		s.Equal("99", downsampled.Last)
+		s.Equal(3, downsampled.NonNumericValues)
+	})
+	s.Run("leaves infinite values out of the statistics so the series can be marshalled", func() {
+		downsampled := Downsample(RangeSeries{Values: []Sample{{Value: "+Inf"}, {Value: "-Inf"}}}, 10)
+		s.Nil(downsampled.Min)
+		s.Nil(downsampled.Max)
+		s.Equal("-Inf", downsampled.Last)
+		s.Equal(2, downsampled.NonNumericValues)
+		_, err := yaml.Marshal(downsampled)
+		s.NoError(err)
+	})
+}
+
+func TestSeries(t *testing.T) {
+	suite.Run(t, new(SeriesSuite))
+}
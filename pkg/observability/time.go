@@ -0,0 +1,36 @@
+package observability
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// ParseTime parses an absolute (RFC3339 or Unix timestamp) or relative to now ("now", "-1h", "now-30m") time.
+// An empty value returns the zero time.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return time.Time{}, nil
+	case value == "now":
+		return now, nil
+	case strings.HasPrefix(value, "now-"), strings.HasPrefix(value, "-"):
+		d, err := model.ParseDuration(strings.TrimPrefix(strings.TrimPrefix(value, "now"), "-"))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %w", value, err)
+		}
+		return now.Add(-time.Duration(d)), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return time.UnixMilli(int64(f * 1000)), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected RFC3339, Unix timestamp, or relative time such as -1h", value)
+}
@@ -0,0 +1,39 @@
+package observability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeSuite struct {
+	suite.Suite
+}
+
+func (s *TimeSuite) TestParseTime() {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for value, expected := range map[string]time.Time{
+		"":                     {},
+		"now":                  now,
+		"-1h":                  now.Add(-time.Hour),
+		"now-30m":              now.Add(-30 * time.Minute),
+		"-1d":                  now.Add(-24 * time.Hour),
+		"2026-01-01T00:00:00Z": time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		"1767225600.5":         time.UnixMilli(1767225600500),
+	} {
+		s.Run(value, func() {
+			t, err := ParseTime(value, now)
+			s.Require().NoError(err)
+			s.True(expected.Equal(t), "expected %s, got %s", expected, t)
+		})
+	}
+	s.Run("rejects invalid time", func() {
+		_, err := ParseTime("yesterday", now)
+		s.ErrorContains(err, "invalid time \"yesterday\"")
+	})
+}
+
+func TestTime(t *testing.T) {
+	suite.Run(t, new(TimeSuite))
+}
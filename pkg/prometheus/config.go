@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+// Config holds Prometheus toolset configuration.
+// The url is the Prometheus (or Thanos Querier) HTTP API, discovered from the thanos-querier Route on OpenShift when empty.
+type Config struct {
+	observability.Config
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("prometheus config is nil")
+	}
+	return c.Config.Validate()
+}
+
+func prometheusToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Parse(ctx, "Prometheus"); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("prometheus", prometheusToolsetParser)
+}
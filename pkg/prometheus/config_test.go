@@ -0,0 +1,117 @@
+package prometheus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+	tempDir string
+	caFile  string
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.tempDir = s.T().TempDir()
+	s.caFile = filepath.Join(s.tempDir, "ca.crt")
+	s.Require().NoError(os.WriteFile(s.caFile, []byte("test ca content"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "token"), []byte("file-token"), 0600))
+}
+
+func (s *ConfigSuite) prometheusConfig(cfg *config.StaticConfig) *Config {
+	prometheusCfg, ok := cfg.GetToolsetConfig("prometheus")
+	s.Require().True(ok, "Prometheus config should be present")
+	pcfg, ok := prometheusCfg.(*Config)
+	s.Require().True(ok, "Prometheus config should be of type *Config")
+	return pcfg
+}
+
+func (s *ConfigSuite) TestConfigParser_ResolvesRelativePaths() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.prometheus]
+		url = "https://prometheus.example/"
+		certificate_authority = "ca.crt"
+		bearer_token_file = "token"
+	`), config.WithDirPath(s.tempDir)))
+
+	pcfg := s.prometheusConfig(cfg)
+	s.Equal(s.caFile, pcfg.CertificateAuthority)
+	s.Equal(filepath.Join(s.tempDir, "token"), pcfg.BearerTokenFile)
+}
+
+func (s *ConfigSuite) TestConfigParser_AllowsEmptyURL() {
+	// The URL is discovered from the thanos-querier Route on OpenShift
+	cfg := test.Must(config.ReadToml([]byte(`
+		[toolset_configs.prometheus]
+	`)))
+
+	s.Empty(s.prometheusConfig(cfg).Url)
+}
+
+func (s *ConfigSuite) TestConfigParser_RejectsInvalidConfig() {
+	s.Run("invalid URL", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "not-a-url"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "url must be a valid URL")
+	})
+	s.Run("https without certificate_authority", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "https://prometheus.example/"
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "certificate_authority is required for https when insecure is false")
+	})
+	s.Run("missing bearer_token_file", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "http://prometheus.example/"
+			bearer_token_file = "missing-token"
+		`), config.WithDirPath(s.tempDir))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "bearer_token_file must be a valid file path")
+	})
+}
+
+func (s *ConfigSuite) TestConfigParser_RequireTLS() {
+	s.Run("rejects HTTP URL", func() {
+		_, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.prometheus]
+			url = "http://prometheus.example/"
+		`))
+		s.Require().Error(err)
+		s.ErrorContains(err, "require_tls is enabled but Prometheus URL uses \"http\" scheme")
+	})
+	s.Run("rejects insecure", func() {
+		_, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.prometheus]
+			insecure = true
+		`))
+		s.Require().Error(err)
+		s.ErrorContains(err, "require_tls is enabled but Prometheus insecure=true disables certificate verification")
+	})
+	s.Run("accepts HTTPS URL", func() {
+		cfg, err := config.ReadToml([]byte(`
+			require_tls = true
+			[toolset_configs.prometheus]
+			url = "https://prometheus.example/"
+			certificate_authority = "ca.crt"
+		`), config.WithDirPath(s.tempDir))
+		s.Require().NoError(err)
+		s.Equal("https://prometheus.example/", s.prometheusConfig(cfg).Url)
+	})
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
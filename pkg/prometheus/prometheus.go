@@ -0,0 +1,213 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+const (
+	// ThanosQuerierNamespace and ThanosQuerierRoute identify the OpenShift cluster monitoring query endpoint.
+	ThanosQuerierNamespace = "openshift-monitoring"
+	ThanosQuerierRoute     = "thanos-querier"
+
+	defaultHTTPTimeout  = 30 * time.Second
+	maxResponseBodySize = 8 << 20 // 8 MiB
+)
+
+// Prometheus is an HTTP client for the Prometheus (or Thanos Querier) query API.
+type Prometheus struct {
+	*observability.Client
+}
+
+// NewPrometheus creates a client using the toolset config and the Kubernetes REST config.
+// When no URL is configured and the cluster is OpenShift, the URL is discovered from the thanos-querier Route.
+func NewPrometheus(ctx context.Context, configProvider api.BaseConfig, k8s api.KubernetesClient, provider api.FilteringProvider) (*Prometheus, error) {
+	var cfg *observability.Config
+	if c, ok := configProvider.GetToolsetConfig("prometheus"); ok {
+		if pc, ok := c.(*Config); ok && pc != nil {
+			cfg = &pc.Config
+		}
+	}
+	client := &Prometheus{Client: observability.NewClient(observability.Options{
+		Name:                "prometheus",
+		Timeout:             defaultHTTPTimeout,
+		MaxResponseBodySize: maxResponseBodySize,
+		TooLargeHint:        "narrow down the query",
+	}, configProvider, k8s, cfg)}
+	if err := client.DiscoverURL(ctx, k8s, provider, ThanosQuerierNamespace, ThanosQuerierRoute); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// apiResponse is the envelope shared by all Prometheus HTTP API responses.
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+// get performs a GET request against the Prometheus HTTP API and decodes the response data into result.
+func (p *Prometheus) get(ctx context.Context, endpoint string, query url.Values, result any) ([]string, error) {
+	statusCode, body, err := p.Do(ctx, http.MethodGet, endpoint, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponse
+	if err = json.Unmarshal(body, &envelope); err != nil {
+		if statusErr := p.StatusError(statusCode, body); statusErr != nil {
+			return nil, statusErr
+		}
+		return nil, fmt.Errorf("failed to decode prometheus API response: %w", err)
+	}
+	if envelope.Status != "success" {
+		if envelope.Error == "" {
+			return nil, fmt.Errorf("prometheus API error: status %d", statusCode)
+		}
+		return nil, fmt.Errorf("prometheus API error (%s): %s", envelope.ErrorType, envelope.Error)
+	}
+	if result != nil {
+		if err = json.Unmarshal(envelope.Data, result); err != nil {
+			return nil, fmt.Errorf("failed to decode prometheus API response data: %w", err)
+		}
+	}
+	return envelope.Warnings, nil
+}
+
+// InstantSeries is an element of an instant vector.
+type InstantSeries struct {
+	Metric map[string]string    `json:"metric"`
+	Value  observability.Sample `json:"value"`
+}
+
+// QueryResult is the decoded result of an instant or range query.
+type QueryResult struct {
+	ResultType string
+	Vector     []InstantSeries
+	Matrix     []observability.RangeSeries
+	Scalar     *observability.Sample
+	Warnings   []string
+}
+
+func (r *QueryResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.ResultType = raw.ResultType
+	switch raw.ResultType {
+	case "vector":
+		return json.Unmarshal(raw.Result, &r.Vector)
+	case "matrix":
+		return json.Unmarshal(raw.Result, &r.Matrix)
+	case "scalar", "string":
+		r.Scalar = &observability.Sample{}
+		return json.Unmarshal(raw.Result, r.Scalar)
+	default:
+		return fmt.Errorf("unsupported result type %q", raw.ResultType)
+	}
+}
+
+// Query evaluates an instant query at the provided time (or the server's current time if zero).
+func (p *Prometheus) Query(ctx context.Context, query string, ts time.Time) (*QueryResult, error) {
+	values := url.Values{"query": {query}}
+	if !ts.IsZero() {
+		values.Set("time", formatTime(ts))
+	}
+	result := &QueryResult{}
+	warnings, err := p.get(ctx, "/api/v1/query", values, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+	return result, nil
+}
+
+// QueryRange evaluates a range query over [start, end] with the provided resolution step.
+func (p *Prometheus) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*QueryResult, error) {
+	values := url.Values{
+		"query": {query},
+		"start": {formatTime(start)},
+		"end":   {formatTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+	result := &QueryResult{}
+	warnings, err := p.get(ctx, "/api/v1/query_range", values, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+	return result, nil
+}
+
+// Series returns the label sets of the series matching any of the provided selectors.
+func (p *Prometheus) Series(ctx context.Context, matches []string, start, end time.Time) ([]map[string]string, error) {
+	var series []map[string]string
+	_, err := p.get(ctx, "/api/v1/series", timeRangeValues(matches, start, end), &series)
+	return series, err
+}
+
+// Labels returns the label names, optionally restricted to the series matching the provided selectors.
+func (p *Prometheus) Labels(ctx context.Context, matches []string, start, end time.Time) ([]string, error) {
+	var labels []string
+	_, err := p.get(ctx, "/api/v1/labels", timeRangeValues(matches, start, end), &labels)
+	return labels, err
+}
+
+// LabelValues returns the values of the provided label, optionally restricted to the series matching the provided selectors.
+func (p *Prometheus) LabelValues(ctx context.Context, label string, matches []string, start, end time.Time) ([]string, error) {
+	var values []string
+	_, err := p.get(ctx, "/api/v1/label/"+url.PathEscape(label)+"/values", timeRangeValues(matches, start, end), &values)
+	return values, err
+}
+
+// Alert is an active (pending or firing) alert as reported by the Prometheus rule evaluator.
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	State       string            `json:"state"`
+	ActiveAt    *time.Time        `json:"activeAt,omitempty"`
+	Value       string            `json:"value,omitempty"`
+}
+
+// Alerts returns the active alerts.
+func (p *Prometheus) Alerts(ctx context.Context) ([]Alert, error) {
+	var data struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	if _, err := p.get(ctx, "/api/v1/alerts", url.Values{}, &data); err != nil {
+		return nil, err
+	}
+	return data.Alerts, nil
+}
+
+func timeRangeValues(matches []string, start, end time.Time) url.Values {
+	values := url.Values{}
+	for _, m := range matches {
+		values.Add("match[]", m)
+	}
+	if !start.IsZero() {
+		values.Set("start", formatTime(start))
+	}
+	if !end.IsZero() {
+		values.Set("end", formatTime(end))
+	}
+	return values
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
@@ -0,0 +1,240 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+type PrometheusSuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Config     *config.StaticConfig
+}
+
+func (s *PrometheusSuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.Config = test.Must(config.ReadToml([]byte(fmt.Sprintf(`
+		[toolset_configs.prometheus]
+		url = "%s/prometheus"
+	`, s.MockServer.Config().Host))))
+}
+
+func (s *PrometheusSuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *PrometheusSuite) TestNewPrometheus() {
+	s.Run("configured bearer_token_file takes precedence over request bearer token", func() {
+		var seenAuth string
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seenAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		}))
+		tokenFile := filepath.Join(s.T().TempDir(), "token")
+		s.Require().NoError(os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "` + s.MockServer.Config().Host + `"
+			bearer_token_file = "` + filepath.ToSlash(tokenFile) + `"
+		`)))
+		client, err := NewPrometheus(context.Background(), cfg, &fakeKubernetesClient{token: "request-token"}, nil)
+		s.Require().NoError(err)
+		_, err = client.Query(s.T().Context(), "up", time.Time{})
+		s.Require().NoError(err)
+		s.Equal("Bearer file-token", seenAuth)
+	})
+	s.Run("fails without URL on non-OpenShift clusters", func() {
+		_, err := NewPrometheus(context.Background(), config.Default(), &fakeKubernetesClient{}, &mockFilteringProvider{})
+		s.Require().Error(err)
+		s.ErrorContains(err, "prometheus URL not configured")
+	})
+	s.Run("discovers URL from thanos-querier route on OpenShift", func() {
+		route := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "route.openshift.io/v1",
+			"kind":       "Route",
+			"metadata":   map[string]interface{}{"name": ThanosQuerierRoute, "namespace": ThanosQuerierNamespace},
+			"spec":       map[string]interface{}{"host": "thanos-querier.apps.example.com"},
+		}}
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"}, route)}
+		client, err := NewPrometheus(context.Background(), config.Default(), k8s, &mockFilteringProvider{openShift: true})
+		s.Require().NoError(err)
+		s.Equal("https://thanos-querier.apps.example.com", client.URL())
+	})
+	s.Run("fails when thanos-querier route is missing on OpenShift", func() {
+		k8s := &fakeKubernetesClient{dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{observability.RouteGVR: "RouteList"})}
+		_, err := NewPrometheus(context.Background(), config.Default(), k8s, &mockFilteringProvider{openShift: true})
+		s.Require().Error(err)
+		s.ErrorContains(err, "failed to discover prometheus URL from route openshift-monitoring/thanos-querier")
+	})
+}
+
+func (s *PrometheusSuite) TestQuery() {
+	var seenPath string
+	var seenQuery url.Values
+	var seenAuth string
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenPath = r.URL.Path
+		seenQuery = r.URL.Query()
+		seenAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[` +
+			`{"metric":{"__name__":"up","job":"kubelet"},"value":[1700000000.5,"1"]}]},"warnings":["partial response"]}`))
+	}))
+	client, err := NewPrometheus(context.Background(), s.Config, &fakeKubernetesClient{token: "token-xyz"}, nil)
+	s.Require().NoError(err)
+
+	result, err := client.Query(s.T().Context(), "up", time.Unix(1700000000, 0))
+	s.Require().NoError(err)
+	s.Equal("/prometheus/api/v1/query", seenPath)
+	s.Equal("up", seenQuery.Get("query"))
+	s.Equal("1700000000", seenQuery.Get("time"))
+	s.Equal("Bearer token-xyz", seenAuth)
+	s.Equal("vector", result.ResultType)
+	s.Require().Len(result.Vector, 1)
+	s.Equal("kubelet", result.Vector[0].Metric["job"])
+	s.Equal("1", result.Vector[0].Value.Value)
+	s.Equal(time.UnixMilli(1700000000500).UTC(), result.Vector[0].Value.Time)
+	s.Equal([]string{"partial response"}, result.Warnings)
+
+	s.Run("returns Prometheus API errors", func() {
+		s.MockServer.ResetHandlers()
+		s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"1:3: parse error: unexpected end of input"}`))
+		}))
+		_, err := client.Query(s.T().Context(), "up{", time.Time{})
+		s.Require().Error(err)
+		s.EqualError(err, "prometheus API error (bad_data): 1:3: parse error: unexpected end of input")
+	})
+}
+
+func (s *PrometheusSuite) TestQueryRange() {
+	var seenQuery url.Values
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenQuery = r.URL.Query()
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+			`{"metric":{"pod":"a"},"values":[[1700000000,"1"],[1700000060,"2"]]}]}}`))
+	}))
+	client, err := NewPrometheus(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	result, err := client.QueryRange(s.T().Context(), "sum by (pod) (up)", time.Unix(1700000000, 0), time.Unix(1700003600, 0), 90*time.Second)
+	s.Require().NoError(err)
+	s.Equal("1700000000", seenQuery.Get("start"))
+	s.Equal("1700003600", seenQuery.Get("end"))
+	s.Equal("90", seenQuery.Get("step"))
+	s.Require().Len(result.Matrix, 1)
+	s.Len(result.Matrix[0].Values, 2)
+}
+
+func (s *PrometheusSuite) TestMetadata() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/prometheus/api/v1/series":
+			s.Equal([]string{`up{job="kubelet"}`, "kube_pod_info"}, r.URL.Query()["match[]"])
+			_, _ = w.Write([]byte(`{"status":"success","data":[{"__name__":"up","job":"kubelet"}]}`))
+		case "/prometheus/api/v1/labels":
+			_, _ = w.Write([]byte(`{"status":"success","data":["__name__","job"]}`))
+		case "/prometheus/api/v1/label/job/values":
+			_, _ = w.Write([]byte(`{"status":"success","data":["kubelet"]}`))
+		case "/prometheus/api/v1/alerts":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"alerts":[{"labels":{"alertname":"Watchdog"},"state":"firing","activeAt":"2026-01-01T00:00:00Z","value":"1e+00"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client, err := NewPrometheus(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	series, err := client.Series(s.T().Context(), []string{`up{job="kubelet"}`, "kube_pod_info"}, time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Equal([]map[string]string{{"__name__": "up", "job": "kubelet"}}, series)
+
+	labels, err := client.Labels(s.T().Context(), nil, time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Equal([]string{"__name__", "job"}, labels)
+
+	values, err := client.LabelValues(s.T().Context(), "job", nil, time.Time{}, time.Time{})
+	s.Require().NoError(err)
+	s.Equal([]string{"kubelet"}, values)
+
+	alerts, err := client.Alerts(s.T().Context())
+	s.Require().NoError(err)
+	s.Require().Len(alerts, 1)
+	s.Equal("Watchdog", alerts[0].Labels["alertname"])
+	s.Equal("firing", alerts[0].State)
+}
+
+func (s *PrometheusSuite) TestGet_rejectsRedirects() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://attacker.example/stolen", http.StatusFound)
+	}))
+	client, err := NewPrometheus(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	_, err = client.Query(s.T().Context(), "up", time.Time{})
+	s.Require().Error(err)
+	s.ErrorContains(err, "redirects are not allowed")
+}
+
+func (s *PrometheusSuite) TestGet_rejectsOversizedResponses() {
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(make([]byte, maxResponseBodySize+1))
+	}))
+	client, err := NewPrometheus(context.Background(), s.Config, nil, nil)
+	s.Require().NoError(err)
+
+	_, err = client.Query(s.T().Context(), "up", time.Time{})
+	s.Require().Error(err)
+	s.ErrorContains(err, fmt.Sprintf("exceeded maximum allowed size of %d bytes", maxResponseBodySize))
+}
+
+type fakeKubernetesClient struct {
+	api.KubernetesClient
+	token   string
+	dynamic dynamic.Interface
+}
+
+func (f *fakeKubernetesClient) RESTConfig() *rest.Config {
+	return &rest.Config{BearerToken: f.token}
+}
+
+func (f *fakeKubernetesClient) DynamicClient() dynamic.Interface {
+	return f.dynamic
+}
+
+type mockFilteringProvider struct {
+	openShift bool
+}
+
+func (m *mockFilteringProvider) IsTargetCompatibilityToolFiltersEnabled() bool {
+	return true
+}
+
+func (m *mockFilteringProvider) AnyTargetHasGVKs(_ context.Context, gvks []schema.GroupVersionKind) bool {
+	return m.openShift
+}
+
+func TestPrometheus(t *testing.T) {
+	suite.Run(t, new(PrometheusSuite))
+}
@@ -0,0 +1,65 @@
+package alertmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/alertmanager"
+)
+
+type AlertmanagerSuite struct {
+	suite.Suite
+}
+
+func (s *AlertmanagerSuite) TestSummarizeAlerts() {
+	at := func(minutes int) time.Time {
+		return time.Date(2026, 1, 2, 0, minutes, 0, 0, time.UTC)
+	}
+	summaries := summarizeAlerts([]alertmanager.Alert{
+		{Labels: map[string]string{"alertname": "Watchdog", "severity": "none"}, StartsAt: at(0)},
+		{Labels: map[string]string{"alertname": "KubePodCrashLooping", "severity": "warning"}, StartsAt: at(2),
+			Annotations: map[string]string{"message": "pod is crash looping"}},
+		{Labels: map[string]string{"alertname": "KubePodNotReady", "severity": "warning"}, StartsAt: at(1),
+			Receivers: []alertmanager.Receiver{{Name: "default"}}, Status: alertmanager.AlertStatus{State: "active"}},
+		{Labels: map[string]string{"alertname": "TargetDown", "severity": "critical"}, StartsAt: at(3),
+			Annotations: map[string]string{"summary": "targets are down", "description": "50% of the targets are down"}},
+	})
+	s.Require().Len(summaries, 4)
+	s.Equal("TargetDown", summaries[0].Alertname)
+	s.Equal("targets are down", summaries[0].Summary)
+	s.Equal("50% of the targets are down", summaries[0].Description)
+	s.Equal("KubePodNotReady", summaries[1].Alertname)
+	s.Equal([]string{"default"}, summaries[1].Receivers)
+	s.Equal("active", summaries[1].State)
+	s.Equal("KubePodCrashLooping", summaries[2].Alertname)
+	s.Equal("pod is crash looping", summaries[2].Description)
+	s.Equal("Watchdog", summaries[3].Alertname)
+}
+
+func (s *AlertmanagerSuite) TestSummarizeSilences() {
+	silences := []alertmanager.Silence{
+		{ID: "expired", Status: alertmanager.SilenceStatus{State: "expired"}},
+		{ID: "active", Status: alertmanager.SilenceStatus{State: "active"},
+			Matchers: []alertmanager.Matcher{{Name: "alertname", Value: "Watchdog", IsEqual: ptr.To(true)}}},
+		{ID: "pending", Status: alertmanager.SilenceStatus{State: "pending"}},
+	}
+	s.Run("excludes expired silences by default", func() {
+		summaries := summarizeSilences(silences, "")
+		s.Require().Len(summaries, 2)
+		s.Equal("active", summaries[0].ID)
+		s.Equal([]string{`alertname="Watchdog"`}, summaries[0].Matchers)
+		s.Equal("pending", summaries[1].ID)
+	})
+	s.Run("filters by state", func() {
+		summaries := summarizeSilences(silences, "expired")
+		s.Require().Len(summaries, 1)
+		s.Equal("expired", summaries[0].ID)
+	})
+}
+
+func TestAlertmanager(t *testing.T) {
+	suite.Run(t, new(AlertmanagerSuite))
+}
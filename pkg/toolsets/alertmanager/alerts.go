@@ -0,0 +1,164 @@
+package alertmanager
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/alertmanager"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+func initAlerts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "alerts_list",
+			Description: "List the alerts currently firing in Alertmanager, sorted by severity and start time. " +
+				"Silenced and inhibited alerts are excluded unless requested",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": {
+						Type:        "array",
+						Description: "Label matchers the alerts must satisfy, e.g. [\"alertname=\\\"KubePodCrashLooping\\\"\", \"severity=~\\\"critical|warning\\\"\", \"namespace!=\\\"openshift-monitoring\\\"\"] (Optional)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"receiver": {
+						Type:        "string",
+						Description: "Regular expression matching the name of the receivers the alerts are routed to (Optional)",
+					},
+					"include_silenced": {
+						Type:        "boolean",
+						Description: "Include silenced alerts (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"include_inhibited": {
+						Type:        "boolean",
+						Description: "Include inhibited alerts (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of alerts to return (Optional, defaults to %d, max %d)", defaultLimit, maxLimit),
+						Default:     api.ToRawMessage(defaultLimit),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(maxLimit)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: List Alerts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: alertsList},
+	}
+}
+
+type alertSummary struct {
+	Alertname   string            `json:"alertname"`
+	Severity    string            `json:"severity,omitempty"`
+	State       string            `json:"state"`
+	StartsAt    time.Time         `json:"startsAt"`
+	Labels      map[string]string `json:"labels"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Receivers   []string          `json:"receivers,omitempty"`
+	SilencedBy  []string          `json:"silencedBy,omitempty"`
+	InhibitedBy []string          `json:"inhibitedBy,omitempty"`
+}
+
+type alertsReport struct {
+	Total     int            `json:"total"`
+	Truncated bool           `json:"truncated,omitempty"`
+	Alerts    []alertSummary `json:"alerts"`
+}
+
+func alertsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	filter := alertmanager.AlertsFilter{
+		Matchers:  p.OptionalStringArray("matchers"),
+		Receiver:  p.OptionalString("receiver", ""),
+		Silenced:  p.OptionalBool("include_silenced", false),
+		Inhibited: p.OptionalBool("include_inhibited", false),
+	}
+	limit := int(min(max(p.OptionalInt64("limit", defaultLimit), 1), maxLimit))
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts: %w", err)), nil
+	}
+	client, err := alertmanager.NewAlertmanager(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts: %w", err)), nil
+	}
+	alerts, err := client.Alerts(params.Context, filter)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts: %w", err)), nil
+	}
+	if len(alerts) == 0 {
+		return api.NewToolCallResult("No alerts found", nil), nil
+	}
+	summaries := summarizeAlerts(alerts)
+	out, err := output.MarshalYaml(&alertsReport{
+		Total:     len(summaries),
+		Truncated: len(summaries) > limit,
+		Alerts:    summaries[:min(len(summaries), limit)],
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal alerts: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
+
+// severityRank orders the conventional alert severities, unknown severities are sorted last.
+var severityRank = map[string]int{"critical": 0, "error": 1, "warning": 2, "info": 3, "none": 4}
+
+func summarizeAlerts(alerts []alertmanager.Alert) []alertSummary {
+	summaries := make([]alertSummary, 0, len(alerts))
+	for _, a := range alerts {
+		s := alertSummary{
+			Alertname:   a.Labels["alertname"],
+			Severity:    a.Labels["severity"],
+			State:       a.Status.State,
+			StartsAt:    a.StartsAt,
+			Labels:      a.Labels,
+			Summary:     a.Annotations["summary"],
+			Description: a.Annotations["description"],
+			SilencedBy:  a.Status.SilencedBy,
+			InhibitedBy: a.Status.InhibitedBy,
+		}
+		if s.Description == "" {
+			s.Description = a.Annotations["message"]
+		}
+		for _, r := range a.Receivers {
+			s.Receivers = append(s.Receivers, r.Name)
+		}
+		summaries = append(summaries, s)
+	}
+	slices.SortStableFunc(summaries, func(a, b alertSummary) int {
+		return cmp.Or(
+			cmp.Compare(rank(a.Severity), rank(b.Severity)),
+			a.StartsAt.Compare(b.StartsAt),
+			cmp.Compare(a.Alertname, b.Alertname),
+		)
+	})
+	return summaries
+}
+
+func rank(severity string) int {
+	if r, ok := severityRank[severity]; ok {
+		return r
+	}
+	return len(severityRank)
+}
@@ -0,0 +1,224 @@
+package alertmanager
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/prometheus/common/model"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/alertmanager"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const defaultCreatedBy = "kubernetes-mcp-server"
+
+func initSilences() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "silences_list",
+			Description: "List the Alertmanager silences, active and pending silences are returned unless a state is provided",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": {
+						Type:        "array",
+						Description: "Label matchers the silences must satisfy, e.g. [\"alertname=\\\"KubePodCrashLooping\\\"\"] (Optional)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"state": {
+						Type:        "string",
+						Description: "Only return silences in this state (Optional, active and pending silences if not provided)",
+						Enum:        []any{"active", "pending", "expired"},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: List Silences",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silencesList},
+		{Tool: api.Tool{
+			Name: "silence_create",
+			Description: "Create an Alertmanager silence muting the alerts that match all the provided matchers for the provided duration. " +
+				"The duration is bounded by the configured max_silence_duration (24h by default). " +
+				"Use alerts_list first to check which alerts will be muted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": {
+						Type:        "array",
+						Description: "Label matchers of the alerts to mute, e.g. [\"alertname=\\\"KubePodCrashLooping\\\"\", \"namespace=\\\"my-app\\\"\"]. Operators =, !=, =~, and !~ are supported",
+						Items:       &jsonschema.Schema{Type: "string"},
+						MinItems:    ptr.To(1),
+					},
+					"duration": {
+						Type:        "string",
+						Description: "How long the silence lasts from now, e.g. 30m or 2h",
+					},
+					"comment": {
+						Type:        "string",
+						Description: "Why the alerts are muted, e.g. the incident or ticket reference",
+					},
+					"created_by": {
+						Type:        "string",
+						Description: fmt.Sprintf("Author of the silence (Optional, defaults to %s)", defaultCreatedBy),
+					},
+				},
+				Required: []string{"matchers", "duration", "comment"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Create Silence",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silenceCreate},
+		{Tool: api.Tool{
+			Name:        "silence_expire",
+			Description: "Expire an Alertmanager silence so the alerts it mutes notify again",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the silence to expire",
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Expire Silence",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silenceExpire},
+	}
+}
+
+type silenceSummary struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	Matchers  []string  `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+func silencesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	matchers := p.OptionalStringArray("matchers")
+	state := p.OptionalString("state", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences: %w", err)), nil
+	}
+	client, err := alertmanager.NewAlertmanager(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences: %w", err)), nil
+	}
+	silences, err := client.Silences(params.Context, matchers)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences: %w", err)), nil
+	}
+	summaries := summarizeSilences(silences, state)
+	if len(summaries) == 0 {
+		return api.NewToolCallResult("No silences found", nil), nil
+	}
+	out, err := output.MarshalYaml(summaries)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal silences: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
+
+func summarizeSilences(silences []alertmanager.Silence, state string) []silenceSummary {
+	summaries := make([]silenceSummary, 0, len(silences))
+	for _, s := range silences {
+		if (state == "" && s.Status.State == "expired") || (state != "" && s.Status.State != state) {
+			continue
+		}
+		summary := silenceSummary{
+			ID:        s.ID,
+			State:     s.Status.State,
+			StartsAt:  s.StartsAt,
+			EndsAt:    s.EndsAt,
+			CreatedBy: s.CreatedBy,
+			Comment:   s.Comment,
+		}
+		for _, m := range s.Matchers {
+			summary.Matchers = append(summary.Matchers, m.String())
+		}
+		summaries = append(summaries, summary)
+	}
+	slices.SortStableFunc(summaries, func(a, b silenceSummary) int {
+		return cmp.Or(cmp.Compare(a.State, b.State), a.EndsAt.Compare(b.EndsAt))
+	})
+	return summaries
+}
+
+func silenceCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	matcherParams := p.OptionalStringArray("matchers")
+	if p.Err() == nil && len(matcherParams) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: matchers parameter required")), nil
+	}
+	durationParam := p.RequiredString("duration")
+	comment := strings.TrimSpace(p.RequiredString("comment"))
+	createdBy := p.OptionalString("created_by", defaultCreatedBy)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+	}
+	silence := alertmanager.PostableSilence{Comment: comment, CreatedBy: createdBy}
+	for _, m := range matcherParams {
+		matcher, err := alertmanager.ParseMatcher(m)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+		}
+		silence.Matchers = append(silence.Matchers, matcher)
+	}
+	duration, err := model.ParseDuration(durationParam)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: invalid duration %q: %w", durationParam, err)), nil
+	}
+	client, err := alertmanager.NewAlertmanager(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+	}
+	silence.StartsAt = time.Now().UTC()
+	silence.EndsAt = silence.StartsAt.Add(time.Duration(duration))
+	id, err := client.CreateSilence(params.Context, silence)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+	}
+	ret := fmt.Sprintf("Silence %s created, muting alerts matching {%s} until %s",
+		id, strings.Join(matcherParams, ", "), silence.EndsAt.Format(time.RFC3339))
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func silenceExpire(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	id := p.RequiredString("id")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence: %w", err)), nil
+	}
+	client, err := alertmanager.NewAlertmanager(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence: %w", err)), nil
+	}
+	if err = client.ExpireSilence(params.Context, id); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence %s: %w", id, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Silence %s expired", id), nil), nil
+}
@@ -0,0 +1,43 @@
+package alertmanager
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "alertmanager"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Alertmanager tools to list alerts and manage silences. Check the [Alertmanager documentation](https://github.com/containers/kubernetes-mcp-server/blob/main/docs/ALERTMANAGER.md) for more details."
+}
+
+func (t *Toolset) GetTools(_ api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initAlerts(),
+		initSilences(),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
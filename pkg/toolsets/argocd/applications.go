@@ -0,0 +1,475 @@
+package argocd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const argoCDGroup = "argoproj.io"
+
+var (
+	applicationGVR    = schema.GroupVersionResource{Group: argoCDGroup, Version: "v1alpha1", Resource: "applications"}
+	applicationSetGVR = schema.GroupVersionResource{Group: argoCDGroup, Version: "v1alpha1", Resource: "applicationsets"}
+)
+
+// hasArgoCD returns a filter that hides the tools when the provided Argo CD CRDs are not installed.
+func hasArgoCD(p api.FilteringProvider, kinds ...string) func() bool {
+	gvks := make([]schema.GroupVersionKind, 0, len(kinds))
+	for _, kind := range kinds {
+		gvks = append(gvks, schema.GroupVersionKind{Group: argoCDGroup, Version: "v1alpha1", Kind: kind})
+	}
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), gvks)
+	}
+}
+
+func initApplications(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "argocd_applications_list",
+			Description: "List Argo CD Applications with their source, destination, sync status, health status, automated sync policy, and last operation. " +
+				"Resources managed by an Application with automated self-heal are reverted by Argo CD when edited directly in the cluster: change the desired state in Git instead",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Applications, usually the Argo CD namespace such as argocd or openshift-gitops (Optional, all namespaces if not provided)",
+					},
+					"project": {
+						Type:        "string",
+						Description: "Only list the Applications of this Argo CD project (Optional)",
+					},
+					"sync_status": {
+						Type:        "string",
+						Description: "Only list the Applications with this sync status (Optional)",
+						Enum:        []any{"Synced", "OutOfSync", "Unknown"},
+					},
+					"health_status": {
+						Type:        "string",
+						Description: "Only list the Applications with this health status (Optional)",
+						Enum:        []any{"Healthy", "Progressing", "Degraded", "Suspended", "Missing", "Unknown"},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Argo CD: List Applications",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: applicationsList, TargetCompatibilityFilters: []func() bool{hasArgoCD(p, "Application")}},
+		{Tool: api.Tool{
+			Name: "argocd_application_diff",
+			Description: "Show the resources of an Argo CD Application that are out of sync with the desired state or need pruning, the unhealthy resources, " +
+				"the Application conditions, and the result of the last sync operation including the resources that failed to sync",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Application",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Application (Optional, the Application is looked up in all namespaces if not provided)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Argo CD: Application Diff",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: applicationDiff, TargetCompatibilityFilters: []func() bool{hasArgoCD(p, "Application")}},
+	}
+}
+
+// applicationObject is the subset of an Argo CD Application inspected by this toolset.
+type applicationObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Project     string              `json:"project"`
+		Source      *applicationSource  `json:"source,omitempty"`
+		Sources     []applicationSource `json:"sources,omitempty"`
+		Destination struct {
+			Server    string `json:"server,omitempty"`
+			Name      string `json:"name,omitempty"`
+			Namespace string `json:"namespace,omitempty"`
+		} `json:"destination"`
+		SyncPolicy *struct {
+			Automated *struct {
+				Prune    bool  `json:"prune,omitempty"`
+				SelfHeal bool  `json:"selfHeal,omitempty"`
+				Enabled  *bool `json:"enabled,omitempty"`
+			} `json:"automated,omitempty"`
+		} `json:"syncPolicy,omitempty"`
+	} `json:"spec"`
+	Operation map[string]any `json:"operation,omitempty"`
+	Status    struct {
+		Sync struct {
+			Status   string `json:"status"`
+			Revision string `json:"revision,omitempty"`
+		} `json:"sync"`
+		Health struct {
+			Status  string `json:"status"`
+			Message string `json:"message,omitempty"`
+		} `json:"health"`
+		Resources  []resourceStatus `json:"resources,omitempty"`
+		Conditions []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"conditions,omitempty"`
+		OperationState *operationState `json:"operationState,omitempty"`
+		ReconciledAt   *metav1.Time    `json:"reconciledAt,omitempty"`
+	} `json:"status"`
+}
+
+type applicationSource struct {
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path,omitempty"`
+	Chart          string `json:"chart,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+}
+
+func (s applicationSource) describe() string {
+	ret := s.RepoURL
+	if s.Chart != "" {
+		ret += " chart " + s.Chart
+	} else if s.Path != "" {
+		ret += " path " + s.Path
+	}
+	return ret + "@" + cmp.Or(s.TargetRevision, "HEAD")
+}
+
+type resourceStatus struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	Health    *struct {
+		Status  string `json:"status,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"health,omitempty"`
+	Hook            bool `json:"hook,omitempty"`
+	RequiresPruning bool `json:"requiresPruning,omitempty"`
+}
+
+func (r resourceStatus) describe() string {
+	kind := r.Kind
+	if r.Group != "" {
+		kind += "." + r.Group
+	}
+	if r.Namespace == "" {
+		return kind + " " + r.Name
+	}
+	return fmt.Sprintf("%s %s/%s", kind, r.Namespace, r.Name)
+}
+
+type operationState struct {
+	Phase      string       `json:"phase"`
+	Message    string       `json:"message,omitempty"`
+	StartedAt  metav1.Time  `json:"startedAt"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+	SyncResult *struct {
+		Revision  string `json:"revision,omitempty"`
+		Resources []struct {
+			Group     string `json:"group,omitempty"`
+			Kind      string `json:"kind"`
+			Namespace string `json:"namespace,omitempty"`
+			Name      string `json:"name"`
+			Status    string `json:"status,omitempty"`
+			Message   string `json:"message,omitempty"`
+			HookPhase string `json:"hookPhase,omitempty"`
+		} `json:"resources,omitempty"`
+	} `json:"syncResult,omitempty"`
+}
+
+type applicationSummary struct {
+	Name        string   `json:"name"`
+	Namespace   string   `json:"namespace"`
+	Project     string   `json:"project"`
+	Sources     []string `json:"sources"`
+	Destination string   `json:"destination"`
+	Sync        string   `json:"sync"`
+	Revision    string   `json:"revision,omitempty"`
+	Health      string   `json:"health"`
+	AutoSync    string   `json:"autoSync,omitempty"`
+	OutOfSync   int      `json:"outOfSync,omitempty"`
+	Operation   string   `json:"operation,omitempty"`
+	Conditions  []string `json:"conditions,omitempty"`
+}
+
+type applicationDiffReport struct {
+	Name          string             `json:"name"`
+	Namespace     string             `json:"namespace"`
+	Sync          string             `json:"sync"`
+	Revision      string             `json:"revision,omitempty"`
+	Health        string             `json:"health"`
+	AutoSync      string             `json:"autoSync,omitempty"`
+	ReconciledAt  *metav1.Time       `json:"reconciledAt,omitempty"`
+	OutOfSync     []resourceReport   `json:"outOfSync,omitempty"`
+	Unhealthy     []resourceReport   `json:"unhealthy,omitempty"`
+	Conditions    []string           `json:"conditions,omitempty"`
+	LastOperation *lastOperationInfo `json:"lastOperation,omitempty"`
+	Hint          string             `json:"hint,omitempty"`
+}
+
+type resourceReport struct {
+	Resource        string `json:"resource"`
+	Status          string `json:"status,omitempty"`
+	Health          string `json:"health,omitempty"`
+	RequiresPruning bool   `json:"requiresPruning,omitempty"`
+}
+
+type lastOperationInfo struct {
+	Phase           string       `json:"phase"`
+	Message         string       `json:"message,omitempty"`
+	Revision        string       `json:"revision,omitempty"`
+	StartedAt       metav1.Time  `json:"startedAt"`
+	FinishedAt      *metav1.Time `json:"finishedAt,omitempty"`
+	FailedResources []string     `json:"failedResources,omitempty"`
+}
+
+func applicationsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	project := p.OptionalString("project", "")
+	syncStatus := p.OptionalString("sync_status", "")
+	healthStatus := p.OptionalString("health_status", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applications: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(applicationGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applications: %w", err)), nil
+	}
+	summaries := make([]applicationSummary, 0, len(list.Items))
+	for _, item := range list.Items {
+		app, err := decodeApplication(&item)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		if (project != "" && app.Spec.Project != project) ||
+			(syncStatus != "" && app.Status.Sync.Status != syncStatus) ||
+			(healthStatus != "" && app.Status.Health.Status != healthStatus) {
+			continue
+		}
+		summaries = append(summaries, summarizeApplication(app))
+	}
+	if len(summaries) == 0 {
+		return api.NewToolCallResult("No Applications found", nil), nil
+	}
+	ret, err := output.MarshalYaml(summaries)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applications: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func applicationDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff application: %w", err)), nil
+	}
+
+	item, err := getApplication(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff application: %w", err)), nil
+	}
+	app, err := decodeApplication(item)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	ret, err := output.MarshalYaml(diffApplication(app))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff application: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// getApplication gets the named Application, looking it up in all namespaces when namespace is empty.
+func getApplication(params api.ToolHandlerParams, namespace, name string) (*unstructured.Unstructured, error) {
+	if namespace != "" {
+		return params.DynamicClient().Resource(applicationGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+	}
+	list, err := params.DynamicClient().Resource(applicationGVR).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var found []*unstructured.Unstructured
+	for i := range list.Items {
+		if list.Items[i].GetName() == name {
+			found = append(found, &list.Items[i])
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("application %q not found in any namespace", name)
+	case 1:
+		return found[0], nil
+	default:
+		namespaces := make([]string, 0, len(found))
+		for _, item := range found {
+			namespaces = append(namespaces, item.GetNamespace())
+		}
+		return nil, fmt.Errorf("application %q found in several namespaces (%s), set the namespace", name, strings.Join(namespaces, ", "))
+	}
+}
+
+func decodeApplication(item *unstructured.Unstructured) (*applicationObject, error) {
+	app := &applicationObject{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, app); err != nil {
+		return nil, fmt.Errorf("failed to decode application %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+	}
+	return app, nil
+}
+
+func summarizeApplication(app *applicationObject) applicationSummary {
+	summary := applicationSummary{
+		Name:        app.Name,
+		Namespace:   app.Namespace,
+		Project:     app.Spec.Project,
+		Destination: describeDestination(app),
+		Sync:        cmp.Or(app.Status.Sync.Status, "Unknown"),
+		Revision:    shortRevision(app.Status.Sync.Revision),
+		Health:      cmp.Or(app.Status.Health.Status, "Unknown"),
+		AutoSync:    describeAutoSync(app),
+	}
+	for _, source := range applicationSources(app) {
+		summary.Sources = append(summary.Sources, source.describe())
+	}
+	for _, r := range app.Status.Resources {
+		if r.Status == "OutOfSync" {
+			summary.OutOfSync++
+		}
+	}
+	if app.Operation != nil {
+		summary.Operation = "Pending"
+	} else if state := app.Status.OperationState; state != nil {
+		summary.Operation = state.Phase
+		if state.Phase != "Succeeded" && state.Message != "" {
+			summary.Operation += ": " + state.Message
+		}
+	}
+	for _, condition := range app.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, condition.Type+": "+condition.Message)
+	}
+	return summary
+}
+
+func diffApplication(app *applicationObject) applicationDiffReport {
+	report := applicationDiffReport{
+		Name:         app.Name,
+		Namespace:    app.Namespace,
+		Sync:         cmp.Or(app.Status.Sync.Status, "Unknown"),
+		Revision:     app.Status.Sync.Revision,
+		Health:       cmp.Or(app.Status.Health.Status, "Unknown"),
+		AutoSync:     describeAutoSync(app),
+		ReconciledAt: app.Status.ReconciledAt,
+	}
+	for _, r := range app.Status.Resources {
+		resource := resourceReport{Resource: r.describe(), Status: r.Status, RequiresPruning: r.RequiresPruning}
+		if r.Health != nil {
+			resource.Health = r.Health.Status
+			if r.Health.Message != "" {
+				resource.Health += ": " + r.Health.Message
+			}
+		}
+		if r.Status == "OutOfSync" || r.RequiresPruning {
+			report.OutOfSync = append(report.OutOfSync, resource)
+		}
+		if r.Health != nil && r.Health.Status != "" && r.Health.Status != "Healthy" {
+			report.Unhealthy = append(report.Unhealthy, resource)
+		}
+	}
+	for _, condition := range app.Status.Conditions {
+		report.Conditions = append(report.Conditions, condition.Type+": "+condition.Message)
+	}
+	if state := app.Status.OperationState; state != nil {
+		report.LastOperation = &lastOperationInfo{
+			Phase:      state.Phase,
+			Message:    state.Message,
+			StartedAt:  state.StartedAt,
+			FinishedAt: state.FinishedAt,
+		}
+		if state.SyncResult != nil {
+			report.LastOperation.Revision = state.SyncResult.Revision
+			for _, r := range state.SyncResult.Resources {
+				if r.Status == "Synced" || r.Status == "Pruned" || r.Status == "PruneSkipped" || r.Status == "" {
+					continue
+				}
+				failed := resourceStatus{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name}
+				report.LastOperation.FailedResources = append(report.LastOperation.FailedResources, fmt.Sprintf("%s: %s: %s", failed.describe(), r.Status, r.Message))
+			}
+		}
+	}
+	switch {
+	case selfHealEnabled(app):
+		report.Hint = "automated sync with self-heal is enabled: changes made directly to the resources of this Application are reverted by Argo CD, change the desired state in Git instead"
+	case len(report.OutOfSync) > 0:
+		report.Hint = "use argocd_application_sync to apply the desired state, with dry_run to preview the changes and prune to delete the resources that require pruning"
+	}
+	return report
+}
+
+func applicationSources(app *applicationObject) []applicationSource {
+	if app.Spec.Source != nil {
+		return []applicationSource{*app.Spec.Source}
+	}
+	return app.Spec.Sources
+}
+
+func describeDestination(app *applicationObject) string {
+	cluster := cmp.Or(app.Spec.Destination.Name, app.Spec.Destination.Server)
+	return cluster + "/" + cmp.Or(app.Spec.Destination.Namespace, "*")
+}
+
+func autoSyncEnabled(app *applicationObject) bool {
+	return app.Spec.SyncPolicy != nil && app.Spec.SyncPolicy.Automated != nil && ptr.Deref(app.Spec.SyncPolicy.Automated.Enabled, true)
+}
+
+func selfHealEnabled(app *applicationObject) bool {
+	return autoSyncEnabled(app) && app.Spec.SyncPolicy.Automated.SelfHeal
+}
+
+func describeAutoSync(app *applicationObject) string {
+	if !autoSyncEnabled(app) {
+		return ""
+	}
+	options := []string{"enabled"}
+	if app.Spec.SyncPolicy.Automated.Prune {
+		options = append(options, "prune")
+	}
+	if app.Spec.SyncPolicy.Automated.SelfHeal {
+		options = append(options, "selfHeal")
+	}
+	return strings.Join(options, ", ")
+}
+
+func shortRevision(revision string) string {
+	if len(revision) == 40 {
+		return revision[:7]
+	}
+	return revision
+}
@@ -0,0 +1,131 @@
+package argocd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initApplicationSets(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "argocd_applicationsets_list",
+			Description: "List Argo CD ApplicationSets with their generators, the Applications they generate with their sync and health status, " +
+				"and the conditions reporting generation errors. Applications generated by an ApplicationSet are overwritten by the ApplicationSet controller: " +
+				"change the ApplicationSet template instead of the Applications",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ApplicationSets, usually the Argo CD namespace such as argocd or openshift-gitops (Optional, all namespaces if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ApplicationSet (Optional, all ApplicationSets if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Argo CD: List ApplicationSets",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: applicationSetsList, TargetCompatibilityFilters: []func() bool{hasArgoCD(p, "ApplicationSet")}},
+	}
+}
+
+// applicationSetObject is the subset of an Argo CD ApplicationSet inspected by this toolset.
+type applicationSetObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Generators []map[string]any `json:"generators,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []applicationSetCondition `json:"conditions,omitempty"`
+		Resources  []resourceStatus          `json:"resources,omitempty"`
+	} `json:"status"`
+}
+
+type applicationSetCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type applicationSetSummary struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	Generators   []string `json:"generators"`
+	Applications []string `json:"applications,omitempty"`
+	Issues       []string `json:"issues,omitempty"`
+}
+
+func applicationSetsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applicationsets: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(applicationSetGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applicationsets: %w", err)), nil
+	}
+	summaries := make([]applicationSetSummary, 0, len(list.Items))
+	for _, item := range list.Items {
+		if name != "" && item.GetName() != name {
+			continue
+		}
+		appSet := &applicationSetObject{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, appSet); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode applicationset %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		summaries = append(summaries, summarizeApplicationSet(appSet))
+	}
+	if len(summaries) == 0 {
+		return api.NewToolCallResult("No ApplicationSets found", nil), nil
+	}
+	ret, err := output.MarshalYaml(summaries)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list applicationsets: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func summarizeApplicationSet(appSet *applicationSetObject) applicationSetSummary {
+	summary := applicationSetSummary{Name: appSet.Name, Namespace: appSet.Namespace}
+	for _, generator := range appSet.Spec.Generators {
+		for generatorType := range generator {
+			summary.Generators = append(summary.Generators, generatorType)
+		}
+	}
+	slices.Sort(summary.Generators)
+	for _, r := range appSet.Status.Resources {
+		application := fmt.Sprintf("%s (sync: %s", r.Name, cmp.Or(r.Status, "Unknown"))
+		if r.Health != nil {
+			application += ", health: " + cmp.Or(r.Health.Status, "Unknown")
+		}
+		summary.Applications = append(summary.Applications, application+")")
+	}
+	for _, condition := range appSet.Status.Conditions {
+		// ErrorOccurred is True on failure, ParametersGenerated and ResourcesUpToDate are False on failure
+		failed := (condition.Type == "ErrorOccurred" && condition.Status == "True") ||
+			((condition.Type == "ParametersGenerated" || condition.Type == "ResourcesUpToDate") && condition.Status == "False")
+		if failed {
+			summary.Issues = append(summary.Issues, fmt.Sprintf("%s=%s: %s", condition.Type, condition.Status, condition.Message))
+		}
+	}
+	return summary
+}
@@ -0,0 +1,129 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ArgoCDSuite struct {
+	suite.Suite
+}
+
+func TestArgoCD(t *testing.T) {
+	suite.Run(t, new(ArgoCDSuite))
+}
+
+func (s *ArgoCDSuite) application() *applicationObject {
+	app, err := decodeApplication(&unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": "guestbook", "namespace": "argocd"},
+		"spec": map[string]any{
+			"project": "default",
+			"source": map[string]any{
+				"repoURL":        "https://github.com/argoproj/argocd-example-apps.git",
+				"path":           "guestbook",
+				"targetRevision": "main",
+			},
+			"destination": map[string]any{"server": "https://kubernetes.default.svc", "namespace": "guestbook"},
+			"syncPolicy":  map[string]any{"automated": map[string]any{"prune": true, "selfHeal": true}},
+		},
+		"status": map[string]any{
+			"sync":   map[string]any{"status": "OutOfSync", "revision": "0123456789abcdef0123456789abcdef01234567"},
+			"health": map[string]any{"status": "Degraded"},
+			"resources": []any{
+				map[string]any{"kind": "Service", "namespace": "guestbook", "name": "guestbook-ui", "status": "Synced", "health": map[string]any{"status": "Healthy"}},
+				map[string]any{"group": "apps", "kind": "Deployment", "namespace": "guestbook", "name": "guestbook-ui", "status": "OutOfSync",
+					"health": map[string]any{"status": "Degraded", "message": "Deployment exceeded its progress deadline"}},
+				map[string]any{"kind": "ConfigMap", "namespace": "guestbook", "name": "legacy", "status": "OutOfSync", "requiresPruning": true},
+			},
+			"conditions": []any{map[string]any{"type": "SyncError", "message": "one or more objects failed to apply"}},
+			"operationState": map[string]any{
+				"phase":     "Failed",
+				"message":   "one or more objects failed to apply",
+				"startedAt": "2026-01-02T03:04:05Z",
+				"syncResult": map[string]any{
+					"revision": "0123456789abcdef0123456789abcdef01234567",
+					"resources": []any{
+						map[string]any{"kind": "Service", "namespace": "guestbook", "name": "guestbook-ui", "status": "Synced"},
+						map[string]any{"group": "apps", "kind": "Deployment", "namespace": "guestbook", "name": "guestbook-ui", "status": "SyncFailed", "message": "field is immutable"},
+					},
+				},
+			},
+		},
+	}})
+	s.Require().NoError(err)
+	return app
+}
+
+func (s *ArgoCDSuite) TestSummarizeApplication() {
+	summary := summarizeApplication(s.application())
+	s.Equal("guestbook", summary.Name)
+	s.Equal([]string{"https://github.com/argoproj/argocd-example-apps.git path guestbook@main"}, summary.Sources)
+	s.Equal("https://kubernetes.default.svc/guestbook", summary.Destination)
+	s.Equal("OutOfSync", summary.Sync)
+	s.Equal("0123456", summary.Revision)
+	s.Equal("Degraded", summary.Health)
+	s.Equal("enabled, prune, selfHeal", summary.AutoSync)
+	s.Equal(2, summary.OutOfSync)
+	s.Equal("Failed: one or more objects failed to apply", summary.Operation)
+	s.Equal([]string{"SyncError: one or more objects failed to apply"}, summary.Conditions)
+}
+
+func (s *ArgoCDSuite) TestDiffApplication() {
+	report := diffApplication(s.application())
+	s.Run("reports out-of-sync resources", func() {
+		s.Require().Len(report.OutOfSync, 2)
+		s.Equal("Deployment.apps guestbook/guestbook-ui", report.OutOfSync[0].Resource)
+		s.Equal("ConfigMap guestbook/legacy", report.OutOfSync[1].Resource)
+		s.True(report.OutOfSync[1].RequiresPruning)
+	})
+	s.Run("reports unhealthy resources", func() {
+		s.Require().Len(report.Unhealthy, 1)
+		s.Equal("Degraded: Deployment exceeded its progress deadline", report.Unhealthy[0].Health)
+	})
+	s.Run("reports resources that failed to sync", func() {
+		s.Require().NotNil(report.LastOperation)
+		s.Equal("Failed", report.LastOperation.Phase)
+		s.Equal([]string{"Deployment.apps guestbook/guestbook-ui: SyncFailed: field is immutable"}, report.LastOperation.FailedResources)
+	})
+	s.Run("warns that self-heal reverts manual changes", func() {
+		s.Contains(report.Hint, "self-heal is enabled")
+	})
+}
+
+func (s *ArgoCDSuite) TestParseSyncResource() {
+	s.Run("parses GROUP:KIND:NAME", func() {
+		resource, err := parseSyncResource("apps:Deployment:web")
+		s.Require().NoError(err)
+		s.Equal(syncResource{Group: "apps", Kind: "Deployment", Name: "web"}, resource)
+	})
+	s.Run("parses core GROUP:KIND:NAMESPACE/NAME", func() {
+		resource, err := parseSyncResource(":Service:ns-1/web")
+		s.Require().NoError(err)
+		s.Equal(syncResource{Kind: "Service", Namespace: "ns-1", Name: "web"}, resource)
+	})
+	s.Run("rejects invalid resources", func() {
+		for _, r := range []string{"Deployment/web", "apps::web", "apps:Deployment:", ":Service:/web"} {
+			_, err := parseSyncResource(r)
+			s.Error(err, r)
+		}
+	})
+}
+
+func (s *ArgoCDSuite) TestSummarizeApplicationSet() {
+	appSet := &applicationSetObject{}
+	appSet.Name = "clusters"
+	appSet.Spec.Generators = []map[string]any{{"list": map[string]any{}}, {"clusters": map[string]any{}}}
+	appSet.Status.Resources = []resourceStatus{{Name: "clusters-dev", Status: "Synced"}}
+	appSet.Status.Conditions = []applicationSetCondition{
+		{Type: "ErrorOccurred", Status: "True", Message: "failed to generate parameters"},
+		{Type: "ResourcesUpToDate", Status: "True"},
+	}
+	summary := summarizeApplicationSet(appSet)
+	s.Equal([]string{"clusters", "list"}, summary.Generators)
+	s.Equal([]string{"clusters-dev (sync: Synced)"}, summary.Applications)
+	s.Equal([]string{"ErrorOccurred=True: failed to generate parameters"}, summary.Issues)
+}
@@ -0,0 +1,217 @@
+package argocd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// refreshAnnotation requests Argo CD to compare the Application with its source, it is removed once the refresh is done.
+	refreshAnnotation = "argocd.argoproj.io/refresh"
+	initiatedBy       = "kubernetes-mcp-server"
+)
+
+func initSync(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "argocd_application_sync",
+			Description: "Trigger the sync of an Argo CD Application by setting its operation, as the Argo CD UI and CLI do. " +
+				"The sync applies the desired state from Git to the cluster; with prune, resources no longer in Git are deleted. " +
+				"Use dry_run to preview the sync, then argocd_application_diff to follow the operation",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Application to sync",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Application (Optional, the Application is looked up in all namespaces if not provided)",
+					},
+					"revision": {
+						Type:        "string",
+						Description: "Git revision, tag, or Helm chart version to sync to (Optional, defaults to the Application target revision)",
+					},
+					"prune": {
+						Type:        "boolean",
+						Description: "Delete the resources that are no longer defined in the source (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "Preview the sync without applying any change (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"resources": {
+						Type: "array",
+						Description: "Only sync these resources, formatted as GROUP:KIND:NAME or GROUP:KIND:NAMESPACE/NAME with an empty group for core resources, " +
+							"e.g. apps:Deployment:my-app or :Service:my-ns/my-svc (Optional, all resources if not provided)",
+						Items: &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Argo CD: Sync Application",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: applicationSync, TargetCompatibilityFilters: []func() bool{hasArgoCD(p, "Application")}},
+		{Tool: api.Tool{
+			Name: "argocd_application_refresh",
+			Description: "Trigger the refresh of an Argo CD Application so that its sync and health status are compared again with the source, " +
+				"e.g. after a change was pushed to Git. A hard refresh also invalidates the cached manifests",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Application to refresh",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Application (Optional, the Application is looked up in all namespaces if not provided)",
+					},
+					"hard": {
+						Type:        "boolean",
+						Description: "Regenerate the manifests instead of using the cached ones (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Argo CD: Refresh Application",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: applicationRefresh, TargetCompatibilityFilters: []func() bool{hasArgoCD(p, "Application")}},
+	}
+}
+
+// syncOperation is the Argo CD Application operation requesting a sync.
+type syncOperation struct {
+	InitiatedBy struct {
+		Username string `json:"username"`
+	} `json:"initiatedBy"`
+	Sync struct {
+		Revision  string         `json:"revision,omitempty"`
+		Prune     bool           `json:"prune,omitempty"`
+		DryRun    bool           `json:"dryRun,omitempty"`
+		Resources []syncResource `json:"resources,omitempty"`
+	} `json:"sync"`
+}
+
+type syncResource struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// parseSyncResource parses a resource in the GROUP:KIND:NAME or GROUP:KIND:NAMESPACE/NAME format used by the Argo CD CLI.
+func parseSyncResource(s string) (syncResource, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) != 3 || fields[1] == "" || fields[2] == "" {
+		return syncResource{}, fmt.Errorf("invalid resource %q, expected GROUP:KIND:NAME or GROUP:KIND:NAMESPACE/NAME", s)
+	}
+	resource := syncResource{Group: fields[0], Kind: fields[1], Name: fields[2]}
+	if namespace, name, ok := strings.Cut(fields[2], "/"); ok {
+		if namespace == "" || name == "" {
+			return syncResource{}, fmt.Errorf("invalid resource %q, expected GROUP:KIND:NAMESPACE/NAME", s)
+		}
+		resource.Namespace, resource.Name = namespace, name
+	}
+	return resource, nil
+}
+
+func applicationSync(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	operation := &syncOperation{}
+	operation.InitiatedBy.Username = initiatedBy
+	operation.Sync.Revision = p.OptionalString("revision", "")
+	operation.Sync.Prune = p.OptionalBool("prune", false)
+	operation.Sync.DryRun = p.OptionalBool("dry_run", false)
+	resources := p.OptionalStringArray("resources")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to sync application: %w", err)), nil
+	}
+	for _, r := range resources {
+		resource, err := parseSyncResource(r)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to sync application: %w", err)), nil
+		}
+		operation.Sync.Resources = append(operation.Sync.Resources, resource)
+	}
+
+	item, err := getApplication(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to sync application: %w", err)), nil
+	}
+	app, err := decodeApplication(item)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if app.Operation != nil || (app.Status.OperationState != nil && app.Status.OperationState.Phase == "Running") {
+		return api.NewToolCallResult("", fmt.Errorf("failed to sync application %s/%s: another operation is already in progress", app.Namespace, app.Name)), nil
+	}
+	patch, err := json.Marshal(map[string]any{"operation": operation})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to sync application: %w", err)), nil
+	}
+	if _, err = params.DynamicClient().Resource(applicationGVR).Namespace(app.Namespace).
+		Patch(params.Context, app.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to sync application %s/%s: %w", app.Namespace, app.Name, err)), nil
+	}
+	mode := "Sync"
+	if operation.Sync.DryRun {
+		mode = "Dry-run sync"
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s of Application '%s' in namespace '%s' requested (revision: %s, prune: %t, resources: %d). "+
+		"Use argocd_application_diff to follow the operation", mode, app.Name, app.Namespace,
+		cmp.Or(operation.Sync.Revision, "target revision"), operation.Sync.Prune, len(operation.Sync.Resources)), nil), nil
+}
+
+func applicationRefresh(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	hard := p.OptionalBool("hard", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to refresh application: %w", err)), nil
+	}
+
+	if namespace == "" {
+		item, err := getApplication(params, namespace, name)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to refresh application: %w", err)), nil
+		}
+		namespace = item.GetNamespace()
+	}
+	refreshType, description := "normal", "Refresh"
+	if hard {
+		refreshType, description = "hard", "Hard refresh"
+	}
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, refreshAnnotation, refreshType))
+	if _, err := params.DynamicClient().Resource(applicationGVR).Namespace(namespace).
+		Patch(params.Context, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to refresh application %s/%s: %w", namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s of Application '%s' in namespace '%s' requested", description, name, namespace), nil), nil
+}
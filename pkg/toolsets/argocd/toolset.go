@@ -0,0 +1,45 @@
+package argocd
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides Argo CD Application and ApplicationSet tools.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "argocd"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Argo CD tools to inspect the sync and health status of Applications and ApplicationSets, find out-of-sync resources, and trigger syncs and refreshes"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initApplications(p),
+		initSync(p),
+		initApplicationSets(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,535 @@
+package certmanager
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	certManagerGroup = "cert-manager.io"
+	acmeGroup        = "acme.cert-manager.io"
+	// revisionAnnotation is set by cert-manager on the CertificateRequests with the revision of the Certificate they issue.
+	revisionAnnotation = "cert-manager.io/certificate-revision"
+	// maxEvents bounds the events reported for each object of the chain.
+	maxEvents = 5
+)
+
+var (
+	certificateGVR        = schema.GroupVersionResource{Group: certManagerGroup, Version: "v1", Resource: "certificates"}
+	certificateRequestGVR = schema.GroupVersionResource{Group: certManagerGroup, Version: "v1", Resource: "certificaterequests"}
+	issuerGVR             = schema.GroupVersionResource{Group: certManagerGroup, Version: "v1", Resource: "issuers"}
+	clusterIssuerGVR      = schema.GroupVersionResource{Group: certManagerGroup, Version: "v1", Resource: "clusterissuers"}
+	orderGVR              = schema.GroupVersionResource{Group: acmeGroup, Version: "v1", Resource: "orders"}
+	challengeGVR          = schema.GroupVersionResource{Group: acmeGroup, Version: "v1", Resource: "challenges"}
+)
+
+// hasCertManager returns a filter that hides the tools when the cert-manager CRDs are not installed.
+func hasCertManager(p api.FilteringProvider) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{{Group: certManagerGroup, Version: "v1", Kind: "Certificate"}})
+	}
+}
+
+func certificateProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"name": {
+			Type:        "string",
+			Description: "Name of the Certificate",
+		},
+		"namespace": {
+			Type:        "string",
+			Description: "Namespace of the Certificate (Optional, current namespace if not provided)",
+		},
+	}
+}
+
+func initCertificates(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "certmanager_certificate_trace",
+			Description: "Trace the issuance of a cert-manager Certificate through its Issuer, the CertificateRequest of its current revision, " +
+				"and for ACME issuers the Order and its Challenges, reporting the conditions and recent events of each step and the step blocking the issuance",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: certificateProperties(),
+				Required:   []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "cert-manager: Trace Certificate",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificateTrace, TargetCompatibilityFilters: []func() bool{hasCertManager(p)}},
+	}
+}
+
+// condition is a cert-manager condition, cert-manager conditions don't always set the fields required by metav1.Condition.
+type condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type certificateObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		SecretName string   `json:"secretName"`
+		CommonName string   `json:"commonName,omitempty"`
+		DNSNames   []string `json:"dnsNames,omitempty"`
+		IssuerRef  struct {
+			Name  string `json:"name"`
+			Kind  string `json:"kind,omitempty"`
+			Group string `json:"group,omitempty"`
+		} `json:"issuerRef"`
+	} `json:"spec"`
+	Status struct {
+		Conditions             []condition `json:"conditions,omitempty"`
+		Revision               *int        `json:"revision,omitempty"`
+		NotAfter               string      `json:"notAfter,omitempty"`
+		RenewalTime            string      `json:"renewalTime,omitempty"`
+		LastFailureTime        string      `json:"lastFailureTime,omitempty"`
+		FailedIssuanceAttempts *int        `json:"failedIssuanceAttempts,omitempty"`
+	} `json:"status"`
+}
+
+// conditionsObject is the subset of the Issuers, ClusterIssuers and CertificateRequests inspected by this toolset.
+type conditionsObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		Conditions []condition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+type orderObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		State  string `json:"state,omitempty"`
+		Reason string `json:"reason,omitempty"`
+	} `json:"status"`
+}
+
+type challengeObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Type    string `json:"type"`
+		DNSName string `json:"dnsName"`
+	} `json:"spec"`
+	Status struct {
+		State      string `json:"state,omitempty"`
+		Reason     string `json:"reason,omitempty"`
+		Presented  bool   `json:"presented,omitempty"`
+		Processing bool   `json:"processing,omitempty"`
+	} `json:"status"`
+}
+
+// certificateChain holds the objects involved in the issuance of a Certificate, nil when not found.
+type certificateChain struct {
+	Certificate *certificateObject
+	// IssuerKind is Issuer or ClusterIssuer, empty for external issuers which are not inspected
+	IssuerKind  string
+	Issuer      *conditionsObject
+	IssuerError string
+	Request     *conditionsObject
+	Order       *orderObject
+	Challenges  []*challengeObject
+}
+
+type traceStep struct {
+	Resource string   `json:"resource"`
+	Status   string   `json:"status"`
+	Blocking bool     `json:"blocking,omitempty"`
+	Details  []string `json:"details,omitempty"`
+	Events   []string `json:"events,omitempty"`
+	// kind and namespace locate the events of the step, name is the object name
+	kind, namespace, name string
+	ok                    bool
+}
+
+type certificateTraceReport struct {
+	Steps     []traceStep `json:"steps"`
+	BlockedAt string      `json:"blockedAt,omitempty"`
+	Hint      string      `json:"hint,omitempty"`
+}
+
+func decode(item *unstructured.Unstructured, into any) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, into); err != nil {
+		return fmt.Errorf("failed to decode %s %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err)
+	}
+	return nil
+}
+
+func certificateTrace(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace certificate: %w", err)), nil
+	}
+
+	chain, err := getCertificateChain(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	report := traceCertificate(chain)
+	for i := range report.Steps {
+		step := &report.Steps[i]
+		step.Events = objectEvents(params, step.namespace, step.kind, step.name)
+	}
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace certificate: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// getCertificateChain gets the Certificate and the objects cert-manager created to issue its current revision.
+func getCertificateChain(params api.ToolHandlerParams, namespace, name string) (*certificateChain, error) {
+	item, err := params.DynamicClient().Resource(certificateGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate %s/%s: %w", namespace, name, err)
+	}
+	chain := &certificateChain{Certificate: &certificateObject{}}
+	if err = decode(item, chain.Certificate); err != nil {
+		return nil, err
+	}
+
+	issuerRef := chain.Certificate.Spec.IssuerRef
+	if issuerRef.Group == "" || issuerRef.Group == certManagerGroup {
+		chain.IssuerKind = cmp.Or(issuerRef.Kind, "Issuer")
+		issuers := params.DynamicClient().Resource(issuerGVR).Namespace(namespace)
+		if chain.IssuerKind == "ClusterIssuer" {
+			issuers = params.DynamicClient().Resource(clusterIssuerGVR).Namespace("")
+		}
+		if issuer, err := issuers.Get(params.Context, issuerRef.Name, metav1.GetOptions{}); err != nil {
+			chain.IssuerError = err.Error()
+		} else {
+			chain.Issuer = &conditionsObject{}
+			if err = decode(issuer, chain.Issuer); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	requests, err := params.DynamicClient().Resource(certificateRequestGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificaterequests: %w", err)
+	}
+	request := latestRevision(controlledBy(requests.Items, item.GetUID()))
+	if request == nil {
+		return chain, nil
+	}
+	chain.Request = &conditionsObject{}
+	if err = decode(request, chain.Request); err != nil {
+		return nil, err
+	}
+
+	orders, err := params.DynamicClient().Resource(orderGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		// ACME CRDs are not installed
+		return chain, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	order := latestCreated(controlledBy(orders.Items, request.GetUID()))
+	if order == nil {
+		return chain, nil
+	}
+	chain.Order = &orderObject{}
+	if err = decode(order, chain.Order); err != nil {
+		return nil, err
+	}
+
+	challenges, err := params.DynamicClient().Resource(challengeGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list challenges: %w", err)
+	}
+	for _, item := range controlledBy(challenges.Items, order.GetUID()) {
+		challenge := &challengeObject{}
+		if err = decode(item, challenge); err != nil {
+			return nil, err
+		}
+		chain.Challenges = append(chain.Challenges, challenge)
+	}
+	return chain, nil
+}
+
+func controlledBy(items []unstructured.Unstructured, uid types.UID) []*unstructured.Unstructured {
+	var ret []*unstructured.Unstructured
+	for i := range items {
+		if owner := metav1.GetControllerOfNoCopy(&items[i]); owner != nil && owner.UID == uid {
+			ret = append(ret, &items[i])
+		}
+	}
+	return ret
+}
+
+// latestRevision returns the CertificateRequest with the highest revision, i.e. the one of the current or last issuance.
+func latestRevision(requests []*unstructured.Unstructured) *unstructured.Unstructured {
+	var latest *unstructured.Unstructured
+	latestRevision := -1
+	for _, request := range requests {
+		revision, err := strconv.Atoi(request.GetAnnotations()[revisionAnnotation])
+		if err != nil {
+			continue
+		}
+		if revision > latestRevision {
+			latest, latestRevision = request, revision
+		}
+	}
+	return latest
+}
+
+func latestCreated(items []*unstructured.Unstructured) *unstructured.Unstructured {
+	var latest *unstructured.Unstructured
+	for _, item := range items {
+		if latest == nil || latest.GetCreationTimestamp().Time.Before(item.GetCreationTimestamp().Time) {
+			latest = item
+		}
+	}
+	return latest
+}
+
+// traceCertificate reports each step of the issuance chain and marks the deepest failing one as blocking.
+func traceCertificate(chain *certificateChain) certificateTraceReport {
+	cert := chain.Certificate
+	ready := findCondition(cert.Status.Conditions, "Ready")
+	issuing := findCondition(cert.Status.Conditions, "Issuing")
+	certStep := traceStep{
+		Resource:  fmt.Sprintf("Certificate %s/%s", cert.Namespace, cert.Name),
+		Status:    describeConditions(cert.Status.Conditions, "Ready", "Issuing"),
+		kind:      "Certificate",
+		namespace: cert.Namespace,
+		name:      cert.Name,
+		ok:        isTrue(ready) && !isTrue(issuing),
+	}
+	certStep.Details = append(certStep.Details, "secret: "+cert.Spec.SecretName)
+	if names := certificateNames(cert); names != "" {
+		certStep.Details = append(certStep.Details, "names: "+names)
+	}
+	if cert.Status.NotAfter != "" {
+		certStep.Details = append(certStep.Details, "notAfter: "+cert.Status.NotAfter)
+	}
+	if cert.Status.RenewalTime != "" {
+		certStep.Details = append(certStep.Details, "renewalTime: "+cert.Status.RenewalTime)
+	}
+	if cert.Status.FailedIssuanceAttempts != nil && *cert.Status.FailedIssuanceAttempts > 0 {
+		certStep.Details = append(certStep.Details, fmt.Sprintf("failedIssuanceAttempts: %d (last failure at %s)",
+			*cert.Status.FailedIssuanceAttempts, cert.Status.LastFailureTime))
+	}
+	report := certificateTraceReport{Steps: []traceStep{certStep}}
+
+	issuerRef := cert.Spec.IssuerRef
+	switch {
+	case chain.IssuerKind == "":
+		report.Steps = append(report.Steps, traceStep{
+			Resource: fmt.Sprintf("%s.%s %s", cmp.Or(issuerRef.Kind, "Issuer"), issuerRef.Group, issuerRef.Name),
+			Status:   "external issuer, not inspected",
+			ok:       true,
+		})
+	case chain.Issuer == nil:
+		report.Steps = append(report.Steps, traceStep{
+			Resource: issuerResource(chain.IssuerKind, cert.Namespace, issuerRef.Name),
+			Status:   "failed to get issuer: " + chain.IssuerError,
+			kind:     chain.IssuerKind,
+		})
+	default:
+		report.Steps = append(report.Steps, traceStep{
+			Resource:  issuerResource(chain.IssuerKind, chain.Issuer.Namespace, chain.Issuer.Name),
+			Status:    describeConditions(chain.Issuer.Status.Conditions, "Ready"),
+			kind:      chain.IssuerKind,
+			namespace: chain.Issuer.Namespace,
+			name:      chain.Issuer.Name,
+			ok:        isTrue(findCondition(chain.Issuer.Status.Conditions, "Ready")),
+		})
+	}
+
+	if chain.Request != nil {
+		request := chain.Request
+		report.Steps = append(report.Steps, traceStep{
+			Resource:  fmt.Sprintf("CertificateRequest %s/%s", request.Namespace, request.Name),
+			Status:    describeConditions(request.Status.Conditions, "Approved", "Denied", "InvalidRequest", "Ready"),
+			Details:   []string{"revision: " + request.Annotations[revisionAnnotation]},
+			kind:      "CertificateRequest",
+			namespace: request.Namespace,
+			name:      request.Name,
+			ok:        isTrue(findCondition(request.Status.Conditions, "Ready")),
+		})
+	} else if !certStep.ok && report.Steps[len(report.Steps)-1].ok {
+		// a failing issuer explains the missing CertificateRequest better
+		report.Steps = append(report.Steps, traceStep{
+			Resource: "CertificateRequest",
+			Status:   "no CertificateRequest found for the Certificate",
+			kind:     "CertificateRequest",
+		})
+	}
+
+	if order := chain.Order; order != nil {
+		status := "state=" + cmp.Or(order.Status.State, "unknown")
+		if order.Status.Reason != "" {
+			status += ": " + order.Status.Reason
+		}
+		report.Steps = append(report.Steps, traceStep{
+			Resource:  fmt.Sprintf("Order %s/%s", order.Namespace, order.Name),
+			Status:    status,
+			kind:      "Order",
+			namespace: order.Namespace,
+			name:      order.Name,
+			ok:        order.Status.State == "valid",
+		})
+	}
+	for _, challenge := range chain.Challenges {
+		status := fmt.Sprintf("state=%s, presented=%t, processing=%t", cmp.Or(challenge.Status.State, "unknown"),
+			challenge.Status.Presented, challenge.Status.Processing)
+		if challenge.Status.Reason != "" {
+			status += ": " + challenge.Status.Reason
+		}
+		report.Steps = append(report.Steps, traceStep{
+			Resource:  fmt.Sprintf("Challenge %s/%s", challenge.Namespace, challenge.Name),
+			Status:    status,
+			Details:   []string{fmt.Sprintf("%s for %s", challenge.Spec.Type, challenge.Spec.DNSName)},
+			kind:      "Challenge",
+			namespace: challenge.Namespace,
+			name:      challenge.Name,
+			ok:        challenge.Status.State == "valid",
+		})
+	}
+
+	if certStep.ok {
+		report.Hint = "the Certificate is Ready and not being issued"
+		if cert.Status.RenewalTime != "" {
+			report.Hint += ", it is renewed at " + cert.Status.RenewalTime
+		}
+		return report
+	}
+	blocking := -1
+	for i, step := range report.Steps {
+		if !step.ok && (blocking == -1 || report.Steps[blocking].kind != "Challenge" || step.kind != "Challenge") {
+			blocking = i
+		}
+	}
+	report.Steps[blocking].Blocking = true
+	report.BlockedAt = report.Steps[blocking].Resource + ": " + report.Steps[blocking].Status
+	report.Hint = blockingHint(chain, report.Steps[blocking])
+	return report
+}
+
+func blockingHint(chain *certificateChain, step traceStep) string {
+	const renew = "Once fixed, use certmanager_certificate_renew to retry the issuance without waiting for the backoff"
+	switch step.kind {
+	case "Issuer", "ClusterIssuer":
+		return "the issuer is missing or not Ready, fix its configuration (e.g. its credentials or ACME account) first. " + renew
+	case "CertificateRequest":
+		if chain.Request == nil {
+			return "cert-manager has not created a CertificateRequest, check the Certificate events and the cert-manager controller logs"
+		}
+		conditions := chain.Request.Status.Conditions
+		switch {
+		case isTrue(findCondition(conditions, "Denied")):
+			return "the CertificateRequest was denied by an approver. " + renew
+		case findCondition(conditions, "Approved") == nil:
+			return "the CertificateRequest is waiting for approval, check the cert-manager approver or the approver-policy configuration"
+		}
+		return "the issuer failed to sign the CertificateRequest. " + renew
+	case "Challenge":
+		return "the ACME server could not validate the challenge: for HTTP-01 check that /.well-known/acme-challenge/ on the domain is routed to the solver, " +
+			"for DNS-01 check that the TXT record is published and propagated. Challenges are retried automatically, failed Orders are not"
+	case "Order":
+		return "the ACME Order failed. " + renew
+	default:
+		return "the Certificate failed to be issued, check its events. " + renew
+	}
+}
+
+func issuerResource(kind, namespace, name string) string {
+	if kind == "ClusterIssuer" {
+		return "ClusterIssuer " + name
+	}
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
+
+func certificateNames(cert *certificateObject) string {
+	names := slices.Clone(cert.Spec.DNSNames)
+	if cert.Spec.CommonName != "" && !slices.Contains(names, cert.Spec.CommonName) {
+		names = append([]string{cert.Spec.CommonName}, names...)
+	}
+	return strings.Join(names, ", ")
+}
+
+func findCondition(conditions []condition, conditionType string) *condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func isTrue(c *condition) bool {
+	return c != nil && c.Status == "True"
+}
+
+// describeConditions describes the provided condition types that are set, e.g. Ready=False (Pending: Waiting on certificate issuance).
+func describeConditions(conditions []condition, conditionTypes ...string) string {
+	var parts []string
+	for _, conditionType := range conditionTypes {
+		c := findCondition(conditions, conditionType)
+		if c == nil {
+			continue
+		}
+		part := c.Type + "=" + c.Status
+		if c.Reason != "" || c.Message != "" {
+			part += fmt.Sprintf(" (%s: %s)", c.Reason, c.Message)
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "no conditions reported"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// objectEvents returns the most recent events of an object, oldest first.
+func objectEvents(params api.ToolHandlerParams, namespace, kind, name string) []string {
+	if kind == "" || name == "" {
+		return nil
+	}
+	events, err := params.CoreV1().Events(namespace).List(params.Context, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=" + kind + ",involvedObject.name=" + name,
+	})
+	if err != nil {
+		return []string{"failed to list events: " + err.Error()}
+	}
+	slices.SortFunc(events.Items, func(a, b v1.Event) int {
+		return a.LastTimestamp.Compare(b.LastTimestamp.Time)
+	})
+	items := events.Items[max(0, len(events.Items)-maxEvents):]
+	ret := make([]string, 0, len(items))
+	for _, event := range items {
+		line := fmt.Sprintf("%s %s: %s", event.Type, event.Reason, event.Message)
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
@@ -0,0 +1,135 @@
+package certmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type CertManagerSuite struct {
+	suite.Suite
+}
+
+func TestCertManager(t *testing.T) {
+	suite.Run(t, new(CertManagerSuite))
+}
+
+// chain returns the chain of a Certificate whose ACME HTTP-01 challenge fails.
+func (s *CertManagerSuite) chain() *certificateChain {
+	cert := &certificateObject{}
+	cert.Name, cert.Namespace = "web-tls", "apps"
+	cert.Spec.SecretName = "web-tls"
+	cert.Spec.DNSNames = []string{"web.example.com"}
+	cert.Spec.IssuerRef.Name, cert.Spec.IssuerRef.Kind = "letsencrypt", "ClusterIssuer"
+	cert.Status.Conditions = []condition{
+		{Type: "Ready", Status: "False", Reason: "DoesNotExist", Message: "Issuing certificate as Secret does not exist"},
+		{Type: "Issuing", Status: "True", Reason: "DoesNotExist", Message: "Issuing certificate as Secret does not exist"},
+	}
+	issuer := &conditionsObject{}
+	issuer.Name = "letsencrypt"
+	issuer.Status.Conditions = []condition{{Type: "Ready", Status: "True", Reason: "ACMEAccountRegistered"}}
+	request := &conditionsObject{}
+	request.Name, request.Namespace = "web-tls-1", "apps"
+	request.Annotations = map[string]string{revisionAnnotation: "1"}
+	request.Status.Conditions = []condition{
+		{Type: "Approved", Status: "True", Reason: "cert-manager.io"},
+		{Type: "Ready", Status: "False", Reason: "Pending", Message: "Waiting on certificate issuance from order apps/web-tls-1-123"},
+	}
+	order := &orderObject{}
+	order.Name, order.Namespace = "web-tls-1-123", "apps"
+	order.Status.State = "pending"
+	valid, failing := &challengeObject{}, &challengeObject{}
+	valid.Name, valid.Namespace, valid.Status.State = "web-tls-1-123-1", "apps", "valid"
+	failing.Name, failing.Namespace = "web-tls-1-123-2", "apps"
+	failing.Spec.Type, failing.Spec.DNSName = "HTTP-01", "web.example.com"
+	failing.Status.State, failing.Status.Presented, failing.Status.Processing = "pending", true, true
+	failing.Status.Reason = "Waiting for HTTP-01 challenge propagation: wrong status code '404', expected '200'"
+	return &certificateChain{
+		Certificate: cert,
+		IssuerKind:  "ClusterIssuer",
+		Issuer:      issuer,
+		Request:     request,
+		Order:       order,
+		Challenges:  []*challengeObject{valid, failing},
+	}
+}
+
+func (s *CertManagerSuite) TestTraceCertificate() {
+	s.Run("reports every step of the chain", func() {
+		report := traceCertificate(s.chain())
+		s.Require().Len(report.Steps, 6)
+		s.Equal("Certificate apps/web-tls", report.Steps[0].Resource)
+		s.Contains(report.Steps[0].Details, "names: web.example.com")
+		s.Equal("ClusterIssuer letsencrypt", report.Steps[1].Resource)
+		s.Equal("Ready=True (ACMEAccountRegistered: )", report.Steps[1].Status)
+		s.Equal([]string{"revision: 1"}, report.Steps[2].Details)
+		s.Equal("state=pending", report.Steps[3].Status)
+	})
+	s.Run("blocks at the failing challenge", func() {
+		report := traceCertificate(s.chain())
+		s.True(report.Steps[5].Blocking)
+		s.Equal("Challenge apps/web-tls-1-123-2: state=pending, presented=true, processing=true: "+
+			"Waiting for HTTP-01 challenge propagation: wrong status code '404', expected '200'", report.BlockedAt)
+		s.Contains(report.Hint, "/.well-known/acme-challenge/")
+	})
+	s.Run("blocks at the unapproved request", func() {
+		chain := s.chain()
+		chain.Request.Status.Conditions = nil
+		chain.Order, chain.Challenges = nil, nil
+		report := traceCertificate(chain)
+		s.True(report.Steps[2].Blocking)
+		s.Contains(report.Hint, "waiting for approval")
+	})
+	s.Run("blocks at the missing issuer", func() {
+		chain := s.chain()
+		chain.Issuer, chain.IssuerError = nil, `clusterissuers.cert-manager.io "letsencrypt" not found`
+		chain.Request, chain.Order, chain.Challenges = nil, nil, nil
+		report := traceCertificate(chain)
+		s.Equal("ClusterIssuer letsencrypt: failed to get issuer: clusterissuers.cert-manager.io \"letsencrypt\" not found", report.BlockedAt)
+		s.Contains(report.Hint, "the issuer is missing or not Ready")
+	})
+	s.Run("does not block ready certificates", func() {
+		chain := s.chain()
+		chain.Certificate.Status.Conditions = []condition{{Type: "Ready", Status: "True"}}
+		chain.Certificate.Status.RenewalTime = "2026-12-01T00:00:00Z"
+		report := traceCertificate(chain)
+		s.Empty(report.BlockedAt)
+		s.Equal("the Certificate is Ready and not being issued, it is renewed at 2026-12-01T00:00:00Z", report.Hint)
+	})
+}
+
+func (s *CertManagerSuite) TestSetIssuingCondition() {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.Run("replaces a False Issuing condition", func() {
+		item := &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "web-tls", "generation": int64(3)},
+			"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+				map[string]any{"type": "Issuing", "status": "False"},
+			}},
+		}}
+		triggered, err := setIssuingCondition(item, now)
+		s.Require().NoError(err)
+		s.True(triggered)
+		conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+		s.Require().Len(conditions, 2)
+		s.Equal(map[string]any{
+			"type":               "Issuing",
+			"status":             "True",
+			"reason":             "ManuallyTriggered",
+			"message":            "Certificate re-issuance manually triggered",
+			"lastTransitionTime": "2026-01-02T03:04:05Z",
+			"observedGeneration": int64(3),
+		}, conditions[1])
+	})
+	s.Run("skips certificates being issued", func() {
+		item := &unstructured.Unstructured{Object: map[string]any{
+			"status": map[string]any{"conditions": []any{map[string]any{"type": "Issuing", "status": "True"}}},
+		}}
+		triggered, err := setIssuingCondition(item, now)
+		s.Require().NoError(err)
+		s.False(triggered)
+	})
+}
@@ -0,0 +1,97 @@
+package certmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	issuingCondition = "Issuing"
+	// manuallyTriggeredReason is the reason set by cmctl renew on the Issuing condition.
+	manuallyTriggeredReason = "ManuallyTriggered"
+)
+
+func initRenew(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "certmanager_certificate_renew",
+			Description: "Trigger the renewal of a cert-manager Certificate, as cmctl renew does, by setting its Issuing status condition. " +
+				"cert-manager then creates a new CertificateRequest for the next revision, use certmanager_certificate_trace to follow the issuance",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: certificateProperties(),
+				Required:   []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "cert-manager: Renew Certificate",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificateRenew, TargetCompatibilityFilters: []func() bool{hasCertManager(p)}},
+	}
+}
+
+func certificateRenew(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to renew certificate: %w", err)), nil
+	}
+
+	certificates := params.DynamicClient().Resource(certificateGVR).Namespace(namespace)
+	item, err := certificates.Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get certificate %s/%s: %w", namespace, name, err)), nil
+	}
+	triggered, err := setIssuingCondition(item, time.Now())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to renew certificate %s/%s: %w", namespace, name, err)), nil
+	}
+	if !triggered {
+		return api.NewToolCallResult(fmt.Sprintf("Certificate '%s' in namespace '%s' is already being issued, use certmanager_certificate_trace to follow the issuance",
+			name, namespace), nil), nil
+	}
+	if _, err = certificates.UpdateStatus(params.Context, item, metav1.UpdateOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to renew certificate %s/%s: %w", namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Renewal of Certificate '%s' in namespace '%s' triggered, use certmanager_certificate_trace to follow the issuance",
+		name, namespace), nil), nil
+}
+
+// setIssuingCondition sets the Issuing=True condition that makes cert-manager re-issue the Certificate,
+// it returns false if the Certificate is already being issued.
+func setIssuingCondition(item *unstructured.Unstructured, now time.Time) (bool, error) {
+	conditions, _, err := unstructured.NestedSlice(item.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	ret := make([]any, 0, len(conditions)+1)
+	for _, c := range conditions {
+		if m, ok := c.(map[string]any); ok && m["type"] == issuingCondition {
+			if m["status"] == "True" {
+				return false, nil
+			}
+			continue
+		}
+		ret = append(ret, c)
+	}
+	ret = append(ret, map[string]any{
+		"type":               issuingCondition,
+		"status":             "True",
+		"reason":             manuallyTriggeredReason,
+		"message":            "Certificate re-issuance manually triggered",
+		"lastTransitionTime": now.UTC().Format(time.RFC3339),
+		"observedGeneration": item.GetGeneration(),
+	})
+	return true, unstructured.SetNestedSlice(item.Object, ret, "status", "conditions")
+}
@@ -0,0 +1,44 @@
+package certmanager
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides cert-manager tools to debug the issuance and renewal of Certificates.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "certmanager"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "cert-manager tools to trace the issuance of a Certificate through its CertificateRequest, ACME Order and Challenges, and trigger its renewal"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initCertificates(p),
+		initRenew(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,640 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// hpaTolerance is the default ratio under which the HPA controller ignores the difference between a metric and its target.
+	hpaTolerance = 0.1
+	// kedaPausedReplicasAnnotation pauses a ScaledObject and scales its target to the provided replica count.
+	kedaPausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+)
+
+var (
+	scaledObjectGVR            = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledobjects"}
+	verticalPodAutoscalerGVR   = schema.GroupVersionResource{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}
+	vpaUpdateModesEvictingPods = []string{"Auto", "Recreate", "InPlaceOrRecreate"}
+)
+
+func initAutoscaling() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "autoscaling_explain",
+			Description: "Explain the current replica count of a scalable Kubernetes workload (Deployment, StatefulSet, or any resource with a scale subresource) " +
+				"and what limits its autoscaling. Reads the scale subresource of the workload, the HorizontalPodAutoscalers targeting it with their current versus target metrics " +
+				"and AbleToScale, ScalingActive and ScalingLimited conditions, and, if installed, the KEDA ScaledObjects and VerticalPodAutoscaler recommendations for it. " +
+				"Returns a structured explanation with the replicas each metric proposes and the limiting factors (min/max replicas, stabilization, unavailable metrics, inactive triggers)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the workload (examples of valid apiVersion are apps/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the workload (examples of valid kind are: Deployment, StatefulSet)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the workload (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the workload",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Autoscaling: Explain",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: autoscalingExplain},
+	}
+}
+
+// scaledObject is the subset of a KEDA ScaledObject inspected by autoscaling_explain.
+type scaledObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ScaleTargetRef struct {
+			APIVersion string `json:"apiVersion,omitempty"`
+			Kind       string `json:"kind,omitempty"`
+			Name       string `json:"name"`
+		} `json:"scaleTargetRef"`
+		MinReplicaCount  *int32                `json:"minReplicaCount,omitempty"`
+		MaxReplicaCount  *int32                `json:"maxReplicaCount,omitempty"`
+		IdleReplicaCount *int32                `json:"idleReplicaCount,omitempty"`
+		Triggers         []scaledObjectTrigger `json:"triggers"`
+	} `json:"spec"`
+	Status struct {
+		HPAName    string                `json:"hpaName,omitempty"`
+		Conditions []autoscalerCondition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+type scaledObjectTrigger struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// verticalPodAutoscaler is the subset of a VerticalPodAutoscaler inspected by autoscaling_explain.
+type verticalPodAutoscaler struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		TargetRef struct {
+			APIVersion string `json:"apiVersion,omitempty"`
+			Kind       string `json:"kind"`
+			Name       string `json:"name"`
+		} `json:"targetRef"`
+		UpdatePolicy *struct {
+			UpdateMode string `json:"updateMode,omitempty"`
+		} `json:"updatePolicy,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Recommendation *struct {
+			ContainerRecommendations []containerRecommendation `json:"containerRecommendations,omitempty"`
+		} `json:"recommendation,omitempty"`
+		Conditions []autoscalerCondition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+type containerRecommendation struct {
+	ContainerName string          `json:"containerName"`
+	Target        v1.ResourceList `json:"target"`
+	LowerBound    v1.ResourceList `json:"lowerBound,omitempty"`
+	UpperBound    v1.ResourceList `json:"upperBound,omitempty"`
+}
+
+// autoscalerCondition is a KEDA or VPA condition, which don't always set the fields required by metav1.Condition.
+type autoscalerCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// autoscalingTarget is the workload being explained, with the replicas read from its scale subresource.
+type autoscalingTarget struct {
+	Kind            string
+	Name            string
+	Namespace       string
+	SpecReplicas    int64
+	CurrentReplicas int64
+}
+
+type autoscalingExplanation struct {
+	Target                   string                    `json:"target"`
+	Replicas                 string                    `json:"replicas"`
+	Summary                  string                    `json:"summary"`
+	HorizontalPodAutoscalers []hpaExplanation          `json:"horizontalPodAutoscalers,omitempty"`
+	ScaledObjects            []scaledObjectExplanation `json:"scaledObjects,omitempty"`
+	VerticalPodAutoscalers   []vpaExplanation          `json:"verticalPodAutoscalers,omitempty"`
+	Limits                   []string                  `json:"limits,omitempty"`
+	Errors                   []string                  `json:"errors,omitempty"`
+}
+
+type hpaExplanation struct {
+	Name       string              `json:"name"`
+	ManagedBy  string              `json:"managedBy,omitempty"`
+	Replicas   string              `json:"replicas"`
+	Metrics    []metricExplanation `json:"metrics,omitempty"`
+	Conditions []string            `json:"conditions,omitempty"`
+	Decision   string              `json:"decision"`
+	Limits     []string            `json:"limits,omitempty"`
+}
+
+type metricExplanation struct {
+	Metric           string `json:"metric"`
+	Current          string `json:"current"`
+	Target           string `json:"target"`
+	ProposedReplicas *int32 `json:"proposedReplicas,omitempty"`
+}
+
+type scaledObjectExplanation struct {
+	Name       string   `json:"name"`
+	HPA        string   `json:"hpa,omitempty"`
+	Replicas   string   `json:"replicas"`
+	Triggers   []string `json:"triggers,omitempty"`
+	Conditions []string `json:"conditions,omitempty"`
+	Limits     []string `json:"limits,omitempty"`
+}
+
+type vpaExplanation struct {
+	Name            string   `json:"name"`
+	UpdateMode      string   `json:"updateMode"`
+	Recommendations []string `json:"recommendations,omitempty"`
+	Conditions      []string `json:"conditions,omitempty"`
+	Limits          []string `json:"limits,omitempty"`
+}
+
+func autoscalingExplain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain autoscaling: %w", err)), nil
+	}
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain autoscaling: %w", err)), nil
+	}
+
+	scale, err := kubernetes.NewCore(params).ResourcesScale(params.Context, gvk, namespace, name, 0, false)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get the scale of %s %s/%s: %w", gvk.Kind, namespace, name, err)), nil
+	}
+	target := autoscalingTarget{Kind: gvk.Kind, Name: name, Namespace: namespace}
+	target.SpecReplicas, _, _ = unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	target.CurrentReplicas, _, _ = unstructured.NestedInt64(scale.Object, "status", "replicas")
+
+	var errs []string
+	var hpas []autoscalingv2.HorizontalPodAutoscaler
+	hpaList, err := params.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("failed to list horizontalpodautoscalers: %v", err))
+	} else {
+		for _, hpa := range hpaList.Items {
+			ref := hpa.Spec.ScaleTargetRef
+			if ref.Kind == gvk.Kind && ref.Name == name && groupOf(ref.APIVersion) == gvk.Group {
+				hpas = append(hpas, hpa)
+			}
+		}
+	}
+	var scaledObjects []scaledObject
+	if err = listAutoscalers(params, scaledObjectGVR, namespace, func(item *unstructured.Unstructured) error {
+		so := scaledObject{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &so); err != nil {
+			return err
+		}
+		ref := so.Spec.ScaleTargetRef
+		if defaultIfEmpty(ref.Kind, "Deployment") == gvk.Kind && ref.Name == name && groupOf(defaultIfEmpty(ref.APIVersion, "apps/v1")) == gvk.Group {
+			scaledObjects = append(scaledObjects, so)
+		}
+		return nil
+	}); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to list KEDA scaledobjects: %v", err))
+	}
+	var vpas []verticalPodAutoscaler
+	if err = listAutoscalers(params, verticalPodAutoscalerGVR, namespace, func(item *unstructured.Unstructured) error {
+		vpa := verticalPodAutoscaler{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &vpa); err != nil {
+			return err
+		}
+		if vpa.Spec.TargetRef.Kind == gvk.Kind && vpa.Spec.TargetRef.Name == name {
+			vpas = append(vpas, vpa)
+		}
+		return nil
+	}); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to list verticalpodautoscalers: %v", err))
+	}
+
+	explanation := explainAutoscaling(target, hpas, scaledObjects, vpas)
+	explanation.Errors = errs
+	ret, err := output.MarshalYaml(explanation)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain autoscaling: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// listAutoscalers lists the optional autoscaler custom resources, ignoring them when their CRD is not installed.
+func listAutoscalers(params api.ToolHandlerParams, gvr schema.GroupVersionResource, namespace string, visit func(*unstructured.Unstructured) error) error {
+	list, err := params.DynamicClient().Resource(gvr).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if err = visit(&list.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func explainAutoscaling(target autoscalingTarget, hpas []autoscalingv2.HorizontalPodAutoscaler, scaledObjects []scaledObject, vpas []verticalPodAutoscaler) autoscalingExplanation {
+	explanation := autoscalingExplanation{
+		Target:   fmt.Sprintf("%s %s/%s", target.Kind, target.Namespace, target.Name),
+		Replicas: fmt.Sprintf("spec=%d current=%d", target.SpecReplicas, target.CurrentReplicas),
+	}
+	for i := range hpas {
+		explanation.HorizontalPodAutoscalers = append(explanation.HorizontalPodAutoscalers, explainHPA(&hpas[i]))
+	}
+	for i := range scaledObjects {
+		so := explainScaledObject(&scaledObjects[i])
+		for j := range explanation.HorizontalPodAutoscalers {
+			if explanation.HorizontalPodAutoscalers[j].Name == so.HPA {
+				explanation.HorizontalPodAutoscalers[j].ManagedBy = "KEDA ScaledObject " + so.Name
+			}
+		}
+		explanation.ScaledObjects = append(explanation.ScaledObjects, so)
+	}
+	scalesOnResources := slices.ContainsFunc(hpas, func(hpa autoscalingv2.HorizontalPodAutoscaler) bool {
+		return slices.ContainsFunc(hpa.Spec.Metrics, func(m autoscalingv2.MetricSpec) bool {
+			return m.Resource != nil && (m.Resource.Name == v1.ResourceCPU || m.Resource.Name == v1.ResourceMemory)
+		})
+	})
+	for i := range vpas {
+		explanation.VerticalPodAutoscalers = append(explanation.VerticalPodAutoscalers, explainVPA(&vpas[i], scalesOnResources))
+	}
+
+	for _, hpa := range explanation.HorizontalPodAutoscalers {
+		for _, limit := range hpa.Limits {
+			explanation.Limits = append(explanation.Limits, "HorizontalPodAutoscaler "+hpa.Name+": "+limit)
+		}
+	}
+	for _, so := range explanation.ScaledObjects {
+		for _, limit := range so.Limits {
+			explanation.Limits = append(explanation.Limits, "ScaledObject "+so.Name+": "+limit)
+		}
+	}
+	for _, vpa := range explanation.VerticalPodAutoscalers {
+		for _, limit := range vpa.Limits {
+			explanation.Limits = append(explanation.Limits, "VerticalPodAutoscaler "+vpa.Name+": "+limit)
+		}
+	}
+
+	switch {
+	case len(hpas) == 0 && len(scaledObjects) == 0:
+		explanation.Summary = fmt.Sprintf("no HorizontalPodAutoscaler or KEDA ScaledObject targets the %s, its %d replicas are only changed manually or by its owner", target.Kind, target.SpecReplicas)
+	case len(hpas) > 1:
+		explanation.Summary = fmt.Sprintf("%d HorizontalPodAutoscalers target the %s and fight over its replicas (AmbiguousSelector), keep a single one", len(hpas), target.Kind)
+		explanation.Limits = append(explanation.Limits, "multiple HorizontalPodAutoscalers target the same workload")
+	case len(hpas) == 1:
+		explanation.Summary = explanation.HorizontalPodAutoscalers[0].Decision
+		if managedBy := explanation.HorizontalPodAutoscalers[0].ManagedBy; managedBy != "" {
+			explanation.Summary += " (HorizontalPodAutoscaler managed by " + managedBy + ")"
+		}
+	default:
+		explanation.Summary = "the KEDA ScaledObject has no HorizontalPodAutoscaler yet, check its Ready condition"
+	}
+	return explanation
+}
+
+func explainHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) hpaExplanation {
+	minReplicas := ptr.Deref(hpa.Spec.MinReplicas, 1)
+	current, desired := hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas
+	explanation := hpaExplanation{
+		Name:     hpa.Name,
+		Replicas: fmt.Sprintf("current=%d desired=%d min=%d max=%d", current, desired, minReplicas, hpa.Spec.MaxReplicas),
+	}
+	for _, owner := range hpa.OwnerReferences {
+		if owner.Kind == "ScaledObject" {
+			explanation.ManagedBy = "KEDA ScaledObject " + owner.Name
+		}
+	}
+
+	var maxProposal *int32
+	withinTolerance := len(hpa.Spec.Metrics) > 0
+	for _, spec := range hpa.Spec.Metrics {
+		metric := metricExplanation{Metric: metricSpecName(spec), Current: "<unknown>"}
+		target := metricSpecTarget(spec)
+		metric.Target = describeMetricTarget(target)
+		status := findMetricStatus(hpa.Status.CurrentMetrics, metric.Metric)
+		if status != nil {
+			value := metricStatusValue(*status)
+			metric.Current = describeMetricValue(value)
+			if ratio, ok := metricRatio(target, value); ok && current > 0 {
+				proposed := current
+				if math.Abs(ratio-1) > hpaTolerance {
+					proposed = int32(math.Ceil(ratio * float64(current)))
+					withinTolerance = false
+				}
+				metric.ProposedReplicas = ptr.To(proposed)
+				if maxProposal == nil || proposed > *maxProposal {
+					maxProposal = ptr.To(proposed)
+				}
+			} else {
+				withinTolerance = false
+			}
+		} else {
+			withinTolerance = false
+		}
+		explanation.Metrics = append(explanation.Metrics, metric)
+	}
+
+	for _, c := range hpa.Status.Conditions {
+		explanation.Conditions = append(explanation.Conditions, fmt.Sprintf("%s=%s (%s: %s)", c.Type, c.Status, c.Reason, c.Message))
+		switch {
+		case c.Type == autoscalingv2.ScalingActive && c.Status == v1.ConditionFalse:
+			if c.Reason == "ScalingDisabled" {
+				explanation.Limits = append(explanation.Limits, "scaling is disabled because the workload was scaled to zero replicas")
+			} else {
+				explanation.Limits = append(explanation.Limits, "metrics are unavailable so the HPA does not scale ("+c.Reason+"): check the metrics server or adapter, "+
+					"and that the containers define resource requests for Utilization targets")
+			}
+		case c.Type == autoscalingv2.AbleToScale && c.Status == v1.ConditionFalse:
+			explanation.Limits = append(explanation.Limits, "the HPA cannot read or update the scale subresource ("+c.Reason+"): "+c.Message)
+		case c.Type == autoscalingv2.AbleToScale && c.Reason == "ScaleDownStabilized":
+			explanation.Limits = append(explanation.Limits, fmt.Sprintf("scale down held by the stabilization window (%ds): a higher recommendation was made recently",
+				stabilizationWindow(hpa.Spec.Behavior, false)))
+		case c.Type == autoscalingv2.AbleToScale && c.Reason == "ScaleUpStabilized":
+			explanation.Limits = append(explanation.Limits, fmt.Sprintf("scale up held by the stabilization window (%ds)", stabilizationWindow(hpa.Spec.Behavior, true)))
+		case c.Type == autoscalingv2.ScalingLimited && c.Status == v1.ConditionTrue:
+			switch c.Reason {
+			case "TooManyReplicas":
+				explanation.Limits = append(explanation.Limits, fmt.Sprintf("capped at maxReplicas=%d", hpa.Spec.MaxReplicas))
+			case "TooFewReplicas":
+				explanation.Limits = append(explanation.Limits, fmt.Sprintf("held at minReplicas=%d", minReplicas))
+			case "ScaleUpLimit", "ScaleDownLimit":
+				explanation.Limits = append(explanation.Limits, "rate limited by the scaling policies of spec.behavior ("+c.Reason+")")
+			default:
+				explanation.Limits = append(explanation.Limits, c.Reason+": "+c.Message)
+			}
+		}
+	}
+
+	switch {
+	case desired > current:
+		explanation.Decision = fmt.Sprintf("scaling up from %d to %d replicas", current, desired)
+	case desired < current:
+		explanation.Decision = fmt.Sprintf("scaling down from %d to %d replicas", current, desired)
+	default:
+		explanation.Decision = fmt.Sprintf("holding at %d replicas", current)
+	}
+	switch {
+	case withinTolerance:
+		explanation.Decision += fmt.Sprintf(", all metrics are within the %.0f%% tolerance of their targets", hpaTolerance*100)
+	case maxProposal != nil && *maxProposal != desired:
+		explanation.Decision += fmt.Sprintf(", the metrics propose %d replicas", *maxProposal)
+	}
+	return explanation
+}
+
+func explainScaledObject(so *scaledObject) scaledObjectExplanation {
+	minReplicas, maxReplicas := ptr.Deref(so.Spec.MinReplicaCount, 0), ptr.Deref(so.Spec.MaxReplicaCount, 100)
+	explanation := scaledObjectExplanation{
+		Name:     so.Name,
+		HPA:      so.Status.HPAName,
+		Replicas: fmt.Sprintf("min=%d max=%d", minReplicas, maxReplicas),
+	}
+	if so.Spec.IdleReplicaCount != nil {
+		explanation.Replicas += fmt.Sprintf(" idle=%d", *so.Spec.IdleReplicaCount)
+	}
+	for _, trigger := range so.Spec.Triggers {
+		explanation.Triggers = append(explanation.Triggers, strings.TrimSuffix(trigger.Type+" "+trigger.Name, " "))
+	}
+	if paused, ok := so.Annotations[kedaPausedReplicasAnnotation]; ok {
+		explanation.Limits = append(explanation.Limits, "autoscaling is paused at "+paused+" replicas by the "+kedaPausedReplicasAnnotation+" annotation")
+	}
+	for _, c := range so.Status.Conditions {
+		explanation.Conditions = append(explanation.Conditions, fmt.Sprintf("%s=%s (%s: %s)", c.Type, c.Status, c.Reason, c.Message))
+		switch {
+		case c.Type == "Ready" && c.Status != "True":
+			explanation.Limits = append(explanation.Limits, "the ScaledObject is not Ready: "+c.Message)
+		case c.Type == "Active" && c.Status == "False":
+			explanation.Limits = append(explanation.Limits, fmt.Sprintf("no trigger is active, the workload is scaled to %d replicas", ptr.Deref(so.Spec.IdleReplicaCount, minReplicas)))
+		case c.Type == "Fallback" && c.Status == "True":
+			explanation.Limits = append(explanation.Limits, "the scalers are failing and the fallback replicas are used: "+c.Message)
+		case c.Type == "Paused" && c.Status == "True" && !slices.ContainsFunc(explanation.Limits, func(limit string) bool { return strings.Contains(limit, "paused") }):
+			explanation.Limits = append(explanation.Limits, "autoscaling is paused: "+c.Message)
+		}
+	}
+	return explanation
+}
+
+func explainVPA(vpa *verticalPodAutoscaler, scalesOnResources bool) vpaExplanation {
+	explanation := vpaExplanation{Name: vpa.Name, UpdateMode: "Auto"}
+	if vpa.Spec.UpdatePolicy != nil && vpa.Spec.UpdatePolicy.UpdateMode != "" {
+		explanation.UpdateMode = vpa.Spec.UpdatePolicy.UpdateMode
+	}
+	if vpa.Status.Recommendation != nil {
+		for _, r := range vpa.Status.Recommendation.ContainerRecommendations {
+			explanation.Recommendations = append(explanation.Recommendations, fmt.Sprintf("%s: target %s (lower %s, upper %s)",
+				r.ContainerName, describeResources(r.Target), describeResources(r.LowerBound), describeResources(r.UpperBound)))
+		}
+	}
+	for _, c := range vpa.Status.Conditions {
+		explanation.Conditions = append(explanation.Conditions, fmt.Sprintf("%s=%s (%s: %s)", c.Type, c.Status, c.Reason, c.Message))
+		if c.Type == "RecommendationProvided" && c.Status == "False" {
+			explanation.Limits = append(explanation.Limits, "no recommendation provided yet: "+c.Message)
+		}
+	}
+	if scalesOnResources && slices.Contains(vpaUpdateModesEvictingPods, explanation.UpdateMode) {
+		explanation.Limits = append(explanation.Limits, "the VPA updates the cpu/memory requests the HPA scales on, which makes them conflict: "+
+			"use the VPA in Off or Initial mode, or scale the HPA on custom metrics")
+	}
+	return explanation
+}
+
+func metricSpecName(spec autoscalingv2.MetricSpec) string {
+	switch {
+	case spec.Resource != nil:
+		return "resource " + string(spec.Resource.Name)
+	case spec.ContainerResource != nil:
+		return fmt.Sprintf("container %s resource %s", spec.ContainerResource.Container, spec.ContainerResource.Name)
+	case spec.Pods != nil:
+		return "pods " + spec.Pods.Metric.Name
+	case spec.Object != nil:
+		return fmt.Sprintf("object %s/%s %s", spec.Object.DescribedObject.Kind, spec.Object.DescribedObject.Name, spec.Object.Metric.Name)
+	case spec.External != nil:
+		return "external " + spec.External.Metric.Name
+	default:
+		return string(spec.Type)
+	}
+}
+
+func metricStatusName(status autoscalingv2.MetricStatus) string {
+	switch {
+	case status.Resource != nil:
+		return "resource " + string(status.Resource.Name)
+	case status.ContainerResource != nil:
+		return fmt.Sprintf("container %s resource %s", status.ContainerResource.Container, status.ContainerResource.Name)
+	case status.Pods != nil:
+		return "pods " + status.Pods.Metric.Name
+	case status.Object != nil:
+		return fmt.Sprintf("object %s/%s %s", status.Object.DescribedObject.Kind, status.Object.DescribedObject.Name, status.Object.Metric.Name)
+	case status.External != nil:
+		return "external " + status.External.Metric.Name
+	default:
+		return string(status.Type)
+	}
+}
+
+func findMetricStatus(statuses []autoscalingv2.MetricStatus, name string) *autoscalingv2.MetricStatus {
+	for i := range statuses {
+		if metricStatusName(statuses[i]) == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func metricSpecTarget(spec autoscalingv2.MetricSpec) autoscalingv2.MetricTarget {
+	switch {
+	case spec.Resource != nil:
+		return spec.Resource.Target
+	case spec.ContainerResource != nil:
+		return spec.ContainerResource.Target
+	case spec.Pods != nil:
+		return spec.Pods.Target
+	case spec.Object != nil:
+		return spec.Object.Target
+	case spec.External != nil:
+		return spec.External.Target
+	default:
+		return autoscalingv2.MetricTarget{}
+	}
+}
+
+func metricStatusValue(status autoscalingv2.MetricStatus) autoscalingv2.MetricValueStatus {
+	switch {
+	case status.Resource != nil:
+		return status.Resource.Current
+	case status.ContainerResource != nil:
+		return status.ContainerResource.Current
+	case status.Pods != nil:
+		return status.Pods.Current
+	case status.Object != nil:
+		return status.Object.Current
+	case status.External != nil:
+		return status.External.Current
+	default:
+		return autoscalingv2.MetricValueStatus{}
+	}
+}
+
+// metricRatio returns the usage ratio the HPA controller multiplies the current replicas with.
+func metricRatio(target autoscalingv2.MetricTarget, current autoscalingv2.MetricValueStatus) (float64, bool) {
+	switch {
+	case target.AverageUtilization != nil && current.AverageUtilization != nil && *target.AverageUtilization > 0:
+		return float64(*current.AverageUtilization) / float64(*target.AverageUtilization), true
+	case target.AverageValue != nil && current.AverageValue != nil && !target.AverageValue.IsZero():
+		return float64(current.AverageValue.MilliValue()) / float64(target.AverageValue.MilliValue()), true
+	case target.Value != nil && current.Value != nil && !target.Value.IsZero():
+		return float64(current.Value.MilliValue()) / float64(target.Value.MilliValue()), true
+	default:
+		return 0, false
+	}
+}
+
+func describeMetricTarget(target autoscalingv2.MetricTarget) string {
+	return describeMetricValue(autoscalingv2.MetricValueStatus{Value: target.Value, AverageValue: target.AverageValue, AverageUtilization: target.AverageUtilization})
+}
+
+func describeMetricValue(value autoscalingv2.MetricValueStatus) string {
+	var parts []string
+	if value.AverageUtilization != nil {
+		parts = append(parts, fmt.Sprintf("%d%% utilization", *value.AverageUtilization))
+	}
+	if value.AverageValue != nil {
+		parts = append(parts, "average "+value.AverageValue.String())
+	}
+	if value.Value != nil {
+		parts = append(parts, "value "+value.Value.String())
+	}
+	if len(parts) == 0 {
+		return "<unknown>"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stabilizationWindow returns the stabilization window in seconds, which defaults to 0 for scale up and 300 for scale down.
+func stabilizationWindow(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, scaleUp bool) int32 {
+	if scaleUp {
+		if behavior != nil && behavior.ScaleUp != nil && behavior.ScaleUp.StabilizationWindowSeconds != nil {
+			return *behavior.ScaleUp.StabilizationWindowSeconds
+		}
+		return 0
+	}
+	if behavior != nil && behavior.ScaleDown != nil && behavior.ScaleDown.StabilizationWindowSeconds != nil {
+		return *behavior.ScaleDown.StabilizationWindowSeconds
+	}
+	return 300
+}
+
+func describeResources(resources v1.ResourceList) string {
+	if len(resources) == 0 {
+		return "<none>"
+	}
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		quantity := resources[v1.ResourceName(name)]
+		parts = append(parts, name+"="+quantity.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func groupOf(apiVersion string) string {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ""
+	}
+	return gv.Group
+}
+
+func defaultIfEmpty(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+type AutoscalingExplainSuite struct {
+	suite.Suite
+}
+
+func TestAutoscalingExplainSuite(t *testing.T) {
+	suite.Run(t, new(AutoscalingExplainSuite))
+}
+
+func testTarget() autoscalingTarget {
+	return autoscalingTarget{Kind: "Deployment", Name: "web", Namespace: "ns-1", SpecReplicas: 5, CurrentReplicas: 5}
+}
+
+func testHPA(currentUtilization int32, conditions ...autoscalingv2.HorizontalPodAutoscalerCondition) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			MinReplicas:    ptr.To(int32(2)),
+			MaxReplicas:    5,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   v1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To(int32(50))},
+				},
+			}},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 5,
+			DesiredReplicas: 5,
+			CurrentMetrics: []autoscalingv2.MetricStatus{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name:    v1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{AverageUtilization: ptr.To(currentUtilization)},
+				},
+			}},
+			Conditions: conditions,
+		},
+	}
+}
+
+func (s *AutoscalingExplainSuite) TestExplainHPA() {
+	s.Run("reports the replicas proposed by each metric and the maxReplicas cap", func() {
+		explanation := explainHPA(testHPA(90, autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type: autoscalingv2.ScalingLimited, Status: v1.ConditionTrue, Reason: "TooManyReplicas", Message: "the desired replica count is more than the maximum replica count",
+		}))
+		s.Equal("current=5 desired=5 min=2 max=5", explanation.Replicas)
+		s.Require().Len(explanation.Metrics, 1)
+		s.Equal("resource cpu", explanation.Metrics[0].Metric)
+		s.Equal("90% utilization", explanation.Metrics[0].Current)
+		s.Equal("50% utilization", explanation.Metrics[0].Target)
+		s.Equal(ptr.To(int32(9)), explanation.Metrics[0].ProposedReplicas)
+		s.Equal("holding at 5 replicas, the metrics propose 9 replicas", explanation.Decision)
+		s.Equal([]string{"capped at maxReplicas=5"}, explanation.Limits)
+	})
+	s.Run("ignores metrics within the tolerance", func() {
+		explanation := explainHPA(testHPA(54))
+		s.Equal(ptr.To(int32(5)), explanation.Metrics[0].ProposedReplicas)
+		s.Equal("holding at 5 replicas, all metrics are within the 10% tolerance of their targets", explanation.Decision)
+	})
+	s.Run("reports unavailable metrics", func() {
+		hpa := testHPA(0, autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type: autoscalingv2.ScalingActive, Status: v1.ConditionFalse, Reason: "FailedGetResourceMetric", Message: "missing request for cpu",
+		})
+		hpa.Status.CurrentMetrics = nil
+		explanation := explainHPA(hpa)
+		s.Equal("<unknown>", explanation.Metrics[0].Current)
+		s.Nil(explanation.Metrics[0].ProposedReplicas)
+		s.Require().Len(explanation.Limits, 1)
+		s.Contains(explanation.Limits[0], "metrics are unavailable so the HPA does not scale (FailedGetResourceMetric)")
+	})
+	s.Run("reports the scale down stabilization window", func() {
+		explanation := explainHPA(testHPA(10, autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type: autoscalingv2.AbleToScale, Status: v1.ConditionTrue, Reason: "ScaleDownStabilized",
+		}))
+		s.Equal(ptr.To(int32(1)), explanation.Metrics[0].ProposedReplicas)
+		s.Equal([]string{"scale down held by the stabilization window (300s): a higher recommendation was made recently"}, explanation.Limits)
+	})
+}
+
+func (s *AutoscalingExplainSuite) TestExplainAutoscaling() {
+	s.Run("explains workloads without autoscalers", func() {
+		explanation := explainAutoscaling(testTarget(), nil, nil, nil)
+		s.Equal("Deployment ns-1/web", explanation.Target)
+		s.Equal("spec=5 current=5", explanation.Replicas)
+		s.Contains(explanation.Summary, "no HorizontalPodAutoscaler or KEDA ScaledObject targets the Deployment")
+	})
+	s.Run("links the HPA to its KEDA ScaledObject", func() {
+		hpa := testHPA(50)
+		hpa.Name = "keda-hpa-web"
+		so := scaledObject{}
+		so.Name = "web"
+		so.Status.HPAName = "keda-hpa-web"
+		so.Spec.Triggers = []scaledObjectTrigger{{Type: "prometheus"}}
+		so.Status.Conditions = []autoscalerCondition{{Type: "Active", Status: "False", Reason: "ScalerNotActive"}}
+		explanation := explainAutoscaling(testTarget(), []autoscalingv2.HorizontalPodAutoscaler{*hpa}, []scaledObject{so}, nil)
+		s.Equal("KEDA ScaledObject web", explanation.HorizontalPodAutoscalers[0].ManagedBy)
+		s.Equal([]string{"prometheus"}, explanation.ScaledObjects[0].Triggers)
+		s.Contains(explanation.Summary, "(HorizontalPodAutoscaler managed by KEDA ScaledObject web)")
+		s.Contains(explanation.Limits, "ScaledObject web: no trigger is active, the workload is scaled to 0 replicas")
+	})
+	s.Run("reports VPAs conflicting with the HPA", func() {
+		vpa := verticalPodAutoscaler{}
+		vpa.Name = "web"
+		vpa.Status.Recommendation = &struct {
+			ContainerRecommendations []containerRecommendation `json:"containerRecommendations,omitempty"`
+		}{ContainerRecommendations: []containerRecommendation{{
+			ContainerName: "app",
+			Target:        v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m"), v1.ResourceMemory: resource.MustParse("256Mi")},
+		}}}
+		explanation := explainAutoscaling(testTarget(), []autoscalingv2.HorizontalPodAutoscaler{*testHPA(50)}, nil, []verticalPodAutoscaler{vpa})
+		s.Require().Len(explanation.VerticalPodAutoscalers, 1)
+		s.Equal("Auto", explanation.VerticalPodAutoscalers[0].UpdateMode)
+		s.Equal([]string{"app: target cpu=250m, memory=256Mi (lower <none>, upper <none>)"}, explanation.VerticalPodAutoscalers[0].Recommendations)
+		s.Require().Len(explanation.Limits, 1)
+		s.Contains(explanation.Limits[0], "VerticalPodAutoscaler web: the VPA updates the cpu/memory requests the HPA scales on")
+	})
+}
@@ -0,0 +1,383 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// deprecatedAPI is an entry of the built-in table of deprecated and removed Kubernetes APIs.
+type deprecatedAPI struct {
+	GroupVersionKind schema.GroupVersionKind
+	DeprecatedIn     string
+	RemovedIn        string
+	// Replacement is the GroupVersionKind to migrate to, or nil if the API was removed without a replacement.
+	Replacement *schema.GroupVersionKind
+	Note        string
+}
+
+func gvk(apiVersion, kind string) schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(apiVersion, kind)
+}
+
+func replacedBy(apiVersion, kind string) *schema.GroupVersionKind {
+	return ptr.To(gvk(apiVersion, kind))
+}
+
+// deprecatedAPIs is the built-in table of deprecated and removed APIs.
+// Details: https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var deprecatedAPIs = []deprecatedAPI{
+	// v1.16
+	{GroupVersionKind: gvk("extensions/v1beta1", "DaemonSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "DaemonSet")},
+	{GroupVersionKind: gvk("apps/v1beta2", "DaemonSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "DaemonSet")},
+	{GroupVersionKind: gvk("extensions/v1beta1", "Deployment"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "Deployment")},
+	{GroupVersionKind: gvk("apps/v1beta1", "Deployment"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "Deployment")},
+	{GroupVersionKind: gvk("apps/v1beta2", "Deployment"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "Deployment")},
+	{GroupVersionKind: gvk("apps/v1beta1", "StatefulSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "StatefulSet")},
+	{GroupVersionKind: gvk("apps/v1beta2", "StatefulSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "StatefulSet")},
+	{GroupVersionKind: gvk("extensions/v1beta1", "ReplicaSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "ReplicaSet")},
+	{GroupVersionKind: gvk("apps/v1beta1", "ReplicaSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "ReplicaSet")},
+	{GroupVersionKind: gvk("apps/v1beta2", "ReplicaSet"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("apps/v1", "ReplicaSet")},
+	{GroupVersionKind: gvk("extensions/v1beta1", "NetworkPolicy"), DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: replacedBy("networking.k8s.io/v1", "NetworkPolicy")},
+	{GroupVersionKind: gvk("extensions/v1beta1", "PodSecurityPolicy"), DeprecatedIn: "1.11", RemovedIn: "1.16", Replacement: replacedBy("policy/v1beta1", "PodSecurityPolicy")},
+	// v1.22
+	{GroupVersionKind: gvk("admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration"), DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: replacedBy("admissionregistration.k8s.io/v1", "MutatingWebhookConfiguration")},
+	{GroupVersionKind: gvk("admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration"), DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: replacedBy("admissionregistration.k8s.io/v1", "ValidatingWebhookConfiguration")},
+	{GroupVersionKind: gvk("apiextensions.k8s.io/v1beta1", "CustomResourceDefinition"), DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: replacedBy("apiextensions.k8s.io/v1", "CustomResourceDefinition")},
+	{GroupVersionKind: gvk("apiregistration.k8s.io/v1beta1", "APIService"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("apiregistration.k8s.io/v1", "APIService")},
+	{GroupVersionKind: gvk("authentication.k8s.io/v1beta1", "TokenReview"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("authentication.k8s.io/v1", "TokenReview")},
+	{GroupVersionKind: gvk("authorization.k8s.io/v1beta1", "LocalSubjectAccessReview"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("authorization.k8s.io/v1", "LocalSubjectAccessReview")},
+	{GroupVersionKind: gvk("authorization.k8s.io/v1beta1", "SelfSubjectAccessReview"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("authorization.k8s.io/v1", "SelfSubjectAccessReview")},
+	{GroupVersionKind: gvk("authorization.k8s.io/v1beta1", "SubjectAccessReview"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("authorization.k8s.io/v1", "SubjectAccessReview")},
+	{GroupVersionKind: gvk("certificates.k8s.io/v1beta1", "CertificateSigningRequest"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("certificates.k8s.io/v1", "CertificateSigningRequest")},
+	{GroupVersionKind: gvk("coordination.k8s.io/v1beta1", "Lease"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("coordination.k8s.io/v1", "Lease")},
+	{GroupVersionKind: gvk("extensions/v1beta1", "Ingress"), DeprecatedIn: "1.14", RemovedIn: "1.22", Replacement: replacedBy("networking.k8s.io/v1", "Ingress")},
+	{GroupVersionKind: gvk("networking.k8s.io/v1beta1", "Ingress"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("networking.k8s.io/v1", "Ingress")},
+	{GroupVersionKind: gvk("networking.k8s.io/v1beta1", "IngressClass"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("networking.k8s.io/v1", "IngressClass")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io/v1beta1", "ClusterRole"), DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: replacedBy("rbac.authorization.k8s.io/v1", "ClusterRole")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding"), DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: replacedBy("rbac.authorization.k8s.io/v1", "ClusterRoleBinding")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io/v1beta1", "Role"), DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: replacedBy("rbac.authorization.k8s.io/v1", "Role")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io/v1beta1", "RoleBinding"), DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: replacedBy("rbac.authorization.k8s.io/v1", "RoleBinding")},
+	{GroupVersionKind: gvk("scheduling.k8s.io/v1beta1", "PriorityClass"), DeprecatedIn: "1.14", RemovedIn: "1.22", Replacement: replacedBy("scheduling.k8s.io/v1", "PriorityClass")},
+	{GroupVersionKind: gvk("storage.k8s.io/v1beta1", "CSIDriver"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("storage.k8s.io/v1", "CSIDriver")},
+	{GroupVersionKind: gvk("storage.k8s.io/v1beta1", "CSINode"), DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: replacedBy("storage.k8s.io/v1", "CSINode")},
+	{GroupVersionKind: gvk("storage.k8s.io/v1beta1", "StorageClass"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("storage.k8s.io/v1", "StorageClass")},
+	{GroupVersionKind: gvk("storage.k8s.io/v1beta1", "VolumeAttachment"), DeprecatedIn: "1.19", RemovedIn: "1.22", Replacement: replacedBy("storage.k8s.io/v1", "VolumeAttachment")},
+	// v1.25
+	{GroupVersionKind: gvk("batch/v1beta1", "CronJob"), DeprecatedIn: "1.21", RemovedIn: "1.25", Replacement: replacedBy("batch/v1", "CronJob")},
+	{GroupVersionKind: gvk("discovery.k8s.io/v1beta1", "EndpointSlice"), DeprecatedIn: "1.21", RemovedIn: "1.25", Replacement: replacedBy("discovery.k8s.io/v1", "EndpointSlice")},
+	{GroupVersionKind: gvk("events.k8s.io/v1beta1", "Event"), DeprecatedIn: "1.19", RemovedIn: "1.25", Replacement: replacedBy("events.k8s.io/v1", "Event")},
+	{GroupVersionKind: gvk("autoscaling/v2beta1", "HorizontalPodAutoscaler"), DeprecatedIn: "1.22", RemovedIn: "1.25", Replacement: replacedBy("autoscaling/v2", "HorizontalPodAutoscaler")},
+	{GroupVersionKind: gvk("policy/v1beta1", "PodDisruptionBudget"), DeprecatedIn: "1.21", RemovedIn: "1.25", Replacement: replacedBy("policy/v1", "PodDisruptionBudget")},
+	{GroupVersionKind: gvk("policy/v1beta1", "PodSecurityPolicy"), DeprecatedIn: "1.21", RemovedIn: "1.25",
+		Note: "PodSecurityPolicy was removed without a direct replacement, migrate to Pod Security Admission or a third-party admission webhook"},
+	{GroupVersionKind: gvk("node.k8s.io/v1beta1", "RuntimeClass"), DeprecatedIn: "1.20", RemovedIn: "1.25", Replacement: replacedBy("node.k8s.io/v1", "RuntimeClass")},
+	// v1.26
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema"), DeprecatedIn: "1.23", RemovedIn: "1.26", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "FlowSchema")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration"), DeprecatedIn: "1.23", RemovedIn: "1.26", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "PriorityLevelConfiguration")},
+	{GroupVersionKind: gvk("autoscaling/v2beta2", "HorizontalPodAutoscaler"), DeprecatedIn: "1.23", RemovedIn: "1.26", Replacement: replacedBy("autoscaling/v2", "HorizontalPodAutoscaler")},
+	// v1.27
+	{GroupVersionKind: gvk("storage.k8s.io/v1beta1", "CSIStorageCapacity"), DeprecatedIn: "1.24", RemovedIn: "1.27", Replacement: replacedBy("storage.k8s.io/v1", "CSIStorageCapacity")},
+	// v1.29
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema"), DeprecatedIn: "1.26", RemovedIn: "1.29", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "FlowSchema")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration"), DeprecatedIn: "1.26", RemovedIn: "1.29", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "PriorityLevelConfiguration")},
+	// v1.32
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema"), DeprecatedIn: "1.29", RemovedIn: "1.32", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "FlowSchema")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io/v1beta3", "PriorityLevelConfiguration"), DeprecatedIn: "1.29", RemovedIn: "1.32", Replacement: replacedBy("flowcontrol.apiserver.k8s.io/v1", "PriorityLevelConfiguration")},
+}
+
+// deprecatedAPIFinding is an object using a deprecated or removed API.
+type deprecatedAPIFinding struct {
+	APIVersion   string `json:"apiVersion"`
+	Kind         string `json:"kind"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name"`
+	Source       string `json:"source"`
+	Status       string `json:"status"`
+	DeprecatedIn string `json:"deprecatedIn"`
+	RemovedIn    string `json:"removedIn"`
+	Replacement  string `json:"replacement,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+type deprecatedAPIsReport struct {
+	TargetVersion string                 `json:"targetVersion"`
+	Findings      []deprecatedAPIFinding `json:"findings"`
+	Errors        []string               `json:"errors,omitempty"`
+}
+
+func initDeprecatedAPIs() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "deprecated_apis_scan",
+			Description: "Scan the cluster for usages of Kubernetes APIs that are deprecated or removed in a target Kubernetes version, typically before a cluster upgrade. " +
+				"Checks live objects (the API version recorded in their managedFields), the kubectl.kubernetes.io/last-applied-configuration annotation, " +
+				"and, when the helm toolset is enabled, the manifests of deployed Helm releases against a built-in table of deprecated and removed GroupVersionKinds. " +
+				"Reports the replacement API for each object found",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target_version": {
+						Type:        "string",
+						Description: "Target Kubernetes version to check against (e.g. 1.25 or v1.32.0)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to scan (Optional, all namespaces if not provided). Cluster-scoped objects are always scanned",
+					},
+					"include_helm": {
+						Type:        "boolean",
+						Description: "Scan the manifests of deployed Helm releases, requires the helm toolset (Optional, defaults to true)",
+						Default:     api.ToRawMessage(true),
+					},
+				},
+				Required: []string{"target_version"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Deprecated APIs: Scan",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: deprecatedAPIsScan},
+	}
+}
+
+func deprecatedAPIsScan(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	targetVersion := p.RequiredString("target_version")
+	namespace := p.OptionalString("namespace", "")
+	includeHelm := p.OptionalBool("include_helm", true)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scan deprecated apis: %w", err)), nil
+	}
+	target, err := version.ParseGeneric(targetVersion)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scan deprecated apis: invalid target_version %q: %w", targetVersion, err)), nil
+	}
+
+	applicable := applicableDeprecatedAPIs(target)
+	report := &deprecatedAPIsReport{TargetVersion: fmt.Sprintf("%d.%d", target.Major(), target.Minor())}
+	scanLiveObjects(params.Context, params.KubernetesClient, namespace, applicable, report)
+	if includeHelm {
+		scanDeployedManifests(params, namespace, applicable, report)
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	if len(report.Findings) == 0 && len(report.Errors) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No usages of APIs deprecated or removed in Kubernetes %s found", report.TargetVersion), nil), nil
+	}
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scan deprecated apis: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// applicableDeprecatedAPIs returns the deprecated APIs (keyed by apiVersion and kind) that are deprecated or removed in the target version.
+func applicableDeprecatedAPIs(target *version.Version) map[schema.GroupVersionKind]deprecatedAPI {
+	ret := map[schema.GroupVersionKind]deprecatedAPI{}
+	for _, d := range deprecatedAPIs {
+		if target.AtLeast(version.MustParseGeneric(d.DeprecatedIn)) {
+			ret[d.GroupVersionKind] = d
+		}
+	}
+	return ret
+}
+
+func newDeprecatedAPIFinding(d deprecatedAPI, target string, namespace, name, source string) deprecatedAPIFinding {
+	finding := deprecatedAPIFinding{
+		APIVersion:   d.GroupVersionKind.GroupVersion().String(),
+		Kind:         d.GroupVersionKind.Kind,
+		Namespace:    namespace,
+		Name:         name,
+		Source:       source,
+		Status:       "deprecated",
+		DeprecatedIn: d.DeprecatedIn,
+		RemovedIn:    d.RemovedIn,
+		Note:         d.Note,
+	}
+	if version.MustParseGeneric(target).AtLeast(version.MustParseGeneric(d.RemovedIn)) {
+		finding.Status = "removed"
+	}
+	if d.Replacement != nil {
+		finding.Replacement = d.Replacement.GroupVersion().String() + " " + d.Replacement.Kind
+	}
+	return finding
+}
+
+// deprecatedAPIsPageSize is the number of objects requested per page when listing the live objects of a kind.
+const deprecatedAPIsPageSize = 500
+
+// scanLiveObjects lists the objects of each deprecated kind still served by the cluster, through the replacement API when available,
+// and reports those whose managedFields or last-applied-configuration annotation record a deprecated API version.
+// The API server converts objects to the requested version, so the version used to list them does not reveal how they were written.
+func scanLiveObjects(ctx context.Context, client api.KubernetesClient, namespace string, applicable map[schema.GroupVersionKind]deprecatedAPI, report *deprecatedAPIsReport) {
+	listed := map[schema.GroupVersionResource]bool{}
+	for _, d := range applicable {
+		mapping, err := deprecatedAPIMapping(client.RESTMapper(), d)
+		if err != nil {
+			continue
+		}
+		if listed[mapping.Resource] {
+			continue
+		}
+		listed[mapping.Resource] = true
+		resources := client.DynamicClient().Resource(mapping.Resource)
+		var resource dynamic.ResourceInterface = resources
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace != "" {
+			resource = resources.Namespace(namespace)
+		}
+		err = listPages(ctx, resource, func(item *unstructured.Unstructured) {
+			report.Findings = append(report.Findings, liveObjectFindings(item, applicable, report.TargetVersion)...)
+		})
+		if err != nil && !isUnlistable(err) {
+			report.Errors = append(report.Errors, fmt.Sprintf("Error listing %s: %v", mapping.Resource.String(), err))
+		}
+	}
+}
+
+// listPages lists the objects of a resource in pages of deprecatedAPIsPageSize objects, so that large clusters are not loaded in a single response.
+func listPages(ctx context.Context, resource dynamic.ResourceInterface, fn func(item *unstructured.Unstructured)) error {
+	opts := metav1.ListOptions{Limit: deprecatedAPIsPageSize}
+	for {
+		list, err := resource.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			fn(&list.Items[i])
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// deprecatedAPIMapping resolves the resource to list for a deprecated API, preferring the replacement kind if it is served.
+func deprecatedAPIMapping(mapper meta.RESTMapper, d deprecatedAPI) (*meta.RESTMapping, error) {
+	if d.Replacement != nil {
+		if mapping, err := mapper.RESTMapping(d.Replacement.GroupKind(), d.Replacement.Version); err == nil {
+			return mapping, nil
+		}
+	}
+	return mapper.RESTMapping(d.GroupVersionKind.GroupKind(), d.GroupVersionKind.Version)
+}
+
+// isUnlistable reports whether a list error means the resource cannot be listed at all (e.g. TokenReview), which is not worth reporting.
+func isUnlistable(err error) bool {
+	var statusErr interface{ Status() metav1.Status }
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.Status().Reason {
+	case metav1.StatusReasonMethodNotAllowed, metav1.StatusReasonNotFound:
+		return true
+	}
+	return false
+}
+
+func liveObjectFindings(obj *unstructured.Unstructured, applicable map[schema.GroupVersionKind]deprecatedAPI, target string) []deprecatedAPIFinding {
+	var findings []deprecatedAPIFinding
+	seen := map[string]bool{}
+	for _, entry := range obj.GetManagedFields() {
+		d, ok := applicable[gvk(entry.APIVersion, obj.GetKind())]
+		if !ok || seen[entry.APIVersion] {
+			continue
+		}
+		seen[entry.APIVersion] = true
+		findings = append(findings, newDeprecatedAPIFinding(d, target, obj.GetNamespace(), obj.GetName(), "managedFields (manager: "+entry.Manager+")"))
+	}
+	if lastApplied, ok := obj.GetAnnotations()["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+		typeMeta := &metav1.TypeMeta{}
+		if err := json.Unmarshal([]byte(lastApplied), typeMeta); err == nil {
+			if d, ok := applicable[typeMeta.GroupVersionKind()]; ok {
+				findings = append(findings, newDeprecatedAPIFinding(d, target, obj.GetNamespace(), obj.GetName(), "last-applied-configuration"))
+			}
+		}
+	}
+	return findings
+}
+
+// scanDeployedManifests reports the objects using a deprecated API in the manifests deployed by the enabled toolsets
+// implementing api.ManifestsProvider (e.g. the Helm releases of the helm toolset).
+func scanDeployedManifests(params api.ToolHandlerParams, namespace string, applicable map[schema.GroupVersionKind]deprecatedAPI, report *deprecatedAPIsReport) {
+	enabled, ok := params.BaseConfig.(api.EnabledToolsetsProvider)
+	if !ok {
+		return
+	}
+	for _, toolset := range enabled.Toolsets() {
+		provider, ok := toolset.(api.ManifestsProvider)
+		if !ok {
+			continue
+		}
+		manifests, err := provider.DeployedManifests(params, namespace)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("Error listing the manifests deployed by the %s toolset: %v", toolset.GetName(), err))
+			continue
+		}
+		for _, m := range manifests {
+			findings, err := manifestFindings(m.Manifest, m.Namespace, m.Source, applicable, report.TargetVersion)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("Error parsing manifest of %s: %v", m.Source, err))
+			}
+			report.Findings = append(report.Findings, findings...)
+		}
+	}
+}
+
+// manifestFindings reports the objects of a multi-document YAML manifest that use a deprecated API.
+func manifestFindings(manifest, defaultNamespace, source string, applicable map[schema.GroupVersionKind]deprecatedAPI, target string) ([]deprecatedAPIFinding, error) {
+	var findings []deprecatedAPIFinding
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewBufferString(manifest)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return findings, nil
+		} else if err != nil {
+			return findings, err
+		}
+		obj := &metav1.PartialObjectMetadata{}
+		if err := utilyaml.Unmarshal(doc, obj); err != nil || obj.Kind == "" {
+			continue
+		}
+		d, ok := applicable[obj.GroupVersionKind()]
+		if !ok {
+			continue
+		}
+		namespace := obj.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		findings = append(findings, newDeprecatedAPIFinding(d, target, namespace, obj.Name, source))
+	}
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+type DeprecatedAPIsSuite struct {
+	suite.Suite
+}
+
+func TestDeprecatedAPIsSuite(t *testing.T) {
+	suite.Run(t, new(DeprecatedAPIsSuite))
+}
+
+func (s *DeprecatedAPIsSuite) TestDeprecatedAPIsTable() {
+	seen := map[string]bool{}
+	for _, d := range deprecatedAPIs {
+		key := d.GroupVersionKind.String()
+		s.Falsef(seen[key], "duplicate entry for %s", key)
+		seen[key] = true
+		s.Truef(version.MustParseGeneric(d.RemovedIn).AtLeast(version.MustParseGeneric(d.DeprecatedIn)),
+			"%s is removed (%s) before being deprecated (%s)", key, d.RemovedIn, d.DeprecatedIn)
+		s.Truef(d.Replacement != nil || d.Note != "", "%s has neither replacement nor note", key)
+	}
+}
+
+func (s *DeprecatedAPIsSuite) TestApplicableDeprecatedAPIs() {
+	s.Run("includes APIs deprecated but not yet removed in target version", func() {
+		applicable := applicableDeprecatedAPIs(version.MustParseGeneric("1.23"))
+		s.Contains(applicable, gvk("policy/v1beta1", "PodDisruptionBudget"))
+		s.Contains(applicable, gvk("autoscaling/v2beta2", "HorizontalPodAutoscaler"))
+		s.NotContains(applicable, gvk("storage.k8s.io/v1beta1", "CSIStorageCapacity"))
+	})
+	s.Run("marks APIs removed in target version as removed", func() {
+		d := applicableDeprecatedAPIs(version.MustParseGeneric("v1.25.3"))[gvk("policy/v1beta1", "PodDisruptionBudget")]
+		finding := newDeprecatedAPIFinding(d, "1.25", "ns-1", "pdb", "test")
+		s.Equal("removed", finding.Status)
+		s.Equal("policy/v1 PodDisruptionBudget", finding.Replacement)
+	})
+	s.Run("marks APIs not yet removed in target version as deprecated", func() {
+		d := applicableDeprecatedAPIs(version.MustParseGeneric("1.24"))[gvk("policy/v1beta1", "PodDisruptionBudget")]
+		s.Equal("deprecated", newDeprecatedAPIFinding(d, "1.24", "ns-1", "pdb", "test").Status)
+	})
+	s.Run("reports note for APIs without replacement", func() {
+		d := applicableDeprecatedAPIs(version.MustParseGeneric("1.25"))[gvk("policy/v1beta1", "PodSecurityPolicy")]
+		finding := newDeprecatedAPIFinding(d, "1.25", "", "restricted", "test")
+		s.Empty(finding.Replacement)
+		s.Contains(finding.Note, "Pod Security Admission")
+	})
+}
+
+func (s *DeprecatedAPIsSuite) TestLiveObjectFindings() {
+	applicable := applicableDeprecatedAPIs(version.MustParseGeneric("1.25"))
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("batch/v1")
+	obj.SetKind("CronJob")
+	obj.SetNamespace("ns-1")
+	obj.SetName("nightly")
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "kubectl-client-side-apply", APIVersion: "batch/v1beta1"},
+		{Manager: "kube-controller-manager", APIVersion: "batch/v1"},
+	})
+	obj.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"batch/v1beta1","kind":"CronJob","metadata":{"name":"nightly"}}`,
+	})
+	findings := liveObjectFindings(obj, applicable, "1.25")
+	s.Require().Len(findings, 2)
+	s.Equal("managedFields (manager: kubectl-client-side-apply)", findings[0].Source)
+	s.Equal("last-applied-configuration", findings[1].Source)
+	s.Equal("batch/v1beta1", findings[1].APIVersion)
+	s.Equal("batch/v1 CronJob", findings[1].Replacement)
+	s.Equal("removed", findings[1].Status)
+}
+
+func (s *DeprecatedAPIsSuite) TestManifestFindings() {
+	manifest := `---
+# Source: chart/templates/ingress.yaml
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: rbac.authorization.k8s.io/v1beta1
+kind: ClusterRole
+metadata:
+  name: web-reader
+`
+	findings, err := manifestFindings(manifest, "ns-1", "helm release ns-1/web (revision 1)", applicableDeprecatedAPIs(version.MustParseGeneric("1.22")), "1.22")
+	s.Require().NoError(err)
+	s.Require().Len(findings, 2)
+	s.Equal("Ingress", findings[0].Kind)
+	s.Equal("ns-1", findings[0].Namespace)
+	s.Equal("networking.k8s.io/v1 Ingress", findings[0].Replacement)
+	s.Equal("ClusterRole", findings[1].Kind)
+	s.Equal("helm release ns-1/web (revision 1)", findings[1].Source)
+}
+
+// pagedResource serves the List requests in pages of the requested limit, using the index of the next item as continue token.
+type pagedResource struct {
+	dynamic.ResourceInterface
+	items    []unstructured.Unstructured
+	requests []metav1.ListOptions
+}
+
+func (r *pagedResource) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.requests = append(r.requests, opts)
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := min(start+int(opts.Limit), len(r.items))
+	list := &unstructured.UnstructuredList{Items: r.items[start:end]}
+	if end < len(r.items) {
+		list.SetContinue(strconv.Itoa(end))
+	}
+	return list, nil
+}
+
+func (s *DeprecatedAPIsSuite) TestListPages() {
+	resource := &pagedResource{}
+	for i := 0; i < 2*deprecatedAPIsPageSize+1; i++ {
+		item := unstructured.Unstructured{}
+		item.SetName("obj-" + strconv.Itoa(i))
+		resource.items = append(resource.items, item)
+	}
+	var names []string
+	s.Require().NoError(listPages(context.Background(), resource, func(item *unstructured.Unstructured) {
+		names = append(names, item.GetName())
+	}))
+	s.Len(names, 2*deprecatedAPIsPageSize+1)
+	s.Equal("obj-1000", names[1000])
+	s.Require().Len(resource.requests, 3)
+	for _, opts := range resource.requests {
+		s.Equal(int64(deprecatedAPIsPageSize), opts.Limit)
+	}
+	s.Equal("", resource.requests[0].Continue)
+	s.Equal("1000", resource.requests[2].Continue)
+}
+
+type enabledToolsetsConfig struct {
+	api.BaseConfig
+	toolsets []api.Toolset
+}
+
+func (c *enabledToolsetsConfig) Toolsets() []api.Toolset {
+	return c.toolsets
+}
+
+type manifestsToolset struct {
+	api.Toolset
+	name      string
+	manifests []api.DeployedManifest
+	err       error
+}
+
+func (t *manifestsToolset) GetName() string {
+	return t.name
+}
+
+func (t *manifestsToolset) DeployedManifests(_ api.ToolHandlerParams, _ string) ([]api.DeployedManifest, error) {
+	return t.manifests, t.err
+}
+
+func (s *DeprecatedAPIsSuite) TestScanDeployedManifests() {
+	applicable := applicableDeprecatedAPIs(version.MustParseGeneric("1.25"))
+	manifest := `apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: cleanup
+`
+	s.Run("scans the manifests of the enabled toolsets providing them", func() {
+		report := &deprecatedAPIsReport{TargetVersion: "1.25"}
+		scanDeployedManifests(api.ToolHandlerParams{BaseConfig: &enabledToolsetsConfig{toolsets: []api.Toolset{
+			&Toolset{},
+			&manifestsToolset{name: "helm", manifests: []api.DeployedManifest{
+				{Source: "helm release ns-1/jobs (revision 2)", Namespace: "ns-1", Manifest: manifest},
+			}},
+			&manifestsToolset{name: "broken", err: errors.New("storage unavailable")},
+		}}}, "", applicable, report)
+		s.Require().Len(report.Findings, 1)
+		s.Equal("CronJob", report.Findings[0].Kind)
+		s.Equal("ns-1", report.Findings[0].Namespace)
+		s.Equal("helm release ns-1/jobs (revision 2)", report.Findings[0].Source)
+		s.Equal("removed", report.Findings[0].Status)
+		s.Equal([]string{"Error listing the manifests deployed by the broken toolset: storage unavailable"}, report.Errors)
+	})
+	s.Run("skips the scan when no toolset provides manifests", func() {
+		report := &deprecatedAPIsReport{TargetVersion: "1.25"}
+		scanDeployedManifests(api.ToolHandlerParams{BaseConfig: &enabledToolsetsConfig{toolsets: []api.Toolset{&Toolset{}}}}, "", applicable, report)
+		s.Empty(report.Findings)
+		s.Empty(report.Errors)
+	})
+}
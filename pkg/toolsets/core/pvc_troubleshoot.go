@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// initPVCTroubleshoot initializes the PersistentVolumeClaim troubleshooting prompt
+func initPVCTroubleshoot() []api.ServerPrompt {
+	return []api.ServerPrompt{
+		{
+			Prompt: api.Prompt{
+				Name:        "pvc-troubleshoot",
+				Title:       "PersistentVolumeClaim Troubleshoot",
+				Description: "Generate a step-by-step troubleshooting guide for diagnosing PersistentVolumeClaim provisioning, attach, mount, and capacity issues",
+				Arguments: []api.PromptArgument{
+					{
+						Name:        "namespace",
+						Description: "The namespace of the PersistentVolumeClaim to troubleshoot",
+						Required:    true,
+					},
+					{
+						Name:        "name",
+						Description: "The name of the PersistentVolumeClaim to troubleshoot",
+						Required:    true,
+					},
+				},
+			},
+			Handler: pvcTroubleshootHandler,
+		},
+	}
+}
+
+// pvcTroubleshootHandler implements the PersistentVolumeClaim troubleshooting prompt
+func pvcTroubleshootHandler(params api.PromptHandlerParams) (*api.PromptCallResult, error) {
+	args := params.GetArguments()
+	namespace := args["namespace"]
+	name := args["name"]
+
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace argument is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name argument is required")
+	}
+
+	report, err := pvcDiagnosticReport(params.Context, params.KubernetesClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	guideText := fmt.Sprintf(`# PersistentVolumeClaim Troubleshooting Guide
+
+## PVC: %s (namespace: %s)
+
+Use this guide to diagnose issues with the PersistentVolumeClaim. The claim, its PersistentVolume, StorageClass,
+VolumeAttachments, consuming pods, volume usage, and related events have been collected and analyzed below.
+
+---
+
+%s
+
+---
+
+## Troubleshooting Analysis
+
+Walk the storage chain and, based on the data above, analyze:
+
+1. **PersistentVolumeClaim**: Is the claim Bound? If Pending, is a StorageClass set (or a default one available) and does it have a provisioner?
+2. **StorageClass**: With volumeBindingMode WaitForFirstConsumer, is there a consuming pod and can it be scheduled?
+3. **PersistentVolume**: Is the volume Bound and healthy? Does its node affinity allow the consuming pods' nodes?
+4. **VolumeAttachments**: Is the volume attached to the node running the consuming pod? Is it still attached to another node (Multi-Attach)?
+5. **Consuming Pods**: Are the pods Running, or stuck in ContainerCreating with FailedAttachVolume or FailedMount events?
+6. **Volume Usage**: Is the volume running out of space or inodes?
+
+---
+
+## Fix the Issue
+
+If you identified a problem, attempt to fix it:
+
+1. **No Provisioner**: Set a StorageClass with a dynamic provisioner, mark a default StorageClass, or create a matching PersistentVolume
+2. **WaitForFirstConsumer**: Create the consuming workload or fix the reason its pod cannot be scheduled
+3. **Multi-Attach**: Stop the other consumer of the ReadWriteOnce volume or remove the stale VolumeAttachment of a node that is gone
+4. **Mount Failures**: Check the CSI node plugin on the pod's node
+5. **Volume Full**: Free up space or expand the claim if the StorageClass allows volume expansion
+
+Use the available Kubernetes tools to apply fixes and re-run the pvcs_diagnose tool to verify the result.
+
+---
+
+## Report Findings
+
+After completing troubleshooting and attempting fixes, report:
+- **Status:** Bound/Pending/Lost
+- **Root Cause:** Description or "None found"
+- **Action Taken:** What was done to fix the issue (or "None" if no fix was needed/possible)
+- **Result:** Whether the fix was successful or further action is needed
+`, name, namespace, report)
+
+	return api.NewPromptCallResult(
+		"PersistentVolumeClaim troubleshooting guide generated",
+		[]api.PromptMessage{
+			{
+				Role: "user",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: guideText,
+				},
+			},
+			{
+				Role: "assistant",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: "I'll analyze the collected data to diagnose the PersistentVolumeClaim issues systematically.",
+				},
+			},
+		},
+		nil,
+	), nil
+}
@@ -0,0 +1,754 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
+)
+
+const (
+	// noProvisioner is the provisioner of StorageClasses that only support statically provisioned PersistentVolumes.
+	noProvisioner = "kubernetes.io/no-provisioner"
+	// volumeUsageWarningPercent and volumeUsageCriticalPercent are the volume usage thresholds reported by pvcs_diagnose.
+	volumeUsageWarningPercent  = 85
+	volumeUsageCriticalPercent = 95
+)
+
+func initPVCs() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pvcs_diagnose",
+			Description: "Diagnose why a Kubernetes PersistentVolumeClaim is Pending, cannot be attached or mounted, or is running out of space. " +
+				"Walks the PersistentVolumeClaim, its bound PersistentVolume, StorageClass, VolumeAttachments, and consuming pods together with their events, " +
+				"and reads the volume usage reported by the kubelet stats summary of the nodes running the consuming pods. " +
+				"Detects claims Pending without a provisioner or default StorageClass, WaitForFirstConsumer claims that never bind, " +
+				"Multi-Attach errors, attach and mount failures, and full volumes. " +
+				"Returns a 'Detected Issues' section with CRITICAL/WARNING/INFO findings and suggested fixes, followed by the raw diagnostic data.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the PersistentVolumeClaim to diagnose (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the PersistentVolumeClaim to diagnose",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PersistentVolumeClaims: Diagnose",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: pvcsDiagnose},
+	}
+}
+
+// pvcDiagnostics holds the objects collected for a PersistentVolumeClaim diagnosis.
+type pvcDiagnostics struct {
+	pvc *v1.PersistentVolumeClaim
+	pv  *v1.PersistentVolume
+	// storageClassName is the StorageClass requested by the claim, or the default StorageClass if the claim requests none.
+	storageClassName string
+	// storageClass is nil if the claim has no StorageClass or the StorageClass does not exist.
+	storageClass      *storagev1.StorageClass
+	volumeAttachments []storagev1.VolumeAttachment
+	// pods are the pods in the claim namespace that reference the claim in their volumes.
+	pods        []v1.Pod
+	events      []v1.Event
+	volumeStats []pvcVolumeStats
+	errors      []string
+}
+
+// pvcVolumeStats is the usage of the claim's volume as reported by the kubelet running a consuming pod.
+type pvcVolumeStats struct {
+	Node           string  `json:"node"`
+	Pod            string  `json:"pod"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	Inodes         *uint64 `json:"inodes,omitempty"`
+	InodesFree     *uint64 `json:"inodesFree,omitempty"`
+}
+
+// kubeletStatsSummary is the subset of the kubelet /stats/summary response used to read pod volume usage.
+type kubeletStatsSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		VolumeStats []struct {
+			Name   string `json:"name"`
+			PVCRef *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef,omitempty"`
+			CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+			UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+			AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+			Inodes         *uint64 `json:"inodes,omitempty"`
+			InodesFree     *uint64 `json:"inodesFree,omitempty"`
+		} `json:"volume,omitempty"`
+	} `json:"pods"`
+}
+
+func pvcsDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose persistentvolumeclaim: %w", err)), nil
+	}
+
+	report, err := pvcDiagnosticReport(params.Context, params.KubernetesClient, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(report, nil), nil
+}
+
+// pvcDiagnosticReport collects and analyzes the storage objects related to a PersistentVolumeClaim
+// and renders them as a markdown report. Shared by the pvcs_diagnose tool and the pvc-troubleshoot prompt.
+func pvcDiagnosticReport(ctx context.Context, client api.KubernetesClient, namespace, name string) (string, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get persistentvolumeclaim %s/%s: %w", namespace, name, err)
+	}
+
+	diag := collectPVCDiagnostics(ctx, client, pvc)
+	issues := analyzePVC(diag)
+
+	return fmt.Sprintf(`# PersistentVolumeClaim Diagnostic Report: %s/%s
+
+%s
+
+%s
+
+%s
+
+%s
+
+%s
+
+%s
+
+%s
+
+%s
+`, namespace, name, diagnostics.FormatIssues(issues), formatPVCSection(diag), formatPVSection(diag), formatStorageClassSection(diag),
+		formatVolumeAttachmentsSection(diag), formatPVCPodsSection(diag), formatVolumeStatsSection(diag), formatPVCEventsSection(diag)), nil
+}
+
+func collectPVCDiagnostics(ctx context.Context, client api.KubernetesClient, pvc *v1.PersistentVolumeClaim) *pvcDiagnostics {
+	diag := &pvcDiagnostics{pvc: pvc}
+	namespace := pvc.Namespace
+
+	if pvc.Spec.VolumeName != "" {
+		pv, err := client.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			diag.errors = append(diag.errors, fmt.Sprintf("Error getting PersistentVolume %s: %v", pvc.Spec.VolumeName, err))
+		} else {
+			diag.pv = pv
+		}
+	}
+
+	if pvc.Spec.StorageClassName != nil {
+		diag.storageClassName = *pvc.Spec.StorageClassName
+	} else if diag.pv != nil {
+		diag.storageClassName = diag.pv.Spec.StorageClassName
+	}
+	if diag.storageClassName != "" {
+		sc, err := client.StorageV1().StorageClasses().Get(ctx, diag.storageClassName, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			diag.errors = append(diag.errors, fmt.Sprintf("Error getting StorageClass %s: %v", diag.storageClassName, err))
+		} else if err == nil {
+			diag.storageClass = sc
+		}
+	} else if pvc.Spec.StorageClassName == nil {
+		classes, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			diag.errors = append(diag.errors, fmt.Sprintf("Error listing StorageClasses: %v", err))
+		} else if sc := defaultStorageClass(classes.Items); sc != nil {
+			diag.storageClassName = sc.Name
+			diag.storageClass = sc
+		}
+	}
+
+	if diag.pv != nil {
+		attachments, err := client.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			diag.errors = append(diag.errors, fmt.Sprintf("Error listing VolumeAttachments: %v", err))
+		} else {
+			for _, va := range attachments.Items {
+				if ptr.Deref(va.Spec.Source.PersistentVolumeName, "") == diag.pv.Name {
+					diag.volumeAttachments = append(diag.volumeAttachments, va)
+				}
+			}
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diag.errors = append(diag.errors, fmt.Sprintf("Error listing pods: %v", err))
+	} else {
+		for _, pod := range pods.Items {
+			if podUsesPVC(&pod, pvc.Name) {
+				diag.pods = append(diag.pods, pod)
+			}
+		}
+	}
+
+	// The events of the claim and of its pods are read from a single list of the namespace events,
+	// indexed by the kind and name of their involved object
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diag.errors = append(diag.errors, fmt.Sprintf("Error listing events: %v", err))
+	} else {
+		eventsByObject := indexEventsByInvolvedObject(events.Items)
+		diag.events = append(diag.events, eventsByObject["PersistentVolumeClaim/"+pvc.Name]...)
+		for _, pod := range diag.pods {
+			diag.events = append(diag.events, eventsByObject["Pod/"+pod.Name]...)
+		}
+		if diag.pv != nil && namespace == metav1.NamespaceDefault {
+			diag.events = append(diag.events, eventsByObject["PersistentVolume/"+diag.pv.Name]...)
+		}
+	}
+	if diag.pv != nil && namespace != metav1.NamespaceDefault {
+		diag.events = append(diag.events, collectPVEvents(ctx, client, diag)...)
+	}
+	sort.SliceStable(diag.events, func(i, j int) bool {
+		return kubernetes.EventTimestamp(&diag.events[i]).After(kubernetes.EventTimestamp(&diag.events[j]))
+	})
+
+	diag.volumeStats = collectVolumeStats(ctx, client, diag)
+	return diag
+}
+
+// collectPVEvents lists the events of the claim's volume. The events of cluster-scoped objects are recorded in the
+// default namespace, which is only read when the caller is allowed to list its events.
+func collectPVEvents(ctx context.Context, client api.KubernetesClient, diag *pvcDiagnostics) []v1.Event {
+	allowed, err := kubernetes.CanI(ctx, client.AuthorizationV1(), &schema.GroupVersionResource{Version: "v1", Resource: "events"}, metav1.NamespaceDefault, "", "list")
+	if err != nil || !allowed {
+		return nil
+	}
+	events, err := client.CoreV1().Events(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=PersistentVolume,involvedObject.name=" + diag.pv.Name,
+	})
+	if err != nil {
+		diag.errors = append(diag.errors, fmt.Sprintf("Error listing events for PersistentVolume %s: %v", diag.pv.Name, err))
+		return nil
+	}
+	return events.Items
+}
+
+// collectVolumeStats reads the claim's volume usage from the kubelet stats summary of each node running a consuming pod.
+func collectVolumeStats(ctx context.Context, client api.KubernetesClient, diag *pvcDiagnostics) []pvcVolumeStats {
+	var stats []pvcVolumeStats
+	core := kubernetes.NewCore(client)
+	seenNodes := map[string]bool{}
+	for _, pod := range diag.pods {
+		node := pod.Spec.NodeName
+		if node == "" || pod.Status.Phase != v1.PodRunning || seenNodes[node] {
+			continue
+		}
+		seenNodes[node] = true
+		raw, err := core.NodesStatsSummary(ctx, node)
+		if err != nil {
+			diag.errors = append(diag.errors, fmt.Sprintf("Error getting stats summary of node %s: %v", node, err))
+			continue
+		}
+		if s := volumeStatsFromSummary(raw, node, diag.pvc); s != nil {
+			stats = append(stats, *s)
+		}
+	}
+	return stats
+}
+
+// volumeStatsFromSummary extracts the usage of the given claim from a kubelet stats summary response.
+func volumeStatsFromSummary(raw, node string, pvc *v1.PersistentVolumeClaim) *pvcVolumeStats {
+	summary := &kubeletStatsSummary{}
+	if err := json.Unmarshal([]byte(raw), summary); err != nil {
+		return nil
+	}
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.VolumeStats {
+			if volume.PVCRef == nil || volume.PVCRef.Name != pvc.Name || volume.PVCRef.Namespace != pvc.Namespace {
+				continue
+			}
+			return &pvcVolumeStats{
+				Node:           node,
+				Pod:            pod.PodRef.Name,
+				CapacityBytes:  volume.CapacityBytes,
+				UsedBytes:      volume.UsedBytes,
+				AvailableBytes: volume.AvailableBytes,
+				Inodes:         volume.Inodes,
+				InodesFree:     volume.InodesFree,
+			}
+		}
+	}
+	return nil
+}
+
+func defaultStorageClass(classes []storagev1.StorageClass) *storagev1.StorageClass {
+	for i := range classes {
+		if classes[i].Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
+func podUsesPVC(pod *v1.Pod, claimName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+		// Generic ephemeral volumes create a claim named <pod>-<volume>
+		if volume.Ephemeral != nil && pod.Name+"-"+volume.Name == claimName {
+			return true
+		}
+	}
+	return false
+}
+
+func analyzePVC(diag *pvcDiagnostics) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, e := range diag.errors {
+		issues = append(issues, diagnostics.Issue{Severity: diagnostics.SeverityWarning, Message: e})
+	}
+	issues = append(issues, checkPVCBinding(diag)...)
+	issues = append(issues, checkPVCAttachment(diag)...)
+	issues = append(issues, checkPVCMounts(diag)...)
+	issues = append(issues, checkPVCUsage(diag)...)
+	return issues
+}
+
+// checkPVCBinding explains why a claim is not Bound.
+func checkPVCBinding(diag *pvcDiagnostics) []diagnostics.Issue {
+	pvc := diag.pvc
+	switch pvc.Status.Phase {
+	case v1.ClaimLost:
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("PersistentVolumeClaim is Lost: its PersistentVolume %q no longer exists.", pvc.Spec.VolumeName),
+			Fix:      "Restore the PersistentVolume from a backup or snapshot, or delete and recreate the PersistentVolumeClaim (data on the lost volume will not be recovered).",
+		}}
+	case v1.ClaimBound:
+		if diag.pv != nil && diag.pv.Status.Phase == v1.VolumeFailed {
+			return []diagnostics.Issue{{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("PersistentVolume %q is Failed: %s", diag.pv.Name, diag.pv.Status.Message),
+				Fix:      "Check the storage backend and the CSI driver logs for the failure reported on the PersistentVolume.",
+			}}
+		}
+		return nil
+	}
+
+	if pvc.Spec.VolumeName != "" {
+		if diag.pv == nil {
+			return []diagnostics.Issue{{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("PersistentVolumeClaim is Pending and references PersistentVolume %q which does not exist.", pvc.Spec.VolumeName),
+				Fix:      fmt.Sprintf("Create PersistentVolume %q or remove spec.volumeName so the claim can be dynamically provisioned.", pvc.Spec.VolumeName),
+			}}
+		}
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message: fmt.Sprintf("PersistentVolumeClaim is Pending and pre-bound to PersistentVolume %q (phase %s) which does not satisfy it.",
+				diag.pv.Name, diag.pv.Status.Phase),
+			Fix: "Make sure the PersistentVolume's capacity, access modes, storageClassName, and claimRef match the claim.",
+		}}
+	}
+
+	if diag.storageClassName == "" {
+		if pvc.Spec.StorageClassName != nil {
+			return []diagnostics.Issue{{
+				Severity: diagnostics.SeverityCritical,
+				Message:  "PersistentVolumeClaim is Pending with storageClassName \"\": it can only bind to a pre-provisioned PersistentVolume without a StorageClass and none matches.",
+				Fix:      "Create a PersistentVolume with no storageClassName that matches the claim's size and access modes, or set a storageClassName with a dynamic provisioner.",
+			}}
+		}
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  "PersistentVolumeClaim is Pending: it requests no StorageClass and the cluster has no default StorageClass, so no provisioner will create a volume.",
+			Fix:      "Set spec.storageClassName to an existing StorageClass or mark one as default with the annotation storageclass.kubernetes.io/is-default-class=true.",
+		}}
+	}
+	if diag.storageClass == nil {
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("PersistentVolumeClaim is Pending: StorageClass %q does not exist.", diag.storageClassName),
+			Fix:      "Create the StorageClass or recreate the claim with an existing StorageClass (storageClassName is immutable).",
+		}}
+	}
+
+	var issues []diagnostics.Issue
+	sc := diag.storageClass
+	if sc.Provisioner == noProvisioner {
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("PersistentVolumeClaim is Pending: StorageClass %q has no dynamic provisioner (%s) and no available PersistentVolume matches the claim.", sc.Name, noProvisioner),
+			Fix:      fmt.Sprintf("Create a PersistentVolume with storageClassName %q that matches the claim's size and access modes.", sc.Name),
+		})
+	}
+
+	if ptr.Deref(sc.VolumeBindingMode, storagev1.VolumeBindingImmediate) == storagev1.VolumeBindingWaitForFirstConsumer {
+		if len(diag.pods) == 0 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("StorageClass %q uses WaitForFirstConsumer and no pod references this claim, so it will stay Pending until a consuming pod is scheduled.", sc.Name),
+				Fix:      "This is expected until a workload mounts the claim. Create the consuming pod, or use a StorageClass with volumeBindingMode Immediate.",
+			})
+		}
+		for _, pod := range diag.pods {
+			if pod.Spec.NodeName != "" {
+				continue
+			}
+			message := "the pod has not been scheduled yet"
+			if event := latestEvent(diag.events, "Pod", pod.Name, "FailedScheduling"); event != nil {
+				message = event.Message
+			}
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("StorageClass %q uses WaitForFirstConsumer but consuming pod %q cannot be scheduled, so the claim never binds: %s", sc.Name, pod.Name, message),
+				Fix:      "Resolve the scheduling failure (node resources, selectors, taints, or topology constraints such as allowedTopologies on the StorageClass).",
+			})
+		}
+	}
+
+	if event := latestEvent(diag.events, "PersistentVolumeClaim", pvc.Name, "ProvisioningFailed"); event != nil {
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("Provisioning by %q failed: %s", sc.Provisioner, event.Message),
+			Fix:      "Check the logs of the provisioner (CSI controller) and the StorageClass parameters.",
+		})
+	} else if event := latestEvent(diag.events, "PersistentVolumeClaim", pvc.Name, "ExternalProvisioning"); event != nil && len(issues) == 0 {
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityWarning,
+			Message:  fmt.Sprintf("PersistentVolumeClaim is waiting for external provisioner %q: %s", sc.Provisioner, event.Message),
+			Fix:      fmt.Sprintf("Check that the CSI controller for %q is installed and running, and review its logs.", sc.Provisioner),
+		})
+	}
+
+	if len(issues) == 0 {
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityWarning,
+			Message:  fmt.Sprintf("PersistentVolumeClaim is Pending with StorageClass %q (provisioner %s).", sc.Name, sc.Provisioner),
+			Fix:      "Review the claim events and the provisioner logs.",
+		})
+	}
+	return issues
+}
+
+// checkPVCAttachment detects Multi-Attach errors and VolumeAttachment failures.
+func checkPVCAttachment(diag *pvcDiagnostics) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, pod := range diag.pods {
+		event := latestEvent(diag.events, "Pod", pod.Name, "FailedAttachVolume")
+		if event == nil {
+			continue
+		}
+		if strings.Contains(event.Message, "Multi-Attach error") {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("Pod %q cannot attach the volume: %s", pod.Name, event.Message),
+				Fix: "The volume is ReadWriteOnce and still attached to another node. Stop the other consumer, " +
+					"or if the previous node is gone, delete its stale VolumeAttachment (and the pod stuck Terminating on that node).",
+			})
+		} else {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("Pod %q cannot attach the volume: %s", pod.Name, event.Message),
+				Fix:      "Check the CSI driver node and controller plugin logs and the VolumeAttachment status.",
+			})
+		}
+	}
+
+	if isReadWriteOnce(diag.pvc) {
+		nodes := map[string][]string{}
+		for _, pod := range diag.pods {
+			if pod.Spec.NodeName != "" && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+				nodes[pod.Spec.NodeName] = append(nodes[pod.Spec.NodeName], pod.Name)
+			}
+		}
+		if len(nodes) > 1 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("ReadWriteOnce claim is used by pods on %d different nodes (%s); only one node can attach it at a time.", len(nodes), strings.Join(sortedKeys(nodes), ", ")),
+				Fix:      "Run all consumers on the same node, use a ReadWriteMany volume, or use a Recreate deployment strategy instead of RollingUpdate.",
+			})
+		}
+	}
+
+	for _, va := range diag.volumeAttachments {
+		if va.Status.AttachError != nil {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("VolumeAttachment %q to node %q failed: %s", va.Name, va.Spec.NodeName, va.Status.AttachError.Message),
+				Fix:      fmt.Sprintf("Check the logs of CSI driver %q and the storage backend.", va.Spec.Attacher),
+			})
+		}
+		if va.Status.DetachError != nil {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("VolumeAttachment %q cannot be detached from node %q: %s", va.Name, va.Spec.NodeName, va.Status.DetachError.Message),
+				Fix:      "A volume stuck attached blocks other nodes from using it. Check the node and the CSI driver logs.",
+			})
+		}
+	}
+	return issues
+}
+
+// checkPVCMounts reports mount failures of the consuming pods.
+func checkPVCMounts(diag *pvcDiagnostics) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, pod := range diag.pods {
+		if pod.Status.Phase == v1.PodRunning {
+			continue
+		}
+		if event := latestEvent(diag.events, "Pod", pod.Name, "FailedMount"); event != nil {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("Pod %q cannot mount the volume: %s", pod.Name, event.Message),
+				Fix:      "Check the kubelet and CSI node plugin logs on the pod's node, and the filesystem type and mount options of the volume.",
+			})
+		}
+	}
+	return issues
+}
+
+// checkPVCUsage reports volumes running out of space or inodes according to the kubelet stats.
+func checkPVCUsage(diag *pvcDiagnostics) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, s := range diag.volumeStats {
+		if s.CapacityBytes != nil && s.UsedBytes != nil && *s.CapacityBytes > 0 {
+			percent := *s.UsedBytes * 100 / *s.CapacityBytes
+			full := s.AvailableBytes != nil && *s.AvailableBytes == 0
+			switch {
+			case full || percent >= volumeUsageCriticalPercent:
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
+					Message:  fmt.Sprintf("Volume is full: %d%% used (%s of %s) as reported by the kubelet on node %q.", percent, formatBytes(*s.UsedBytes), formatBytes(*s.CapacityBytes), s.Node),
+					Fix:      "Free up space or expand the claim by increasing spec.resources.requests.storage (requires a StorageClass with allowVolumeExpansion: true).",
+				})
+			case percent >= volumeUsageWarningPercent:
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityWarning,
+					Message:  fmt.Sprintf("Volume is %d%% used (%s of %s) as reported by the kubelet on node %q.", percent, formatBytes(*s.UsedBytes), formatBytes(*s.CapacityBytes), s.Node),
+					Fix:      "Plan to free up space or expand the claim before it fills up.",
+				})
+			}
+		}
+		if s.Inodes != nil && s.InodesFree != nil && *s.Inodes > 0 && *s.InodesFree == 0 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("Volume has no free inodes (%d in total) as reported by the kubelet on node %q.", *s.Inodes, s.Node),
+				Fix:      "Remove unused files; many small files can exhaust inodes before disk space.",
+			})
+		}
+	}
+	return issues
+}
+
+// latestEvent returns the most recent event with the given reason for the given object.
+// Events are expected to be sorted by time, most recent first.
+func latestEvent(events []v1.Event, kind, name, reason string) *v1.Event {
+	for i := range events {
+		if events[i].InvolvedObject.Kind == kind && events[i].InvolvedObject.Name == name && events[i].Reason == reason {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+func isReadWriteOnce(pvc *v1.PersistentVolumeClaim) bool {
+	for _, mode := range pvc.Spec.AccessModes {
+		if mode == v1.ReadWriteOnce || mode == v1.ReadWriteOncePod {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+func formatPVCSection(diag *pvcDiagnostics) string {
+	pvc := diag.pvc
+	summary := map[string]any{
+		"phase":       pvc.Status.Phase,
+		"accessModes": pvc.Spec.AccessModes,
+		"requests":    pvc.Spec.Resources.Requests,
+	}
+	if pvc.Spec.StorageClassName != nil {
+		summary["storageClassName"] = *pvc.Spec.StorageClassName
+	}
+	if pvc.Spec.VolumeName != "" {
+		summary["volumeName"] = pvc.Spec.VolumeName
+	}
+	if pvc.Spec.VolumeMode != nil {
+		summary["volumeMode"] = *pvc.Spec.VolumeMode
+	}
+	if len(pvc.Status.Capacity) > 0 {
+		summary["capacity"] = pvc.Status.Capacity
+	}
+	if len(pvc.Status.Conditions) > 0 {
+		summary["conditions"] = pvc.Status.Conditions
+	}
+	return formatYamlSection("PersistentVolumeClaim", summary)
+}
+
+func formatPVSection(diag *pvcDiagnostics) string {
+	if diag.pv == nil {
+		return "## PersistentVolume\n\n*No PersistentVolume bound to this claim*"
+	}
+	pv := diag.pv
+	summary := map[string]any{
+		"name":                          pv.Name,
+		"phase":                         pv.Status.Phase,
+		"capacity":                      pv.Spec.Capacity,
+		"accessModes":                   pv.Spec.AccessModes,
+		"persistentVolumeReclaimPolicy": pv.Spec.PersistentVolumeReclaimPolicy,
+		"storageClassName":              pv.Spec.StorageClassName,
+	}
+	if pv.Spec.CSI != nil {
+		summary["csi"] = map[string]any{"driver": pv.Spec.CSI.Driver, "volumeHandle": pv.Spec.CSI.VolumeHandle}
+	}
+	if pv.Spec.NodeAffinity != nil {
+		summary["nodeAffinity"] = pv.Spec.NodeAffinity
+	}
+	if pv.Status.Message != "" {
+		summary["message"] = pv.Status.Message
+	}
+	return formatYamlSection("PersistentVolume", summary)
+}
+
+func formatStorageClassSection(diag *pvcDiagnostics) string {
+	if diag.storageClass == nil {
+		if diag.storageClassName != "" {
+			return fmt.Sprintf("## StorageClass\n\n*StorageClass %q not found*", diag.storageClassName)
+		}
+		return "## StorageClass\n\n*No StorageClass requested and no default StorageClass found*"
+	}
+	sc := diag.storageClass
+	summary := map[string]any{
+		"name":                 sc.Name,
+		"provisioner":          sc.Provisioner,
+		"volumeBindingMode":    ptr.Deref(sc.VolumeBindingMode, storagev1.VolumeBindingImmediate),
+		"allowVolumeExpansion": ptr.Deref(sc.AllowVolumeExpansion, false),
+	}
+	if sc.ReclaimPolicy != nil {
+		summary["reclaimPolicy"] = *sc.ReclaimPolicy
+	}
+	if len(sc.Parameters) > 0 {
+		summary["parameters"] = sc.Parameters
+	}
+	if len(sc.AllowedTopologies) > 0 {
+		summary["allowedTopologies"] = sc.AllowedTopologies
+	}
+	return formatYamlSection("StorageClass", summary)
+}
+
+func formatVolumeAttachmentsSection(diag *pvcDiagnostics) string {
+	if len(diag.volumeAttachments) == 0 {
+		return "## VolumeAttachments\n\n*No VolumeAttachments found for this volume*"
+	}
+	var attachments []map[string]any
+	for _, va := range diag.volumeAttachments {
+		entry := map[string]any{
+			"name":     va.Name,
+			"nodeName": va.Spec.NodeName,
+			"attacher": va.Spec.Attacher,
+			"attached": va.Status.Attached,
+		}
+		if va.Status.AttachError != nil {
+			entry["attachError"] = va.Status.AttachError.Message
+		}
+		if va.Status.DetachError != nil {
+			entry["detachError"] = va.Status.DetachError.Message
+		}
+		attachments = append(attachments, entry)
+	}
+	return formatYamlSection("VolumeAttachments", attachments)
+}
+
+func formatPVCPodsSection(diag *pvcDiagnostics) string {
+	if len(diag.pods) == 0 {
+		return "## Consuming Pods\n\n*No pods reference this claim*"
+	}
+	var pods []map[string]any
+	for _, pod := range diag.pods {
+		pods = append(pods, map[string]any{
+			"name":     pod.Name,
+			"nodeName": pod.Spec.NodeName,
+			"phase":    pod.Status.Phase,
+		})
+	}
+	return formatYamlSection("Consuming Pods", pods)
+}
+
+func formatVolumeStatsSection(diag *pvcDiagnostics) string {
+	if len(diag.volumeStats) == 0 {
+		return "## Volume Usage\n\n*No volume usage reported by the kubelet (no running consuming pod or volume stats unavailable)*"
+	}
+	return formatYamlSection("Volume Usage", diag.volumeStats)
+}
+
+func formatPVCEventsSection(diag *pvcDiagnostics) string {
+	if len(diag.events) == 0 {
+		return "## Events\n\n*No events found related to this claim, its volume, or its pods*"
+	}
+	events := make([]map[string]any, 0, len(diag.events))
+	for i := range diag.events {
+		events = append(events, kubernetes.EventMap(&diag.events[i]))
+	}
+	return formatYamlSection("Events", events)
+}
+
+func formatYamlSection(title string, v any) string {
+	yamlStr, err := output.MarshalYaml(v)
+	if err != nil {
+		return fmt.Sprintf("## %s\n\n*Error marshaling %s: %v*", title, strings.ToLower(title), err)
+	}
+	return fmt.Sprintf("## %s\n\n```yaml\n%s```", title, yamlStr)
+}
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
+)
+
+type PVCsDiagnoseSuite struct {
+	suite.Suite
+}
+
+func TestPVCsDiagnoseSuite(t *testing.T) {
+	suite.Run(t, new(PVCsDiagnoseSuite))
+}
+
+func testPVC(phase v1.PersistentVolumeClaimPhase, storageClassName *string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "ns-1"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			StorageClassName: storageClassName,
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func testStorageClass(provisioner string, mode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: "standard"},
+		Provisioner:       provisioner,
+		VolumeBindingMode: ptr.To(mode),
+	}
+}
+
+func testPVCPod(name, node string, phase v1.PodPhase) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-1"},
+		Spec: v1.PodSpec{
+			NodeName: node,
+			Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+			}}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func testEvent(kind, name, reason, message string, age time.Duration) v1.Event {
+	return v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: kind, Name: name},
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func (s *PVCsDiagnoseSuite) TestCheckPVCBinding() {
+	s.Run("reports Pending claim without StorageClass and default StorageClass", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{pvc: testPVC(v1.ClaimPending, nil)})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "no default StorageClass")
+	})
+	s.Run("reports missing StorageClass", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{pvc: testPVC(v1.ClaimPending, ptr.To("fast")), storageClassName: "fast"})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, `StorageClass "fast" does not exist`)
+	})
+	s.Run("reports StorageClass without provisioner", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{
+			pvc:              testPVC(v1.ClaimPending, ptr.To("standard")),
+			storageClassName: "standard",
+			storageClass:     testStorageClass(noProvisioner, storagev1.VolumeBindingImmediate),
+		})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "has no dynamic provisioner")
+	})
+	s.Run("reports WaitForFirstConsumer without consuming pods", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{
+			pvc:              testPVC(v1.ClaimPending, ptr.To("standard")),
+			storageClassName: "standard",
+			storageClass:     testStorageClass("ebs.csi.aws.com", storagev1.VolumeBindingWaitForFirstConsumer),
+		})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
+		s.Contains(issues[0].Message, "no pod references this claim")
+	})
+	s.Run("reports WaitForFirstConsumer with unschedulable consuming pod", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{
+			pvc:              testPVC(v1.ClaimPending, ptr.To("standard")),
+			storageClassName: "standard",
+			storageClass:     testStorageClass("ebs.csi.aws.com", storagev1.VolumeBindingWaitForFirstConsumer),
+			pods:             []v1.Pod{testPVCPod("app-0", "", v1.PodPending)},
+			events:           []v1.Event{testEvent("Pod", "app-0", "FailedScheduling", "0/3 nodes are available: 3 Insufficient memory.", time.Minute)},
+		})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, `consuming pod "app-0" cannot be scheduled`)
+		s.Contains(issues[0].Message, "Insufficient memory")
+	})
+	s.Run("reports provisioning failures", func() {
+		issues := checkPVCBinding(&pvcDiagnostics{
+			pvc:              testPVC(v1.ClaimPending, ptr.To("standard")),
+			storageClassName: "standard",
+			storageClass:     testStorageClass("ebs.csi.aws.com", storagev1.VolumeBindingImmediate),
+			events:           []v1.Event{testEvent("PersistentVolumeClaim", "data", "ProvisioningFailed", "quota exceeded", time.Minute)},
+		})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "quota exceeded")
+	})
+	s.Run("reports Lost claim", func() {
+		pvc := testPVC(v1.ClaimLost, ptr.To("standard"))
+		pvc.Spec.VolumeName = "pv-1"
+		issues := checkPVCBinding(&pvcDiagnostics{pvc: pvc})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "Lost")
+	})
+	s.Run("returns nil for Bound claim", func() {
+		s.Nil(checkPVCBinding(&pvcDiagnostics{pvc: testPVC(v1.ClaimBound, ptr.To("standard"))}))
+	})
+}
+
+func (s *PVCsDiagnoseSuite) TestCheckPVCAttachment() {
+	s.Run("reports Multi-Attach errors", func() {
+		issues := checkPVCAttachment(&pvcDiagnostics{
+			pvc:  testPVC(v1.ClaimBound, nil),
+			pods: []v1.Pod{testPVCPod("app-1", "node-b", v1.PodPending)},
+			events: []v1.Event{testEvent("Pod", "app-1", "FailedAttachVolume",
+				`Multi-Attach error for volume "pv-1" Volume is already exclusively attached to one node and can't be attached to another`, time.Minute)},
+		})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "Multi-Attach error")
+		s.Contains(issues[0].Fix, "stale VolumeAttachment")
+	})
+	s.Run("reports ReadWriteOnce claim used from several nodes", func() {
+		issues := checkPVCAttachment(&pvcDiagnostics{
+			pvc:  testPVC(v1.ClaimBound, nil),
+			pods: []v1.Pod{testPVCPod("app-0", "node-a", v1.PodRunning), testPVCPod("app-1", "node-b", v1.PodPending)},
+		})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "2 different nodes (node-a, node-b)")
+	})
+	s.Run("reports VolumeAttachment errors", func() {
+		issues := checkPVCAttachment(&pvcDiagnostics{
+			pvc: testPVC(v1.ClaimBound, nil),
+			volumeAttachments: []storagev1.VolumeAttachment{{
+				ObjectMeta: metav1.ObjectMeta{Name: "csi-123"},
+				Spec:       storagev1.VolumeAttachmentSpec{Attacher: "ebs.csi.aws.com", NodeName: "node-a"},
+				Status:     storagev1.VolumeAttachmentStatus{AttachError: &storagev1.VolumeError{Message: "volume is in use"}},
+			}},
+		})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, `VolumeAttachment "csi-123" to node "node-a" failed: volume is in use`)
+	})
+}
+
+func (s *PVCsDiagnoseSuite) TestCheckPVCMounts() {
+	issues := checkPVCMounts(&pvcDiagnostics{
+		pvc:    testPVC(v1.ClaimBound, nil),
+		pods:   []v1.Pod{testPVCPod("app-0", "node-a", v1.PodPending)},
+		events: []v1.Event{testEvent("Pod", "app-0", "FailedMount", "MountVolume.SetUp failed", time.Minute)},
+	})
+	s.Require().Len(issues, 1)
+	s.Contains(issues[0].Message, `Pod "app-0" cannot mount the volume: MountVolume.SetUp failed`)
+}
+
+func (s *PVCsDiagnoseSuite) TestVolumeUsage() {
+	summary := `{"pods":[
+		{"podRef":{"name":"other","namespace":"ns-1"},"volume":[{"name":"tmp","capacityBytes":100,"usedBytes":1}]},
+		{"podRef":{"name":"app-0","namespace":"ns-1"},"volume":[
+			{"name":"data","pvcRef":{"name":"data","namespace":"ns-1"},"capacityBytes":1073741824,"usedBytes":1063004405,"availableBytes":10737419,"inodes":100,"inodesFree":50}
+		]}
+	]}`
+	stats := volumeStatsFromSummary(summary, "node-a", testPVC(v1.ClaimBound, nil))
+	s.Run("reads claim usage from kubelet stats summary", func() {
+		s.Require().NotNil(stats)
+		s.Equal("app-0", stats.Pod)
+		s.Equal(uint64(1073741824), *stats.CapacityBytes)
+	})
+	s.Run("ignores other claims", func() {
+		pvc := testPVC(v1.ClaimBound, nil)
+		pvc.Name = "other"
+		s.Nil(volumeStatsFromSummary(summary, "node-a", pvc))
+	})
+	s.Run("reports full volume", func() {
+		issues := checkPVCUsage(&pvcDiagnostics{volumeStats: []pvcVolumeStats{*stats}})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "Volume is full: 98% used (1013.8MiB of 1.0GiB)")
+	})
+	s.Run("reports exhausted inodes", func() {
+		issues := checkPVCUsage(&pvcDiagnostics{volumeStats: []pvcVolumeStats{{Node: "node-a", Inodes: ptr.To(uint64(100)), InodesFree: ptr.To(uint64(0))}}})
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "no free inodes")
+	})
+}
+
+func (s *PVCsDiagnoseSuite) TestAnalyzePVC() {
+	report := diagnostics.FormatIssues(analyzePVC(&pvcDiagnostics{pvc: testPVC(v1.ClaimPending, nil), errors: []string{"Error listing pods: forbidden"}}))
+	s.True(strings.HasPrefix(report, "## Detected Issues"))
+	s.Contains(report, "- **WARNING**: Error listing pods: forbidden")
+	s.Contains(report, "- **CRITICAL**: PersistentVolumeClaim is Pending")
+	s.Contains(report, "## Suggested Fixes")
+}
+
+func (s *PVCsDiagnoseSuite) TestFormatPVCEventsSection() {
+	event := testEvent("PersistentVolumeClaim", "data", "ProvisioningFailed", "storageclass not found", 0)
+	event.Namespace = "ns-1"
+	event.InvolvedObject.APIVersion = "v1"
+	event.FirstTimestamp = metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	section := formatPVCEventsSection(&pvcDiagnostics{events: []v1.Event{event}})
+	s.Contains(section, "Namespace: ns-1", "expected the events in the shape of events_list")
+	s.Contains(section, "Kind: PersistentVolumeClaim")
+	s.Contains(section, "Name: data")
+	s.Contains(section, "apiVersion: v1")
+	s.Contains(section, "Timestamp: 2024-01-01 00:00:00 +0000 UTC")
+}
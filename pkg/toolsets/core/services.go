@@ -0,0 +1,681 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
+)
+
+// maxReportedSelectorNearMisses limits the number of pods reported as "almost" matching
+// a Service selector when the selector matches no pods at all.
+const maxReportedSelectorNearMisses = 3
+
+func initServices() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "services_diagnose",
+			Description: "Diagnose why a Kubernetes Service has no (ready) endpoints or is not routing traffic. " +
+				"Compares the Service selector against pod labels, lists ready and not-ready EndpointSlice addresses, " +
+				"checks that each targetPort matches a container port by name or number, and surfaces failing readiness probes from pod status and events. " +
+				"Returns a 'Detected Issues' section with CRITICAL/WARNING/INFO findings and suggested fixes, followed by the raw diagnostic data. " +
+				"Use this tool FIRST when a user reports that a Service has no endpoints, returns connection refused, or is not reachable.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Service to diagnose (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Service to diagnose",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Services: Diagnose",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: servicesDiagnose},
+	}
+}
+
+// serviceDiagnostics holds the objects collected for a Service diagnosis.
+type serviceDiagnostics struct {
+	service *v1.Service
+	// selectedPods are the pods matched by the Service selector.
+	selectedPods []v1.Pod
+	// namespacePods are all the pods in the Service namespace, only collected when
+	// the selector matches no pods so that near-misses can be reported.
+	namespacePods  []v1.Pod
+	endpointSlices []discoveryv1.EndpointSlice
+	// events are the events of the Service and of its selected pods.
+	events []v1.Event
+	// eventsError is reported in the events section when the events of the namespace cannot be listed.
+	eventsError string
+	// probeFailures maps a pod name to the most recent readiness probe failure messages.
+	probeFailures map[string][]string
+	errors        []string
+}
+
+func servicesDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose service: %w", err)), nil
+	}
+
+	service, err := params.CoreV1().Services(namespace).Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)), nil
+	}
+
+	diag := collectServiceDiagnostics(params.Context, params.KubernetesClient, service)
+	issues := analyzeService(diag)
+
+	report := fmt.Sprintf(`# Service Diagnostic Report: %s/%s
+
+%s
+
+%s
+
+%s
+
+%s
+
+%s
+`, namespace, name, diagnostics.FormatIssues(issues), formatServiceSection(diag), formatEndpointSlicesSection(diag), formatSelectedPodsSection(diag), formatServiceEventsSection(diag))
+
+	return api.NewToolCallResult(report, nil), nil
+}
+
+func collectServiceDiagnostics(ctx context.Context, client api.KubernetesClient, service *v1.Service) *serviceDiagnostics {
+	diag := &serviceDiagnostics{service: service, probeFailures: map[string][]string{}}
+	namespace := service.Namespace
+
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name,
+	})
+	if err != nil {
+		diag.errors = append(diag.errors, fmt.Sprintf("Error listing EndpointSlices: %v", err))
+	} else {
+		diag.endpointSlices = slices.Items
+	}
+
+	if len(service.Spec.Selector) > 0 {
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		})
+		switch {
+		case err != nil:
+			diag.errors = append(diag.errors, fmt.Sprintf("Error listing pods matching the Service selector: %v", err))
+		case len(pods.Items) > 0:
+			diag.selectedPods = pods.Items
+		default:
+			allPods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				diag.errors = append(diag.errors, fmt.Sprintf("Error listing pods in namespace: %v", err))
+			} else {
+				diag.namespacePods = allPods.Items
+			}
+		}
+	}
+
+	// The events of the Service and of all its pods are read from a single list of the namespace events,
+	// indexed by the kind and name of their involved object
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diag.eventsError = fmt.Sprintf("Error listing events: %v", err)
+		return diag
+	}
+	eventsByObject := indexEventsByInvolvedObject(events.Items)
+	diag.events = append(diag.events, eventsByObject["Service/"+service.Name]...)
+	for _, pod := range diag.selectedPods {
+		podEvents := eventsByObject["Pod/"+pod.Name]
+		diag.events = append(diag.events, podEvents...)
+		if isPodReady(&pod) {
+			continue
+		}
+		var unhealthy []v1.Event
+		for _, event := range podEvents {
+			if event.Reason == "Unhealthy" {
+				unhealthy = append(unhealthy, event)
+			}
+		}
+		diag.probeFailures[pod.Name] = readinessProbeFailures(unhealthy)
+	}
+
+	return diag
+}
+
+// indexEventsByInvolvedObject groups the events by the "<kind>/<name>" of their involved object.
+func indexEventsByInvolvedObject(events []v1.Event) map[string][]v1.Event {
+	ret := make(map[string][]v1.Event)
+	for _, event := range events {
+		key := event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+		ret[key] = append(ret[key], event)
+	}
+	return ret
+}
+
+// readinessProbeFailures returns the distinct readiness probe failure messages, most recent first.
+func readinessProbeFailures(events []v1.Event) []string {
+	sort.Slice(events, func(i, j int) bool {
+		return kubernetes.EventTimestamp(&events[i]).After(kubernetes.EventTimestamp(&events[j]))
+	})
+	var messages []string
+	seen := map[string]bool{}
+	for _, event := range events {
+		message := strings.TrimSpace(event.Message)
+		if !strings.HasPrefix(message, "Readiness probe") || seen[message] {
+			continue
+		}
+		seen[message] = true
+		messages = append(messages, message)
+		if len(messages) == 3 {
+			break
+		}
+	}
+	return messages
+}
+
+func analyzeService(diag *serviceDiagnostics) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, e := range diag.errors {
+		issues = append(issues, diagnostics.Issue{Severity: diagnostics.SeverityWarning, Message: e})
+	}
+
+	if diag.service.Spec.Type == v1.ServiceTypeExternalName {
+		return append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityInfo,
+			Message:  fmt.Sprintf("Service is of type ExternalName and resolves to %q through DNS. It has no selector nor endpoints, so endpoint checks were skipped.", diag.service.Spec.ExternalName),
+		})
+	}
+
+	issues = append(issues, checkServiceSelector(diag.service, diag.selectedPods, diag.namespacePods)...)
+	issues = append(issues, checkServiceTargetPorts(diag.service, diag.selectedPods)...)
+	issues = append(issues, checkServicePodReadiness(diag.selectedPods, diag.probeFailures)...)
+	issues = append(issues, checkServiceEndpointSlices(diag.service, diag.endpointSlices)...)
+	return issues
+}
+
+func checkServiceSelector(service *v1.Service, selectedPods, namespacePods []v1.Pod) []diagnostics.Issue {
+	if len(service.Spec.Selector) == 0 {
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityInfo,
+			Message:  "Service has no selector. Its EndpointSlices are not managed by Kubernetes and must be created manually or by another controller, so selector checks were skipped.",
+		}}
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector).String()
+	if len(selectedPods) == 0 {
+		issues := []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("Service selector %q matches no pods in namespace %q.", selector, service.Namespace),
+			Fix:      fmt.Sprintf("Update the Service selector %q or the labels of the pod template of the backing workload so that they match.", selector),
+		}}
+		for _, nearMiss := range selectorNearMisses(service.Spec.Selector, namespacePods) {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityInfo,
+				Message:  nearMiss,
+			})
+		}
+		return issues
+	}
+
+	active := 0
+	for _, pod := range selectedPods {
+		if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			active++
+		}
+	}
+	if active == 0 {
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  fmt.Sprintf("All %d pods matching the Service selector %q have terminated (Succeeded or Failed).", len(selectedPods), selector),
+			Fix:      "Check why the backing workload pods exited and restart or fix the workload.",
+		}}
+	}
+	return nil
+}
+
+// selectorNearMisses describes the pods that match part of the selector, listing the labels that differ.
+func selectorNearMisses(selector map[string]string, pods []v1.Pod) []string {
+	type nearMiss struct {
+		pod        string
+		matched    int
+		mismatches []string
+	}
+	var candidates []nearMiss
+	for _, pod := range pods {
+		candidate := nearMiss{pod: pod.Name}
+		for key, expected := range selector {
+			actual, ok := pod.Labels[key]
+			switch {
+			case !ok:
+				candidate.mismatches = append(candidate.mismatches, fmt.Sprintf("label %q is missing (expected %q)", key, expected))
+			case actual != expected:
+				candidate.mismatches = append(candidate.mismatches, fmt.Sprintf("label %q is %q (expected %q)", key, actual, expected))
+			default:
+				candidate.matched++
+			}
+		}
+		if candidate.matched > 0 {
+			sort.Strings(candidate.mismatches)
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].matched != candidates[j].matched {
+			return candidates[i].matched > candidates[j].matched
+		}
+		return candidates[i].pod < candidates[j].pod
+	})
+
+	var result []string
+	for i, candidate := range candidates {
+		if i == maxReportedSelectorNearMisses {
+			result = append(result, fmt.Sprintf("%d additional pods partially match the selector.", len(candidates)-maxReportedSelectorNearMisses))
+			break
+		}
+		result = append(result, fmt.Sprintf("Pod %q partially matches the selector: %s.", candidate.pod, strings.Join(candidate.mismatches, ", ")))
+	}
+	return result
+}
+
+func checkServiceTargetPorts(service *v1.Service, selectedPods []v1.Pod) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, servicePort := range service.Spec.Ports {
+		targetPort := servicePort.TargetPort
+		if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+			targetPort = intstr.FromInt32(servicePort.Port)
+		}
+		portLabel := servicePortLabel(servicePort)
+
+		var missing, protocolMismatch []string
+		for _, pod := range selectedPods {
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			containerPort := findContainerPort(&pod, targetPort)
+			switch {
+			case containerPort == nil:
+				missing = append(missing, pod.Name)
+			case protocolOrDefault(containerPort.Protocol) != protocolOrDefault(servicePort.Protocol):
+				protocolMismatch = append(protocolMismatch, pod.Name)
+			}
+		}
+
+		if len(missing) > 0 && targetPort.Type == intstr.String {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
+				Message:  fmt.Sprintf("Service port %s targets the named port %q, but pods %s do not declare a container port with that name. These pods are published without a port for it.", portLabel, targetPort.StrVal, quoteAll(missing)),
+				Fix:      fmt.Sprintf("Name the container port %q in the pod template, or change the targetPort of Service port %s to the container port number.", targetPort.StrVal, portLabel),
+			})
+		} else if len(missing) > 0 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("Service port %s targets port %d, but pods %s do not declare it as a containerPort. Traffic still reaches the pods if a process listens on %d; verify that the application really listens on this port.", portLabel, targetPort.IntVal, quoteAll(missing), targetPort.IntVal),
+				Fix:      fmt.Sprintf("Check the port the application listens on and align the targetPort of Service port %s with it.", portLabel),
+			})
+		}
+		if len(protocolMismatch) > 0 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("Service port %s uses protocol %s, but the matching container port on pods %s uses a different protocol.", portLabel, protocolOrDefault(servicePort.Protocol), quoteAll(protocolMismatch)),
+				Fix:      fmt.Sprintf("Align the protocol of Service port %s with the container port protocol.", portLabel),
+			})
+		}
+	}
+	return issues
+}
+
+// findContainerPort looks up the container port targeted by a Service targetPort,
+// including restartable init containers (sidecars), which can also serve traffic.
+func findContainerPort(pod *v1.Pod, targetPort intstr.IntOrString) *v1.ContainerPort {
+	containers := append([]v1.Container{}, pod.Spec.Containers...)
+	for _, c := range pod.Spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			containers = append(containers, c)
+		}
+	}
+	for _, c := range containers {
+		for i := range c.Ports {
+			port := &c.Ports[i]
+			if targetPort.Type == intstr.String && port.Name == targetPort.StrVal {
+				return port
+			}
+			if targetPort.Type == intstr.Int && port.ContainerPort == targetPort.IntVal {
+				return port
+			}
+		}
+	}
+	return nil
+}
+
+func checkServicePodReadiness(selectedPods []v1.Pod, probeFailures map[string][]string) []diagnostics.Issue {
+	var issues []diagnostics.Issue
+	for _, pod := range selectedPods {
+		if pod.DeletionTimestamp != nil {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityInfo,
+				Message:  fmt.Sprintf("Pod %q is terminating and is being removed from the Service endpoints.", pod.Name),
+			})
+			continue
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if pod.Status.Phase == v1.PodPending && len(pod.Status.ContainerStatuses) == 0 {
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
+				Message:  fmt.Sprintf("Pod %q is Pending and has no running containers, so it cannot receive traffic.", pod.Name),
+				Fix:      fmt.Sprintf("Check the events of pod %q for scheduling or image pull problems.", pod.Name),
+			})
+			continue
+		}
+
+		readinessProbes := map[string]*v1.Probe{}
+		for _, c := range pod.Spec.Containers {
+			readinessProbes[c.Name] = c.ReadinessProbe
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				continue
+			}
+			switch {
+			case cs.State.Waiting != nil:
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
+					Message:  strings.TrimSpace(fmt.Sprintf("Container %q in pod %q is waiting (%s): %s", cs.Name, pod.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message)),
+					Fix:      fmt.Sprintf("Inspect the logs and events of pod %q to find out why container %q is not running.", pod.Name, cs.Name),
+				})
+			case cs.State.Terminated != nil:
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
+					Message:  fmt.Sprintf("Container %q in pod %q terminated with reason %s (exit code %d).", cs.Name, pod.Name, cs.State.Terminated.Reason, cs.State.Terminated.ExitCode),
+					Fix:      fmt.Sprintf("Inspect the logs of container %q in pod %q.", cs.Name, pod.Name),
+				})
+			case readinessProbes[cs.Name] != nil:
+				msg := fmt.Sprintf("Readiness probe of container %q in pod %q is failing, so the pod is published as a not-ready endpoint.", cs.Name, pod.Name)
+				if failures := probeFailures[pod.Name]; len(failures) > 0 {
+					msg += " Latest failure: " + failures[0]
+				}
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
+					Message:  msg,
+					Fix:      fmt.Sprintf("Verify that the readiness probe of container %q (%s) matches the application health endpoint and port, or fix the application so that it becomes ready.", cs.Name, describeProbe(readinessProbes[cs.Name])),
+				})
+			default:
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityWarning,
+					Message:  fmt.Sprintf("Container %q in pod %q is running but not ready (it may still be starting up).", cs.Name, pod.Name),
+				})
+			}
+		}
+
+		for _, gate := range pod.Spec.ReadinessGates {
+			if podConditionStatus(&pod, gate.ConditionType) != v1.ConditionTrue {
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityWarning,
+					Message:  fmt.Sprintf("Readiness gate %q of pod %q is not satisfied.", gate.ConditionType, pod.Name),
+					Fix:      fmt.Sprintf("Check the controller responsible for setting the %q pod condition.", gate.ConditionType),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func checkServiceEndpointSlices(service *v1.Service, slices []discoveryv1.EndpointSlice) []diagnostics.Issue {
+	if len(service.Spec.Selector) == 0 && len(slices) == 0 {
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityWarning,
+			Message:  "Service has no selector and no EndpointSlices, so it has no endpoints to route traffic to.",
+			Fix:      fmt.Sprintf("Create an EndpointSlice labeled %s=%s with the backend addresses, or add a selector to the Service.", discoveryv1.LabelServiceName, service.Name),
+		}}
+	}
+	if len(slices) == 0 {
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  "No EndpointSlices exist for this Service. The Service has no endpoints.",
+		}}
+	}
+
+	ready, notReady := 0, 0
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if isEndpointReady(endpoint) {
+				ready++
+			} else {
+				notReady++
+			}
+		}
+	}
+
+	switch {
+	case ready == 0 && notReady == 0:
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  "The EndpointSlices of this Service contain no addresses. The Service has no endpoints.",
+		}}
+	case ready == 0:
+		msg := fmt.Sprintf("Service has no ready endpoints: all %d EndpointSlice addresses are not ready.", notReady)
+		if service.Spec.PublishNotReadyAddresses {
+			msg += " publishNotReadyAddresses is enabled, so DNS still returns them."
+		}
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
+			Message:  msg,
+			Fix:      "Fix the readiness of the backing pods (see the readiness findings above).",
+		}}
+	case notReady > 0:
+		return []diagnostics.Issue{{
+			Severity: diagnostics.SeverityWarning,
+			Message:  fmt.Sprintf("Service has %d ready and %d not-ready endpoints.", ready, notReady),
+		}}
+	}
+	return nil
+}
+
+// isEndpointReady follows the EndpointSlice API convention that a nil ready condition means ready.
+func isEndpointReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	return podConditionStatus(pod, v1.PodReady) == v1.ConditionTrue
+}
+
+func podConditionStatus(pod *v1.Pod, conditionType v1.PodConditionType) v1.ConditionStatus {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return v1.ConditionUnknown
+}
+
+func describeProbe(probe *v1.Probe) string {
+	switch {
+	case probe.HTTPGet != nil:
+		return fmt.Sprintf("httpGet %s on port %s", probe.HTTPGet.Path, probe.HTTPGet.Port.String())
+	case probe.TCPSocket != nil:
+		return fmt.Sprintf("tcpSocket on port %s", probe.TCPSocket.Port.String())
+	case probe.GRPC != nil:
+		return fmt.Sprintf("grpc on port %d", probe.GRPC.Port)
+	case probe.Exec != nil:
+		return fmt.Sprintf("exec %q", strings.Join(probe.Exec.Command, " "))
+	default:
+		return "unknown handler"
+	}
+}
+
+func servicePortLabel(port v1.ServicePort) string {
+	if port.Name != "" {
+		return fmt.Sprintf("%q (%d)", port.Name, port.Port)
+	}
+	return fmt.Sprintf("%d", port.Port)
+}
+
+func protocolOrDefault(protocol v1.Protocol) v1.Protocol {
+	if protocol == "" {
+		return v1.ProtocolTCP
+	}
+	return protocol
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func formatServiceSection(diag *serviceDiagnostics) string {
+	service := diag.service
+	summary := map[string]any{
+		"type":  service.Spec.Type,
+		"ports": service.Spec.Ports,
+	}
+	if len(service.Spec.Selector) > 0 {
+		summary["selector"] = service.Spec.Selector
+	}
+	if service.Spec.ClusterIP != "" {
+		summary["clusterIP"] = service.Spec.ClusterIP
+	}
+	if service.Spec.ExternalName != "" {
+		summary["externalName"] = service.Spec.ExternalName
+	}
+	if service.Spec.PublishNotReadyAddresses {
+		summary["publishNotReadyAddresses"] = true
+	}
+	yamlStr, err := output.MarshalYaml(summary)
+	if err != nil {
+		return fmt.Sprintf("## Service\n\n*Error marshaling service: %v*", err)
+	}
+	return fmt.Sprintf("## Service\n\n```yaml\n%s```", yamlStr)
+}
+
+func formatEndpointSlicesSection(diag *serviceDiagnostics) string {
+	if len(diag.endpointSlices) == 0 {
+		return "## EndpointSlices\n\n*No EndpointSlices found for this Service*"
+	}
+	var ready, notReady []map[string]any
+	for _, slice := range diag.endpointSlices {
+		for _, endpoint := range slice.Endpoints {
+			entry := map[string]any{
+				"slice":     slice.Name,
+				"addresses": endpoint.Addresses,
+			}
+			if endpoint.TargetRef != nil {
+				entry["targetRef"] = endpoint.TargetRef.Kind + "/" + endpoint.TargetRef.Name
+			}
+			if endpoint.NodeName != nil {
+				entry["nodeName"] = *endpoint.NodeName
+			}
+			if endpoint.Conditions.Serving != nil {
+				entry["serving"] = *endpoint.Conditions.Serving
+			}
+			if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+				entry["terminating"] = true
+			}
+			if isEndpointReady(endpoint) {
+				ready = append(ready, entry)
+			} else {
+				notReady = append(notReady, entry)
+			}
+		}
+	}
+	yamlStr, err := output.MarshalYaml(map[string]any{
+		"ready":    ready,
+		"notReady": notReady,
+	})
+	if err != nil {
+		return fmt.Sprintf("## EndpointSlices\n\n*Error marshaling EndpointSlices: %v*", err)
+	}
+	return fmt.Sprintf("## EndpointSlices (%d ready, %d not ready)\n\n```yaml\n%s```", len(ready), len(notReady), yamlStr)
+}
+
+func formatSelectedPodsSection(diag *serviceDiagnostics) string {
+	if len(diag.service.Spec.Selector) == 0 {
+		return "## Selected Pods\n\n*Service has no selector*"
+	}
+	if len(diag.selectedPods) == 0 {
+		return "## Selected Pods\n\n*No pods match the Service selector*"
+	}
+	var pods []map[string]any
+	for _, pod := range diag.selectedPods {
+		var ports []string
+		for _, c := range pod.Spec.Containers {
+			for _, port := range c.Ports {
+				ports = append(ports, fmt.Sprintf("%s:%s/%d/%s", c.Name, port.Name, port.ContainerPort, protocolOrDefault(port.Protocol)))
+			}
+		}
+		entry := map[string]any{
+			"name":  pod.Name,
+			"phase": pod.Status.Phase,
+			"ready": isPodReady(&pod),
+		}
+		if pod.Status.PodIP != "" {
+			entry["podIP"] = pod.Status.PodIP
+		}
+		if len(ports) > 0 {
+			entry["containerPorts"] = ports
+		}
+		if failures := diag.probeFailures[pod.Name]; len(failures) > 0 {
+			entry["readinessProbeFailures"] = failures
+		}
+		pods = append(pods, entry)
+	}
+	yamlStr, err := output.MarshalYaml(pods)
+	if err != nil {
+		return fmt.Sprintf("## Selected Pods\n\n*Error marshaling pods: %v*", err)
+	}
+	return fmt.Sprintf("## Selected Pods\n\n```yaml\n%s```", yamlStr)
+}
+
+func formatServiceEventsSection(diag *serviceDiagnostics) string {
+	if len(diag.events) == 0 && diag.eventsError == "" {
+		return "## Events\n\n*No events found related to this Service or its pods*"
+	}
+
+	var result strings.Builder
+	result.WriteString("## Events")
+	if diag.eventsError != "" {
+		fmt.Fprintf(&result, "\n\n*%s*", diag.eventsError)
+	}
+	if len(diag.events) > 0 {
+		relatedEvents := make([]map[string]any, 0, len(diag.events))
+		for i := range diag.events {
+			relatedEvents = append(relatedEvents, kubernetes.EventMap(&diag.events[i]))
+		}
+		yamlStr, err := output.MarshalYaml(relatedEvents)
+		if err != nil {
+			fmt.Fprintf(&result, "\n\n*Error marshaling events: %v*", err)
+		} else {
+			fmt.Fprintf(&result, "\n\n```yaml\n%s```", yamlStr)
+		}
+	}
+	return result.String()
+}
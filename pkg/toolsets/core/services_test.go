@@ -0,0 +1,260 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
+)
+
+type ServicesDiagnoseSuite struct {
+	suite.Suite
+}
+
+func TestServicesDiagnoseSuite(t *testing.T) {
+	suite.Run(t, new(ServicesDiagnoseSuite))
+}
+
+func testService(selector map[string]string, ports ...v1.ServicePort) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+		Spec: v1.ServiceSpec{
+			Type:     v1.ServiceTypeClusterIP,
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+func testPod(name string, podLabels map[string]string, ready bool, ports ...v1.ContainerPort) v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-1", Labels: podLabels},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app", Ports: ports}},
+		},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:  "app",
+				Ready: ready,
+				State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			}},
+		},
+	}
+}
+
+func (s *ServicesDiagnoseSuite) TestCheckServiceSelector() {
+	s.Run("reports selector matching no pods with near misses", func() {
+		service := testService(map[string]string{"app": "web", "tier": "frontend"})
+		namespacePods := []v1.Pod{
+			testPod("web-1", map[string]string{"app": "web", "tier": "front-end"}, true),
+			testPod("db-1", map[string]string{"app": "db"}, true),
+		}
+		issues := checkServiceSelector(service, nil, namespacePods)
+		s.Require().Len(issues, 2)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "app=web,tier=frontend")
+		s.NotEmpty(issues[0].Fix)
+		s.Equal(diagnostics.SeverityInfo, issues[1].Severity)
+		s.Contains(issues[1].Message, `Pod "web-1"`)
+		s.Contains(issues[1].Message, `label "tier" is "front-end" (expected "frontend")`)
+	})
+	s.Run("limits the reported near misses", func() {
+		service := testService(map[string]string{"app": "web", "tier": "frontend"})
+		var namespacePods []v1.Pod
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			namespacePods = append(namespacePods, testPod(name, map[string]string{"app": "web"}, true))
+		}
+		issues := checkServiceSelector(service, nil, namespacePods)
+		s.Require().Len(issues, 1+maxReportedSelectorNearMisses+1)
+		s.Contains(issues[len(issues)-1].Message, "2 additional pods")
+	})
+	s.Run("reports selector-less service as info", func() {
+		issues := checkServiceSelector(testService(nil), nil, nil)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityInfo, issues[0].Severity)
+	})
+	s.Run("reports when all selected pods have terminated", func() {
+		pod := testPod("job-1", map[string]string{"app": "web"}, false)
+		pod.Status.Phase = v1.PodSucceeded
+		issues := checkServiceSelector(testService(map[string]string{"app": "web"}), []v1.Pod{pod}, nil)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "terminated")
+	})
+	s.Run("returns nil when selector matches running pods", func() {
+		pods := []v1.Pod{testPod("web-1", map[string]string{"app": "web"}, true)}
+		s.Nil(checkServiceSelector(testService(map[string]string{"app": "web"}), pods, nil))
+	})
+}
+
+func (s *ServicesDiagnoseSuite) TestCheckServiceTargetPorts() {
+	s.Run("detects missing named target port", func() {
+		service := testService(map[string]string{"app": "web"}, v1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromString("http")})
+		pods := []v1.Pod{testPod("web-1", map[string]string{"app": "web"}, true, v1.ContainerPort{Name: "web", ContainerPort: 8080})}
+		issues := checkServiceTargetPorts(service, pods)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, `named port "http"`)
+		s.Contains(issues[0].Message, `"web-1"`)
+	})
+	s.Run("warns about undeclared numeric target port", func() {
+		service := testService(map[string]string{"app": "web"}, v1.ServicePort{Port: 80, TargetPort: intstr.FromInt32(9090)})
+		pods := []v1.Pod{testPod("web-1", map[string]string{"app": "web"}, true, v1.ContainerPort{ContainerPort: 8080})}
+		issues := checkServiceTargetPorts(service, pods)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
+		s.Contains(issues[0].Message, "9090")
+	})
+	s.Run("defaults target port to service port", func() {
+		service := testService(map[string]string{"app": "web"}, v1.ServicePort{Port: 8080})
+		pods := []v1.Pod{testPod("web-1", map[string]string{"app": "web"}, true, v1.ContainerPort{ContainerPort: 8080})}
+		s.Empty(checkServiceTargetPorts(service, pods))
+	})
+	s.Run("matches ports declared by sidecar containers", func() {
+		service := testService(map[string]string{"app": "web"}, v1.ServicePort{Name: "metrics", Port: 9090, TargetPort: intstr.FromString("metrics")})
+		pod := testPod("web-1", map[string]string{"app": "web"}, true)
+		pod.Spec.InitContainers = []v1.Container{{
+			Name:          "proxy",
+			RestartPolicy: ptr.To(v1.ContainerRestartPolicyAlways),
+			Ports:         []v1.ContainerPort{{Name: "metrics", ContainerPort: 9090}},
+		}}
+		s.Empty(checkServiceTargetPorts(service, []v1.Pod{pod}))
+	})
+	s.Run("detects protocol mismatch", func() {
+		service := testService(map[string]string{"app": "web"}, v1.ServicePort{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP, TargetPort: intstr.FromString("dns")})
+		pods := []v1.Pod{testPod("dns-1", map[string]string{"app": "web"}, true, v1.ContainerPort{Name: "dns", ContainerPort: 53})}
+		issues := checkServiceTargetPorts(service, pods)
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "protocol UDP")
+	})
+}
+
+func (s *ServicesDiagnoseSuite) TestCheckServicePodReadiness() {
+	s.Run("surfaces failing readiness probe with latest failure", func() {
+		pod := testPod("web-1", map[string]string{"app": "web"}, false)
+		pod.Spec.Containers[0].ReadinessProbe = &v1.Probe{ProbeHandler: v1.ProbeHandler{
+			HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)},
+		}}
+		issues := checkServicePodReadiness([]v1.Pod{pod}, map[string][]string{
+			"web-1": {"Readiness probe failed: HTTP probe failed with statuscode: 503"},
+		})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "Readiness probe of container \"app\"")
+		s.Contains(issues[0].Message, "statuscode: 503")
+		s.Contains(issues[0].Fix, "httpGet /healthz on port 8080")
+	})
+	s.Run("reports waiting containers", func() {
+		pod := testPod("web-1", map[string]string{"app": "web"}, false)
+		pod.Status.ContainerStatuses[0].State = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s"}}
+		issues := checkServicePodReadiness([]v1.Pod{pod}, nil)
+		s.Require().Len(issues, 1)
+		s.Contains(issues[0].Message, "CrashLoopBackOff")
+	})
+	s.Run("reports unsatisfied readiness gates", func() {
+		pod := testPod("web-1", map[string]string{"app": "web"}, true)
+		pod.Spec.ReadinessGates = []v1.PodReadinessGate{{ConditionType: "target-health.elbv2.k8s.aws/web"}}
+		issues := checkServicePodReadiness([]v1.Pod{pod}, nil)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
+		s.Contains(issues[0].Message, "target-health.elbv2.k8s.aws/web")
+	})
+	s.Run("returns nil for ready pods", func() {
+		s.Nil(checkServicePodReadiness([]v1.Pod{testPod("web-1", nil, true)}, nil))
+	})
+}
+
+func (s *ServicesDiagnoseSuite) TestCheckServiceEndpointSlices() {
+	service := testService(map[string]string{"app": "web"})
+	slice := func(ready ...*bool) discoveryv1.EndpointSlice {
+		es := discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web-abc"}}
+		for _, r := range ready {
+			es.Endpoints = append(es.Endpoints, discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: r}})
+		}
+		return es
+	}
+	s.Run("reports missing EndpointSlices", func() {
+		issues := checkServiceEndpointSlices(service, nil)
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+	})
+	s.Run("reports no ready endpoints", func() {
+		issues := checkServiceEndpointSlices(service, []discoveryv1.EndpointSlice{slice(ptr.To(false), ptr.To(false))})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
+		s.Contains(issues[0].Message, "all 2 EndpointSlice addresses are not ready")
+	})
+	s.Run("reports partially ready endpoints", func() {
+		issues := checkServiceEndpointSlices(service, []discoveryv1.EndpointSlice{slice(ptr.To(true), ptr.To(false))})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
+	})
+	s.Run("treats nil ready condition as ready", func() {
+		s.Nil(checkServiceEndpointSlices(service, []discoveryv1.EndpointSlice{slice(nil)}))
+	})
+}
+
+func (s *ServicesDiagnoseSuite) TestIndexEventsByInvolvedObject() {
+	event := func(kind, name, reason string) v1.Event {
+		return v1.Event{InvolvedObject: v1.ObjectReference{Kind: kind, Name: name}, Reason: reason}
+	}
+	events := indexEventsByInvolvedObject([]v1.Event{
+		event("Service", "web", "UpdatedLoadBalancer"),
+		event("Pod", "web-1", "Unhealthy"),
+		event("Deployment", "web", "ScalingReplicaSet"),
+		event("Pod", "web-1", "Pulled"),
+	})
+	s.Equal([]v1.Event{event("Service", "web", "UpdatedLoadBalancer")}, events["Service/web"],
+		"expected the events of other kinds of objects with the same name to be left out")
+	s.Equal([]v1.Event{event("Pod", "web-1", "Unhealthy"), event("Pod", "web-1", "Pulled")}, events["Pod/web-1"])
+	s.Empty(events["Pod/web-2"])
+}
+
+func (s *ServicesDiagnoseSuite) TestReadinessProbeFailures() {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+	failures := readinessProbeFailures([]v1.Event{
+		{Message: "Readiness probe failed: first", FirstTimestamp: at(1)},
+		{Message: "Readiness probe failed: repeated", FirstTimestamp: at(0), LastTimestamp: at(5), Count: 3},
+		{Message: "Readiness probe failed: series", EventTime: metav1.NewMicroTime(at(3).Time), Series: &v1.EventSeries{LastObservedTime: metav1.NewMicroTime(at(9).Time)}},
+		{Message: "Liveness probe failed: ignored", FirstTimestamp: at(8)},
+	})
+	s.Equal([]string{
+		"Readiness probe failed: repeated",
+		"Readiness probe failed: series",
+		"Readiness probe failed: first",
+	}, failures, "expected the failures ranked by the timestamp reported in the events section")
+}
+
+func (s *ServicesDiagnoseSuite) TestAnalyzeService() {
+	s.Run("skips endpoint checks for ExternalName services", func() {
+		service := testService(nil)
+		service.Spec.Type = v1.ServiceTypeExternalName
+		service.Spec.ExternalName = "db.example.com"
+		issues := analyzeService(&serviceDiagnostics{service: service})
+		s.Require().Len(issues, 1)
+		s.Equal(diagnostics.SeverityInfo, issues[0].Severity)
+		s.Contains(issues[0].Message, "db.example.com")
+	})
+	s.Run("formats detected issues and fixes", func() {
+		report := diagnostics.FormatIssues(analyzeService(&serviceDiagnostics{service: testService(map[string]string{"app": "web"})}))
+		s.True(strings.HasPrefix(report, "## Detected Issues"))
+		s.Contains(report, "- **CRITICAL**: Service selector \"app=web\" matches no pods")
+		s.Contains(report, "## Suggested Fixes")
+	})
+}
@@ -27,17 +27,22 @@ func (t *Toolset) GetDescription() string {
 
 func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
 	return slices.Concat(
+		initAutoscaling(),
+		initDeprecatedAPIs(),
 		initEvents(),
 		initNamespaces(p),
 		initNodes(),
 		initPods(),
+		initPVCs(),
 		initResources(p),
+		initServices(),
 	)
 }
 
 func (t *Toolset) GetPrompts() []api.ServerPrompt {
 	return slices.Concat(
 		initHealthChecks(),
+		initPVCTroubleshoot(),
 	)
 }
 
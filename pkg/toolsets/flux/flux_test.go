@@ -0,0 +1,252 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type FluxSuite struct {
+	suite.Suite
+}
+
+func TestFlux(t *testing.T) {
+	suite.Run(t, new(FluxSuite))
+}
+
+// objects returns a getter over the provided objects, keyed by kind, namespace and name.
+func objects(items ...map[string]any) getter {
+	byKey := map[string]*unstructured.Unstructured{}
+	for _, item := range items {
+		u := &unstructured.Unstructured{Object: item}
+		byKey[u.GetKind()+"/"+u.GetNamespace()+"/"+u.GetName()] = u
+	}
+	return func(_ context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+		if u, ok := byKey[gvk.Kind+"/"+namespace+"/"+name]; ok {
+			return u, nil
+		}
+		return nil, fmt.Errorf("%s %s/%s not found", gvk.Kind, namespace, name)
+	}
+}
+
+func (s *FluxSuite) decode(item map[string]any) *fluxObject {
+	obj, err := decodeFluxObject(&unstructured.Unstructured{Object: item})
+	s.Require().NoError(err)
+	return obj
+}
+
+func gitRepository() map[string]any {
+	return map[string]any{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "GitRepository",
+		"metadata":   map[string]any{"name": "flux-system", "namespace": "flux-system"},
+		"spec": map[string]any{
+			"url": "https://github.com/example/fleet",
+			"ref": map[string]any{"branch": "main"},
+		},
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True", "reason": "Succeeded", "message": "stored artifact",
+				"lastTransitionTime": "2026-01-02T03:04:05Z"}},
+			"artifact": map[string]any{"revision": "main@sha1:0123456789abcdef"},
+		},
+	}
+}
+
+func kustomization() map[string]any {
+	return map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": "apps", "namespace": "flux-system"},
+		"spec": map[string]any{
+			"path":      "./apps",
+			"sourceRef": map[string]any{"kind": "GitRepository", "name": "flux-system"},
+		},
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Ready", "status": "False", "reason": "BuildFailed", "message": "kustomize build failed",
+				"lastTransitionTime": "2026-01-02T03:04:05Z"}},
+			"lastAppliedRevision":   "main@sha1:fedcba9876543210",
+			"lastAttemptedRevision": "main@sha1:0123456789abcdef",
+		},
+	}
+}
+
+func helmRelease() map[string]any {
+	return map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata": map[string]any{"name": "podinfo", "namespace": "apps", "labels": map[string]any{
+			"kustomize.toolkit.fluxcd.io/name":      "apps",
+			"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+		}},
+		"spec": map[string]any{
+			"chart": map[string]any{"spec": map[string]any{
+				"chart":     "podinfo",
+				"version":   "6.x",
+				"sourceRef": map[string]any{"kind": "HelmRepository", "name": "podinfo"},
+			}},
+		},
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True", "reason": "UpgradeSucceeded", "message": "upgraded",
+				"lastTransitionTime": "2026-01-02T03:04:05Z"}},
+			"lastAttemptedRevision": "6.5.4",
+			"history":               []any{map[string]any{"chartName": "podinfo", "chartVersion": "6.5.4"}},
+		},
+	}
+}
+
+func (s *FluxSuite) TestSummarizeFluxObject() {
+	s.Run("summarizes sources", func() {
+		summary := summarizeFluxObject("GitRepository", s.decode(gitRepository()))
+		s.Equal("True", summary.Ready)
+		s.Equal("https://github.com/example/fleet branch=main", summary.Source)
+		s.Equal("main@sha1:0123456789abcdef", summary.Revision)
+		s.Empty(summary.LastAttemptedRevision)
+	})
+	s.Run("summarizes failing Kustomizations", func() {
+		summary := summarizeFluxObject("Kustomization", s.decode(kustomization()))
+		s.Equal("False (BuildFailed: kustomize build failed)", summary.Ready)
+		s.Equal("GitRepository flux-system/flux-system", summary.Source)
+		s.Equal("main@sha1:fedcba9876543210", summary.Revision)
+		s.Equal("main@sha1:0123456789abcdef", summary.LastAttemptedRevision)
+	})
+	s.Run("summarizes HelmReleases", func() {
+		summary := summarizeFluxObject("HelmRelease", s.decode(helmRelease()))
+		s.Equal("HelmRepository apps/podinfo", summary.Source)
+		s.Equal("podinfo@6.5.4", summary.Revision)
+		s.Empty(summary.LastAttemptedRevision)
+	})
+	s.Run("reports a missing Ready condition", func() {
+		summary := summarizeFluxObject("GitRepository", &fluxObject{})
+		s.Equal("Unknown (not reported)", summary.Ready)
+	})
+}
+
+// servedKinds is a FilteringProvider serving the provided kinds.
+type servedKinds []schema.GroupVersionKind
+
+func (k servedKinds) IsTargetCompatibilityToolFiltersEnabled() bool { return true }
+
+func (k servedKinds) AnyTargetHasGVKs(_ context.Context, gvks []schema.GroupVersionKind) bool {
+	for _, gvk := range gvks {
+		if !slices.Contains(k, gvk) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *FluxSuite) TestFluxKind() {
+	s.Run("returns supported kinds", func() {
+		gk, err := fluxKind("HelmRelease")
+		s.Require().NoError(err)
+		s.Equal("helm.toolkit.fluxcd.io", gk.Group)
+	})
+	s.Run("rejects unsupported kinds", func() {
+		_, err := fluxKind("HelmRepository")
+		s.ErrorContains(err, "unsupported kind \"HelmRepository\"")
+	})
+}
+
+func (s *FluxSuite) TestFluxGVR() {
+	sourceV1 := schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1"}
+	sourceV1beta2 := schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1beta2"}
+	ociRepository, err := fluxKind("OCIRepository")
+	s.Require().NoError(err)
+	s.Run("resolves the version served by the cluster", func() {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{sourceV1})
+		mapper.Add(sourceV1.WithKind("OCIRepository"), meta.RESTScopeNamespace)
+		gvr, err := fluxGVR(mapper, ociRepository)
+		s.Require().NoError(err)
+		s.Equal(sourceV1.WithResource("ocirepositories"), gvr)
+	})
+	s.Run("resolves the version preferred by the cluster", func() {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{sourceV1, sourceV1beta2})
+		mapper.Add(sourceV1beta2.WithKind("OCIRepository"), meta.RESTScopeNamespace)
+		mapper.Add(sourceV1.WithKind("OCIRepository"), meta.RESTScopeNamespace)
+		gvr, err := fluxGVR(mapper, ociRepository)
+		s.Require().NoError(err)
+		s.Equal(sourceV1.WithResource("ocirepositories"), gvr)
+	})
+	s.Run("resolves the older versions served by the cluster", func() {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{sourceV1beta2})
+		mapper.Add(sourceV1beta2.WithKind("OCIRepository"), meta.RESTScopeNamespace)
+		gvr, err := fluxGVR(mapper, ociRepository)
+		s.Require().NoError(err)
+		s.Equal(sourceV1beta2.WithResource("ocirepositories"), gvr)
+	})
+	s.Run("reports the kinds not served by the cluster", func() {
+		_, err := fluxGVR(meta.NewDefaultRESTMapper(nil), ociRepository)
+		s.True(meta.IsNoMatchError(err))
+	})
+}
+
+func (s *FluxSuite) TestHasFlux() {
+	s.Run("detects any of the Flux controllers", func() {
+		s.True(hasFlux(servedKinds{{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "OCIRepository"}})())
+		s.True(hasFlux(servedKinds{{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}})())
+	})
+	s.Run("hides the tools without Flux", func() {
+		s.False(hasFlux(servedKinds{{Group: "apps", Version: "v1", Kind: "Deployment"}})())
+	})
+}
+
+func (s *FluxSuite) TestTraceObject() {
+	deployment := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{"name": "podinfo", "namespace": "apps", "labels": map[string]any{
+			"helm.toolkit.fluxcd.io/name":      "podinfo",
+			"helm.toolkit.fluxcd.io/namespace": "apps",
+		}},
+	}
+	replicaSet := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "ReplicaSet",
+		"metadata": map[string]any{"name": "podinfo-5d8f", "namespace": "apps", "ownerReferences": []any{
+			map[string]any{"apiVersion": "apps/v1", "kind": "Deployment", "name": "podinfo", "uid": "1", "controller": true},
+		}},
+	}
+	pod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{"name": "podinfo-5d8f-x2x4z", "namespace": "apps", "ownerReferences": []any{
+			map[string]any{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "podinfo-5d8f", "uid": "2", "controller": true},
+		}},
+	}}
+	get := objects(deployment, replicaSet, helmRelease(), kustomization(), gitRepository())
+
+	s.Run("follows owners and Flux objects up to the sources", func() {
+		report := traceObject(context.Background(), get, pod)
+		s.Equal("Pod apps/podinfo-5d8f-x2x4z", report.Object)
+		s.Equal([]string{"ReplicaSet apps/podinfo-5d8f", "Deployment apps/podinfo"}, report.Owners)
+		s.Require().Len(report.ManagedBy, 3)
+		s.Equal("HelmRelease", report.ManagedBy[0].Kind)
+		s.Equal("Kustomization", report.ManagedBy[1].Kind)
+		s.Equal("GitRepository", report.ManagedBy[2].Kind)
+		s.Empty(report.Issues)
+		s.Contains(report.Hint, "reconciliation of HelmRelease apps/podinfo")
+	})
+	s.Run("reports missing Flux objects", func() {
+		report := traceObject(context.Background(), objects(deployment), &unstructured.Unstructured{Object: deployment})
+		s.Empty(report.ManagedBy)
+		s.Require().Len(report.Issues, 1)
+		s.Contains(report.Issues[0], "failed to get HelmRelease apps/podinfo")
+	})
+	s.Run("reports objects not managed by Flux", func() {
+		configMap := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "settings", "namespace": "apps"},
+		}}
+		report := traceObject(context.Background(), get, configMap)
+		s.Empty(report.Owners)
+		s.Empty(report.ManagedBy)
+		s.Contains(report.Hint, "not managed by Flux")
+	})
+}
@@ -0,0 +1,200 @@
+package flux
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// reconcileRequestedAtAnnotation requests the Flux controllers to reconcile an object outside of its interval.
+const reconcileRequestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+
+func objectProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"kind": {
+			Type:        "string",
+			Description: "Kind of the Flux object",
+			Enum:        fluxKindNames(),
+		},
+		"name": {
+			Type:        "string",
+			Description: "Name of the Flux object",
+		},
+		"namespace": {
+			Type:        "string",
+			Description: "Namespace of the Flux object (Optional, current namespace if not provided)",
+		},
+	}
+}
+
+func initReconcile(p api.FilteringProvider) []api.ServerTool {
+	reconcileProperties := objectProperties()
+	reconcileProperties["with_source"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Also reconcile the source (GitRepository or OCIRepository) of a Kustomization or HelmRelease first, to fetch the latest revision (Optional, defaults to false)",
+		Default:     api.ToRawMessage(false),
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "flux_reconcile",
+			Description: "Force the reconciliation of a Flux object outside of its interval by setting the reconcile.fluxcd.io/requestedAt annotation, as flux reconcile does. " +
+				"Use flux_resources_list afterwards to check its Ready condition and revision",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: reconcileProperties,
+				Required:   []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Flux: Reconcile",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: reconcile, TargetCompatibilityFilters: []func() bool{hasFlux(p)}},
+		{Tool: api.Tool{
+			Name: "flux_suspend",
+			Description: "Suspend the reconciliation of a Flux object by setting spec.suspend, e.g. to make temporary changes to the resources it manages. " +
+				"Suspended objects are not reconciled until they are resumed with flux_resume",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: objectProperties(),
+				Required:   []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Flux: Suspend",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: suspend, TargetCompatibilityFilters: []func() bool{hasFlux(p)}},
+		{Tool: api.Tool{
+			Name: "flux_resume",
+			Description: "Resume the reconciliation of a suspended Flux object and request its immediate reconciliation. " +
+				"Changes made to the managed resources while the object was suspended are reverted",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: objectProperties(),
+				Required:   []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Flux: Resume",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resume, TargetCompatibilityFilters: []func() bool{hasFlux(p)}},
+	}
+}
+
+// objectParams extracts the kind, name and namespace of the targeted Flux object.
+func objectParams(params api.ToolHandlerParams) (*api.Params, schema.GroupKind, string, string, error) {
+	p := api.WrapParams(params)
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	if err := p.Err(); err != nil {
+		return p, schema.GroupKind{}, "", "", err
+	}
+	gk, err := fluxKind(kind)
+	return p, gk, namespace, name, err
+}
+
+func reconcileAnnotationPatch(now time.Time) map[string]any {
+	return map[string]any{"annotations": map[string]any{reconcileRequestedAtAnnotation: now.Format(time.RFC3339Nano)}}
+}
+
+func patchFluxObject(params api.ToolHandlerParams, gk schema.GroupKind, namespace, name string, patch map[string]any) error {
+	gvr, err := fluxGVR(params.RESTMapper(), gk)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = params.DynamicClient().Resource(gvr).Namespace(namespace).Patch(params.Context, name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+func reconcile(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p, gk, namespace, name, err := objectParams(params)
+	withSource := p.OptionalBool("with_source", false)
+	if err == nil {
+		err = p.Err()
+	}
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to reconcile flux object: %w", err)), nil
+	}
+
+	gvr, err := fluxGVR(params.RESTMapper(), gk)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s/%s: %w", gk.Kind, namespace, name, err)), nil
+	}
+	item, err := params.DynamicClient().Resource(gvr).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s/%s: %w", gk.Kind, namespace, name, err)), nil
+	}
+	obj, err := decodeFluxObject(item)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if obj.Spec.Suspend {
+		return api.NewToolCallResult("", fmt.Errorf("failed to reconcile %s %s/%s: the object is suspended, resume it with flux_resume", gk.Kind, namespace, name)), nil
+	}
+	now := time.Now()
+	result := ""
+	if ref := obj.sourceRef(); withSource && ref != nil {
+		sourceGK, err := fluxKind(ref.Kind)
+		if err != nil {
+			result += fmt.Sprintf("Source %s was not reconciled: only GitRepository and OCIRepository sources are supported. ", ref.describe(namespace))
+		} else if err = patchFluxObject(params, sourceGK, cmp.Or(ref.Namespace, namespace), ref.Name, map[string]any{"metadata": reconcileAnnotationPatch(now)}); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to reconcile source %s: %w", ref.describe(namespace), err)), nil
+		} else {
+			result += fmt.Sprintf("Reconciliation of source %s requested. ", ref.describe(namespace))
+		}
+	}
+	if err = patchFluxObject(params, gk, namespace, name, map[string]any{"metadata": reconcileAnnotationPatch(now)}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to reconcile %s %s/%s: %w", gk.Kind, namespace, name, err)), nil
+	}
+	result += fmt.Sprintf("Reconciliation of %s '%s' in namespace '%s' requested at %s. Use flux_resources_list to check its Ready condition",
+		gk.Kind, name, namespace, now.Format(time.RFC3339))
+	return api.NewToolCallResult(result, nil), nil
+}
+
+func suspend(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	_, gk, namespace, name, err := objectParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to suspend flux object: %w", err)), nil
+	}
+	if err = patchFluxObject(params, gk, namespace, name, map[string]any{"spec": map[string]any{"suspend": true}}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to suspend %s %s/%s: %w", gk.Kind, namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s '%s' in namespace '%s' suspended", gk.Kind, name, namespace), nil), nil
+}
+
+func resume(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	_, gk, namespace, name, err := objectParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to resume flux object: %w", err)), nil
+	}
+	patch := map[string]any{
+		"metadata": reconcileAnnotationPatch(time.Now()),
+		"spec":     map[string]any{"suspend": nil},
+	}
+	if err = patchFluxObject(params, gk, namespace, name, patch); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to resume %s %s/%s: %w", gk.Kind, namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s '%s' in namespace '%s' resumed and its reconciliation requested", gk.Kind, name, namespace), nil), nil
+}
@@ -0,0 +1,311 @@
+package flux
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// fluxKinds are the Flux kinds supported by this toolset, sources first. Their version is resolved through the RESTMapper,
+// the Flux releases serve them at different versions, e.g. OCIRepository is served at v1 since Flux 2.6 and at v1beta2 before.
+var fluxKinds = []schema.GroupKind{
+	{Group: "source.toolkit.fluxcd.io", Kind: "GitRepository"},
+	{Group: "source.toolkit.fluxcd.io", Kind: "OCIRepository"},
+	{Group: "kustomize.toolkit.fluxcd.io", Kind: "Kustomization"},
+	{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"},
+}
+
+// fluxKindVersions are the versions of the Flux kinds served by the supported Flux releases, to detect Flux through the discovery.
+var fluxKindVersions = map[string][]string{
+	"GitRepository": {"v1", "v1beta2"},
+	"OCIRepository": {"v1", "v1beta2"},
+	"Kustomization": {"v1"},
+	"HelmRelease":   {"v2", "v2beta2"},
+}
+
+func fluxKindNames() []any {
+	ret := make([]any, 0, len(fluxKinds))
+	for _, gk := range fluxKinds {
+		ret = append(ret, gk.Kind)
+	}
+	return ret
+}
+
+// fluxKind returns the GroupKind of the provided Flux kind.
+func fluxKind(kind string) (schema.GroupKind, error) {
+	for _, gk := range fluxKinds {
+		if gk.Kind == kind {
+			return gk, nil
+		}
+	}
+	return schema.GroupKind{}, fmt.Errorf("unsupported kind %q, must be one of %v", kind, fluxKindNames())
+}
+
+// fluxGVR returns the GroupVersionResource of a supported Flux kind at the version preferred by the cluster.
+func fluxGVR(mapper meta.RESTMapper, gk schema.GroupKind) (schema.GroupVersionResource, error) {
+	mapping, err := mapper.RESTMapping(gk)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return mapping.Resource, nil
+}
+
+// hasFlux returns a filter that hides the tools when none of the Flux kinds is served, whichever Flux controllers are installed.
+func hasFlux(p api.FilteringProvider) func() bool {
+	return func() bool {
+		for _, gk := range fluxKinds {
+			for _, version := range fluxKindVersions[gk.Kind] {
+				if p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{gk.WithVersion(version)}) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func initResources(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "flux_resources_list",
+			Description: "List Flux GitRepositories, OCIRepositories, Kustomizations and HelmReleases with their Ready condition, suspension, source, " +
+				"and last applied (or fetched) revision. Resources managed by Flux are reverted on the next reconciliation when edited directly in the cluster: " +
+				"change the desired state in the source instead, or suspend the Flux object first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the Flux objects to list (Optional, all kinds if not provided)",
+						Enum:        fluxKindNames(),
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Flux objects (Optional, all namespaces if not provided)",
+					},
+					"not_ready": {
+						Type:        "boolean",
+						Description: "Only list the objects that are not Ready or are suspended (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Flux: List Resources",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesList, TargetCompatibilityFilters: []func() bool{hasFlux(p)}},
+	}
+}
+
+// fluxObject is the subset of the Flux source, Kustomization and HelmRelease objects inspected by this toolset.
+type fluxObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Suspend   bool           `json:"suspend,omitempty"`
+		URL       string         `json:"url,omitempty"`
+		Ref       map[string]any `json:"ref,omitempty"`
+		Path      string         `json:"path,omitempty"`
+		SourceRef *objectRef     `json:"sourceRef,omitempty"`
+		Chart     *struct {
+			Spec struct {
+				Chart     string    `json:"chart"`
+				Version   string    `json:"version,omitempty"`
+				SourceRef objectRef `json:"sourceRef"`
+			} `json:"spec"`
+		} `json:"chart,omitempty"`
+		ChartRef *objectRef `json:"chartRef,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+		Artifact   *struct {
+			Revision string `json:"revision"`
+		} `json:"artifact,omitempty"`
+		LastAppliedRevision    string `json:"lastAppliedRevision,omitempty"`
+		LastAttemptedRevision  string `json:"lastAttemptedRevision,omitempty"`
+		LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+		History                []struct {
+			ChartName    string `json:"chartName"`
+			ChartVersion string `json:"chartVersion"`
+		} `json:"history,omitempty"`
+	} `json:"status"`
+}
+
+// objectRef references a Flux object, such as the source of a Kustomization, the namespace defaults to the referencing object's one.
+type objectRef struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (r objectRef) describe(defaultNamespace string) string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, cmp.Or(r.Namespace, defaultNamespace), r.Name)
+}
+
+type fluxSummary struct {
+	Kind                   string `json:"kind"`
+	Name                   string `json:"name"`
+	Namespace              string `json:"namespace"`
+	Ready                  string `json:"ready"`
+	Suspended              bool   `json:"suspended,omitempty"`
+	Source                 string `json:"source,omitempty"`
+	Revision               string `json:"revision,omitempty"`
+	LastAttemptedRevision  string `json:"lastAttemptedRevision,omitempty"`
+	LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+}
+
+func decodeFluxObject(item *unstructured.Unstructured) (*fluxObject, error) {
+	obj := &fluxObject{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err)
+	}
+	return obj, nil
+}
+
+func summarizeFluxObject(kind string, obj *fluxObject) fluxSummary {
+	summary := fluxSummary{
+		Kind:                   kind,
+		Name:                   obj.Name,
+		Namespace:              obj.Namespace,
+		Ready:                  readyStatus(obj.Status.Conditions),
+		Suspended:              obj.Spec.Suspend,
+		LastHandledReconcileAt: obj.Status.LastHandledReconcileAt,
+	}
+	if ref := obj.sourceRef(); ref != nil {
+		summary.Source = ref.describe(obj.Namespace)
+	} else if obj.Spec.URL != "" {
+		summary.Source = obj.Spec.URL
+		if ref := describeRef(obj.Spec.Ref); ref != "" {
+			summary.Source += " " + ref
+		}
+	}
+	switch {
+	case obj.Status.Artifact != nil:
+		summary.Revision = obj.Status.Artifact.Revision
+	case len(obj.Status.History) > 0:
+		summary.Revision = obj.Status.History[0].ChartName + "@" + obj.Status.History[0].ChartVersion
+	default:
+		summary.Revision = obj.Status.LastAppliedRevision
+	}
+	// HelmReleases report the attempted chart version, Kustomizations the attempted source revision
+	if attempted := obj.Status.LastAttemptedRevision; attempted != "" && attempted != obj.Status.LastAppliedRevision &&
+		(len(obj.Status.History) == 0 || attempted != obj.Status.History[0].ChartVersion) {
+		summary.LastAttemptedRevision = attempted
+	}
+	return summary
+}
+
+// sourceRef returns the source of a Kustomization or HelmRelease, or nil for sources.
+func (obj *fluxObject) sourceRef() *objectRef {
+	switch {
+	case obj.Spec.SourceRef != nil:
+		return obj.Spec.SourceRef
+	case obj.Spec.ChartRef != nil:
+		return obj.Spec.ChartRef
+	case obj.Spec.Chart != nil:
+		return &obj.Spec.Chart.Spec.SourceRef
+	default:
+		return nil
+	}
+}
+
+// describeRef describes the Git or OCI reference of a source, e.g. branch=main.
+func describeRef(ref map[string]any) string {
+	var parts []string
+	for _, key := range []string{"branch", "tag", "semver", "name", "commit", "digest"} {
+		if value, ok := ref[key].(string); ok && value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func readyStatus(conditions []metav1.Condition) string {
+	for _, condition := range conditions {
+		if condition.Type != "Ready" {
+			continue
+		}
+		if condition.Status == metav1.ConditionTrue {
+			return string(condition.Status)
+		}
+		return fmt.Sprintf("%s (%s: %s)", condition.Status, condition.Reason, condition.Message)
+	}
+	return "Unknown (not reported)"
+}
+
+func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	kind := p.OptionalString("kind", "")
+	namespace := p.OptionalString("namespace", "")
+	notReady := p.OptionalBool("not_ready", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list flux resources: %w", err)), nil
+	}
+	kinds := fluxKinds
+	if kind != "" {
+		gk, err := fluxKind(kind)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list flux resources: %w", err)), nil
+		}
+		kinds = []schema.GroupKind{gk}
+	}
+
+	var summaries []fluxSummary
+	// notServed are the kinds whose Flux controller is not installed, reported along with the objects of the other kinds
+	var notServed []string
+	for _, gk := range kinds {
+		gvr, err := fluxGVR(params.RESTMapper(), gk)
+		if err != nil && kind == "" && meta.IsNoMatchError(err) {
+			notServed = append(notServed, gk.Kind)
+			continue
+		} else if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list %ss: %w", gk.Kind, err)), nil
+		}
+		list, err := params.DynamicClient().Resource(gvr).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list %ss: %w", gk.Kind, err)), nil
+		}
+		for _, item := range list.Items {
+			obj, err := decodeFluxObject(&item)
+			if err != nil {
+				return api.NewToolCallResult("", err), nil
+			}
+			summary := summarizeFluxObject(gk.Kind, obj)
+			if notReady && summary.Ready == "True" && !summary.Suspended {
+				continue
+			}
+			summaries = append(summaries, summary)
+		}
+	}
+	notServedNote := ""
+	if len(notServed) > 0 {
+		notServedNote = fmt.Sprintf("# Kinds not served by the cluster, their Flux controller is not installed: %s\n", strings.Join(notServed, ", "))
+	}
+	if len(summaries) == 0 {
+		return api.NewToolCallResult(notServedNote+"No Flux resources found", nil), nil
+	}
+	slices.SortStableFunc(summaries, func(a, b fluxSummary) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	ret, err := output.MarshalYaml(summaries)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list flux resources: %w", err)), nil
+	}
+	return api.NewToolCallResult(notServedNote+ret, nil), nil
+}
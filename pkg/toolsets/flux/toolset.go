@@ -0,0 +1,45 @@
+package flux
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides Flux GitOps tools for sources, Kustomizations and HelmReleases.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "flux"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Flux tools to inspect the Ready status and applied revision of GitRepositories, OCIRepositories, Kustomizations and HelmReleases, reconcile, suspend and resume them, and trace which Flux object manages a workload"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initResources(p),
+		initReconcile(p),
+		initTrace(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
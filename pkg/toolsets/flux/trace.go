@@ -0,0 +1,209 @@
+package flux
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// maxOwnerDepth bounds the ownerReferences followed from the traced object, e.g. Pod -> ReplicaSet -> Deployment.
+	maxOwnerDepth = 5
+	// maxManagerDepth bounds the Flux objects followed, e.g. HelmRelease -> Kustomization -> Kustomization.
+	maxManagerDepth = 10
+)
+
+// managerLabels are the labels set by the Flux controllers on the objects they apply, Helm first as HelmReleases are themselves
+// usually applied by a Kustomization.
+var managerLabels = []struct {
+	kind, nameLabel, namespaceLabel string
+}{
+	{"HelmRelease", "helm.toolkit.fluxcd.io/name", "helm.toolkit.fluxcd.io/namespace"},
+	{"Kustomization", "kustomize.toolkit.fluxcd.io/name", "kustomize.toolkit.fluxcd.io/namespace"},
+}
+
+func initTrace(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "flux_trace",
+			Description: "Trace which Flux Kustomization or HelmRelease manages a Kubernetes object, based on the labels set by the Flux controllers, " +
+				"following the owner references first (e.g. Pod -> ReplicaSet -> Deployment) and then the chain of Flux objects up to their sources. " +
+				"Use it before editing a workload to know whether Flux will revert the change",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the object to trace (e.g. apps/v1, v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the object to trace (e.g. Deployment, Pod, ConfigMap)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the object to trace",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the object to trace (Optional, current namespace if not provided)",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Flux: Trace",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: trace, TargetCompatibilityFilters: []func() bool{hasFlux(p)}},
+	}
+}
+
+type traceReport struct {
+	Object    string        `json:"object"`
+	Owners    []string      `json:"owners,omitempty"`
+	ManagedBy []fluxSummary `json:"managedBy,omitempty"`
+	Issues    []string      `json:"issues,omitempty"`
+	Hint      string        `json:"hint,omitempty"`
+}
+
+// getter gets an object of any kind, it is swapped in tests.
+type getter func(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error)
+
+func trace(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	apiVersion := p.RequiredString("apiVersion")
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace object: %w", err)), nil
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace object: invalid apiVersion %q: %w", apiVersion, err)), nil
+	}
+
+	core := kubernetes.NewCore(params)
+	get := func(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+		if gvk.Version == "" {
+			// The Flux kinds are got at the version preferred by the cluster
+			mapping, err := params.RESTMapper().RESTMapping(gvk.GroupKind())
+			if err != nil {
+				return nil, err
+			}
+			gvk = mapping.GroupVersionKind
+		}
+		return core.ResourcesGet(ctx, &gvk, namespace, name)
+	}
+	obj, err := get(params.Context, gv.WithKind(kind), namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s: %w", kind, name, err)), nil
+	}
+	ret, err := output.MarshalYaml(traceObject(params.Context, get, obj))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace object: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func traceObject(ctx context.Context, get getter, obj *unstructured.Unstructured) traceReport {
+	report := traceReport{Object: describeObject(obj)}
+
+	current := obj
+	for range maxOwnerDepth {
+		if managerOf(current) != nil {
+			break
+		}
+		owner := metav1.GetControllerOfNoCopy(current)
+		if owner == nil {
+			break
+		}
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			report.Issues = append(report.Issues, fmt.Sprintf("invalid owner apiVersion %q: %v", owner.APIVersion, err))
+			break
+		}
+		next, err := get(ctx, gv.WithKind(owner.Kind), current.GetNamespace(), owner.Name)
+		if err != nil {
+			report.Issues = append(report.Issues, fmt.Sprintf("failed to get owner %s %s/%s: %v", owner.Kind, current.GetNamespace(), owner.Name, err))
+			break
+		}
+		report.Owners = append(report.Owners, describeObject(next))
+		current = next
+	}
+
+	seen := map[string]bool{}
+	for manager := managerOf(current); manager != nil && len(report.ManagedBy) < maxManagerDepth; manager = managerOf(current) {
+		key := manager.describe("")
+		if seen[key] {
+			break
+		}
+		seen[key] = true
+		gk, _ := fluxKind(manager.Kind)
+		namespace := manager.Namespace
+		item, err := get(ctx, gk.WithVersion(""), namespace, manager.Name)
+		if err != nil {
+			report.Issues = append(report.Issues, fmt.Sprintf("failed to get %s referenced by the Flux labels: %v", key, err))
+			break
+		}
+		fluxObj, err := decodeFluxObject(item)
+		if err != nil {
+			report.Issues = append(report.Issues, err.Error())
+			break
+		}
+		report.ManagedBy = append(report.ManagedBy, summarizeFluxObject(manager.Kind, fluxObj))
+		if source := fluxObj.sourceRef(); source != nil {
+			if sourceGK, err := fluxKind(source.Kind); err == nil {
+				sourceItem, err := get(ctx, sourceGK.WithVersion(""), cmp.Or(source.Namespace, namespace), source.Name)
+				if err != nil {
+					report.Issues = append(report.Issues, fmt.Sprintf("failed to get source %s: %v", source.describe(namespace), err))
+				} else if sourceObj, err := decodeFluxObject(sourceItem); err == nil {
+					report.ManagedBy = append(report.ManagedBy, summarizeFluxObject(source.Kind, sourceObj))
+				}
+			}
+		}
+		current = item
+	}
+
+	if len(report.ManagedBy) == 0 {
+		report.Hint = "the object is not managed by Flux: no helm.toolkit.fluxcd.io or kustomize.toolkit.fluxcd.io labels found on the object or its owners"
+	} else {
+		manager := report.ManagedBy[0]
+		report.Hint = fmt.Sprintf("changes made directly to the object are reverted on the next reconciliation of %s %s/%s: "+
+			"change the desired state in the source, or suspend it with flux_suspend first", manager.Kind, manager.Namespace, manager.Name)
+	}
+	return report
+}
+
+// managerOf returns the Flux object that applied obj according to its labels, or nil if obj is not managed by Flux.
+func managerOf(obj *unstructured.Unstructured) *objectRef {
+	labels := obj.GetLabels()
+	for _, m := range managerLabels {
+		name, namespace := labels[m.nameLabel], labels[m.namespaceLabel]
+		if name != "" && namespace != "" {
+			return &objectRef{Kind: m.kind, Name: name, Namespace: namespace}
+		}
+	}
+	return nil
+}
+
+func describeObject(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetKind() + " " + obj.GetName()
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxReportedEndpoints limits the number of ready endpoint addresses listed per backend.
+const maxReportedEndpoints = 10
+
+// backend is a Service backend resolved down to its ready endpoints.
+type backend struct {
+	Service           string   `json:"service"`
+	Namespace         string   `json:"namespace"`
+	Port              string   `json:"port,omitempty"`
+	Weight            *int64   `json:"weight,omitempty"`
+	ReadyEndpoints    []string `json:"readyEndpoints,omitempty"`
+	NotReadyEndpoints int      `json:"notReadyEndpoints,omitempty"`
+	Issues            []string `json:"issues,omitempty"`
+}
+
+// resolveServiceBackend resolves a Service backend and its port (number or name) to the ready
+// endpoint addresses published in its EndpointSlices. Dangling references are reported as issues.
+func resolveServiceBackend(ctx context.Context, client kubernetes.Interface, namespace, name, port string) *backend {
+	b := &backend{Service: name, Namespace: namespace, Port: port}
+	service, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		b.Issues = append(b.Issues, fmt.Sprintf("dangling backend: Service %s/%s does not exist", namespace, name))
+		return b
+	} else if err != nil {
+		b.Issues = append(b.Issues, fmt.Sprintf("unable to get Service %s/%s: %v", namespace, name, err))
+		return b
+	}
+
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		b.ReadyEndpoints = []string{service.Spec.ExternalName}
+		return b
+	}
+
+	servicePort := findServicePort(service, port)
+	if port != "" && servicePort == nil {
+		b.Issues = append(b.Issues, fmt.Sprintf("dangling backend: Service %s/%s has no port %s", namespace, name, port))
+		return b
+	}
+
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err != nil {
+		b.Issues = append(b.Issues, fmt.Sprintf("unable to list EndpointSlices of Service %s/%s: %v", namespace, name, err))
+		return b
+	}
+	ready := 0
+	for _, slice := range slices.Items {
+		endpointPort := endpointSlicePort(slice, servicePort)
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				b.NotReadyEndpoints++
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				ready++
+				if len(b.ReadyEndpoints) < maxReportedEndpoints {
+					b.ReadyEndpoints = append(b.ReadyEndpoints, joinHostPort(address, endpointPort))
+				}
+			}
+		}
+	}
+	if ready > maxReportedEndpoints {
+		b.ReadyEndpoints = append(b.ReadyEndpoints, fmt.Sprintf("(%d more)", ready-maxReportedEndpoints))
+	}
+	if ready == 0 {
+		b.Issues = append(b.Issues, fmt.Sprintf("Service %s/%s has no ready endpoints (%d not ready)", namespace, name, b.NotReadyEndpoints))
+	}
+	return b
+}
+
+// findServicePort returns the Service port matching the given port number or name.
+// When port is empty and the Service exposes a single port, that port is returned.
+func findServicePort(service *v1.Service, port string) *v1.ServicePort {
+	if port == "" {
+		if len(service.Spec.Ports) == 1 {
+			return &service.Spec.Ports[0]
+		}
+		return nil
+	}
+	number, err := strconv.ParseInt(port, 10, 32)
+	for i := range service.Spec.Ports {
+		sp := &service.Spec.Ports[i]
+		if err == nil && int64(sp.Port) == number {
+			return sp
+		}
+		if err != nil && sp.Name == port {
+			return sp
+		}
+	}
+	return nil
+}
+
+// endpointSlicePort returns the endpoint port number published for the given Service port, or 0 if unknown.
+func endpointSlicePort(slice discoveryv1.EndpointSlice, servicePort *v1.ServicePort) int32 {
+	if servicePort == nil {
+		return 0
+	}
+	for _, p := range slice.Ports {
+		name := ""
+		if p.Name != nil {
+			name = *p.Name
+		}
+		if name == servicePort.Name && p.Port != nil {
+			return *p.Port
+		}
+	}
+	return 0
+}
+
+func joinHostPort(address string, port int32) string {
+	if port == 0 {
+		return address
+	}
+	if strings.Contains(address, ":") {
+		return fmt.Sprintf("[%s]:%d", address, port)
+	}
+	return fmt.Sprintf("%s:%d", address, port)
+}
+
+// checkTLSSecret verifies that a TLS certificate Secret exists and is usable, returning a description of the problem otherwise.
+func checkTLSSecret(ctx context.Context, client kubernetes.Interface, namespace, name string) string {
+	if name == "" {
+		return ""
+	}
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("missing TLS secret: Secret %s/%s does not exist", namespace, name)
+	} else if err != nil {
+		return fmt.Sprintf("unable to get TLS Secret %s/%s: %v", namespace, name, err)
+	}
+	if len(secret.Data[v1.TLSCertKey]) == 0 || len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
+		return fmt.Sprintf("invalid TLS secret: Secret %s/%s has no %s or %s entry", namespace, name, v1.TLSCertKey, v1.TLSPrivateKeyKey)
+	}
+	return ""
+}
+
+// hostMatches reports whether a requested hostname is served by the given route hostname,
+// supporting the leading wildcard label used by both Ingress and Gateway API.
+// An empty route hostname or an empty requested hostname matches everything.
+func hostMatches(routeHost, host string) bool {
+	if routeHost == "" || host == "" {
+		return true
+	}
+	routeHost, host = strings.ToLower(routeHost), strings.ToLower(host)
+	if routeHost == host {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(routeHost, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return false
+}
@@ -0,0 +1,503 @@
+package gateway
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+var (
+	gatewayGVR   = schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1", Resource: "gateways"}
+	httpRouteGVR = schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1", Resource: "httproutes"}
+	grpcRouteGVR = schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1", Resource: "grpcroutes"}
+)
+
+// hasGatewayAPI returns a filter that hides the Gateway API tools when the Gateway API CRDs are not installed.
+func hasGatewayAPI(p api.FilteringProvider) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{
+			{Group: gatewayAPIGroup, Version: "v1", Kind: "Gateway"},
+			{Group: gatewayAPIGroup, Version: "v1", Kind: "HTTPRoute"},
+		})
+	}
+}
+
+func initGateways(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "gateways_inspect",
+			Description: "Inspect Gateway API Gateways: listeners (hostname, port, protocol), the number of attached routes per listener, " +
+				"the Accepted and Programmed status conditions, and missing or invalid TLS certificate Secrets",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Gateways to inspect (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Gateway to inspect (Optional, all Gateways in the namespace if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Gateways: Inspect",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: gatewaysInspect, TargetCompatibilityFilters: []func() bool{hasGatewayAPI(p)}},
+		{Tool: api.Tool{
+			Name: "gateway_routes_inspect",
+			Description: "Inspect Gateway API HTTPRoutes or GRPCRoutes and resolve their hostnames and matches to backend Services and the Services' ready endpoints. " +
+				"Reports the Accepted and ResolvedRefs status conditions for each parent Gateway and flags dangling backendRefs " +
+				"(missing Service or port), backends without ready endpoints, and backendRefs to kinds other than Service",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the routes to inspect (Optional, defaults to HTTPRoute)",
+						Enum:        []any{"HTTPRoute", "GRPCRoute"},
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the routes to inspect (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the route to inspect (Optional, all routes of the kind in the namespace if not provided)",
+					},
+					"host": {
+						Type:        "string",
+						Description: "Only report routes serving this hostname, wildcard hostnames are matched too (Optional)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Gateway Routes: Inspect",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: gatewayRoutesInspect, TargetCompatibilityFilters: []func() bool{hasGatewayAPI(p)}},
+	}
+}
+
+// gatewayObject is the subset of a Gateway API Gateway inspected by this toolset.
+type gatewayObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+		Listeners        []struct {
+			Name     string  `json:"name"`
+			Hostname *string `json:"hostname,omitempty"`
+			Port     int32   `json:"port"`
+			Protocol string  `json:"protocol"`
+			TLS      *struct {
+				Mode            *string     `json:"mode,omitempty"`
+				CertificateRefs []objectRef `json:"certificateRefs,omitempty"`
+			} `json:"tls,omitempty"`
+		} `json:"listeners"`
+	} `json:"spec"`
+	Status struct {
+		Addresses []struct {
+			Value string `json:"value"`
+		} `json:"addresses,omitempty"`
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []metav1.Condition `json:"conditions,omitempty"`
+		} `json:"listeners,omitempty"`
+	} `json:"status"`
+}
+
+// routeObject is the subset of a Gateway API HTTPRoute or GRPCRoute inspected by this toolset.
+type routeObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ParentRefs []objectRef `json:"parentRefs,omitempty"`
+		Hostnames  []string    `json:"hostnames,omitempty"`
+		Rules      []struct {
+			Matches     []map[string]any `json:"matches,omitempty"`
+			BackendRefs []struct {
+				objectRef `json:",inline"`
+				Port      *int32 `json:"port,omitempty"`
+				Weight    *int32 `json:"weight,omitempty"`
+			} `json:"backendRefs,omitempty"`
+		} `json:"rules,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef      objectRef          `json:"parentRef"`
+			ControllerName string             `json:"controllerName"`
+			Conditions     []metav1.Condition `json:"conditions,omitempty"`
+		} `json:"parents,omitempty"`
+	} `json:"status"`
+}
+
+// objectRef covers the Gateway API ParentReference, SecretObjectReference, and BackendObjectReference fields used by this toolset.
+type objectRef struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// describe returns a human-readable reference, defaulting the namespace and kind as the Gateway API does.
+func (r objectRef) describe(defaultKind, defaultNamespace string) string {
+	kind := ptr.Deref(r.Kind, defaultKind)
+	if group := ptr.Deref(r.Group, ""); group != "" {
+		kind += "." + group
+	}
+	ret := fmt.Sprintf("%s %s/%s", kind, ptr.Deref(r.Namespace, defaultNamespace), r.Name)
+	if r.SectionName != nil {
+		ret += "#" + *r.SectionName
+	}
+	return ret
+}
+
+type gatewayReport struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Class      string            `json:"class"`
+	Addresses  []string          `json:"addresses,omitempty"`
+	Conditions map[string]string `json:"conditions"`
+	Listeners  []listenerReport  `json:"listeners"`
+	Issues     []string          `json:"issues,omitempty"`
+}
+
+type listenerReport struct {
+	Name            string            `json:"name"`
+	Hostname        string            `json:"hostname"`
+	Port            int32             `json:"port"`
+	Protocol        string            `json:"protocol"`
+	TLSMode         string            `json:"tlsMode,omitempty"`
+	CertificateRefs []string          `json:"certificateRefs,omitempty"`
+	AttachedRoutes  int32             `json:"attachedRoutes"`
+	Conditions      map[string]string `json:"conditions,omitempty"`
+}
+
+type routeReport struct {
+	Kind      string            `json:"kind"`
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Hostnames []string          `json:"hostnames"`
+	Parents   []routeParent     `json:"parents"`
+	Rules     []routeRuleReport `json:"rules,omitempty"`
+	Issues    []string          `json:"issues,omitempty"`
+}
+
+type routeParent struct {
+	Ref        string            `json:"ref"`
+	Controller string            `json:"controller,omitempty"`
+	Conditions map[string]string `json:"conditions"`
+}
+
+type routeRuleReport struct {
+	Matches  []string   `json:"matches"`
+	Backends []*backend `json:"backends,omitempty"`
+}
+
+func gatewaysInspect(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect gateways: %w", err)), nil
+	}
+
+	items, err := getOrList(params, gatewayGVR, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect gateways: %w", err)), nil
+	}
+	reports := make([]gatewayReport, 0, len(items))
+	for _, item := range items {
+		gateway := &gatewayObject{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, gateway); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode gateway %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		reports = append(reports, inspectGateway(params.Context, params.KubernetesClient, gateway))
+	}
+	if len(reports) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No Gateways found in namespace %s", namespace), nil), nil
+	}
+	ret, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect gateways: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func inspectGateway(ctx context.Context, client kubernetes.Interface, gateway *gatewayObject) gatewayReport {
+	report := gatewayReport{
+		Name:      gateway.Name,
+		Namespace: gateway.Namespace,
+		Class:     gateway.Spec.GatewayClassName,
+	}
+	for _, address := range gateway.Status.Addresses {
+		report.Addresses = append(report.Addresses, address.Value)
+	}
+	report.Conditions, report.Issues = summarizeConditions("Gateway", gateway.Status.Conditions, "Accepted", "Programmed")
+
+	for _, l := range gateway.Spec.Listeners {
+		listener := listenerReport{
+			Name:     l.Name,
+			Hostname: ptr.Deref(l.Hostname, "*"),
+			Port:     l.Port,
+			Protocol: l.Protocol,
+		}
+		for _, status := range gateway.Status.Listeners {
+			if status.Name != l.Name {
+				continue
+			}
+			listener.AttachedRoutes = status.AttachedRoutes
+			var issues []string
+			listener.Conditions, issues = summarizeConditions(fmt.Sprintf("Listener %q", l.Name), status.Conditions, "Accepted", "Programmed", "ResolvedRefs")
+			report.Issues = append(report.Issues, issues...)
+		}
+		if l.TLS != nil {
+			listener.TLSMode = ptr.Deref(l.TLS.Mode, "Terminate")
+			for _, ref := range l.TLS.CertificateRefs {
+				listener.CertificateRefs = append(listener.CertificateRefs, ref.describe("Secret", gateway.Namespace))
+				if ptr.Deref(ref.Kind, "Secret") != "Secret" || ptr.Deref(ref.Group, "") != "" {
+					continue
+				}
+				if problem := checkTLSSecret(ctx, client, ptr.Deref(ref.Namespace, gateway.Namespace), ref.Name); problem != "" {
+					report.Issues = append(report.Issues, fmt.Sprintf("Listener %q: %s", l.Name, problem))
+				}
+			}
+			if listener.TLSMode == "Terminate" && len(l.TLS.CertificateRefs) == 0 {
+				report.Issues = append(report.Issues, fmt.Sprintf("Listener %q terminates TLS but has no certificateRefs", l.Name))
+			}
+		}
+		report.Listeners = append(report.Listeners, listener)
+	}
+	return report
+}
+
+func gatewayRoutesInspect(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	kind := p.OptionalString("kind", "HTTPRoute")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.OptionalString("name", "")
+	host := p.OptionalString("host", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect routes: %w", err)), nil
+	}
+	var gvr schema.GroupVersionResource
+	switch kind {
+	case "HTTPRoute":
+		gvr = httpRouteGVR
+	case "GRPCRoute":
+		gvr = grpcRouteGVR
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect routes: unsupported kind %q, must be one of HTTPRoute, GRPCRoute", kind)), nil
+	}
+
+	items, err := getOrList(params, gvr, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect %ss: %w", kind, err)), nil
+	}
+	reports := make([]routeReport, 0, len(items))
+	for _, item := range items {
+		route := &routeObject{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, route); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode %s %s/%s: %w", kind, item.GetNamespace(), item.GetName(), err)), nil
+		}
+		if !routeServesHost(route, host) {
+			continue
+		}
+		reports = append(reports, inspectRoute(params.Context, params.KubernetesClient, kind, route))
+	}
+	if len(reports) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No %ss found in namespace %s", kind, namespace), nil), nil
+	}
+	ret, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect %ss: %w", kind, err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func routeServesHost(route *routeObject, host string) bool {
+	if host == "" || len(route.Spec.Hostnames) == 0 {
+		return true
+	}
+	for _, hostname := range route.Spec.Hostnames {
+		if hostMatches(hostname, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func inspectRoute(ctx context.Context, client kubernetes.Interface, kind string, route *routeObject) routeReport {
+	report := routeReport{
+		Kind:      kind,
+		Name:      route.Name,
+		Namespace: route.Namespace,
+		Hostnames: route.Spec.Hostnames,
+	}
+	if len(report.Hostnames) == 0 {
+		report.Hostnames = []string{"*"}
+	}
+
+	// refNotPermitted is set when a parent reports that a backendRef is not allowed by a ReferenceGrant,
+	// cross-namespace backendRefs are only flagged then since a valid ReferenceGrant may permit them
+	refNotPermitted := false
+	for _, parentRef := range route.Spec.ParentRefs {
+		parent := routeParent{Ref: parentRef.describe("Gateway", route.Namespace)}
+		found := false
+		for _, status := range route.Status.Parents {
+			if status.ParentRef.Name != parentRef.Name ||
+				ptr.Deref(status.ParentRef.Namespace, route.Namespace) != ptr.Deref(parentRef.Namespace, route.Namespace) ||
+				ptr.Deref(status.ParentRef.SectionName, "") != ptr.Deref(parentRef.SectionName, "") {
+				continue
+			}
+			found = true
+			if resolvedRefs := findCondition(status.Conditions, "ResolvedRefs"); resolvedRefs != nil &&
+				resolvedRefs.Status == metav1.ConditionFalse && resolvedRefs.Reason == "RefNotPermitted" {
+				refNotPermitted = true
+			}
+			var issues []string
+			parent.Controller = status.ControllerName
+			parent.Conditions, issues = summarizeConditions(parent.Ref, status.Conditions, "Accepted", "ResolvedRefs")
+			report.Issues = append(report.Issues, issues...)
+		}
+		if !found {
+			report.Issues = append(report.Issues, fmt.Sprintf("%s has not reported any status for this route, check that the Gateway exists and its controller is running", parent.Ref))
+		}
+		report.Parents = append(report.Parents, parent)
+	}
+	if len(route.Spec.ParentRefs) == 0 {
+		report.Issues = append(report.Issues, "route has no parentRefs and is not attached to any Gateway")
+	}
+
+	for _, rule := range route.Spec.Rules {
+		ruleReport := routeRuleReport{}
+		for _, match := range rule.Matches {
+			ruleReport.Matches = append(ruleReport.Matches, summarizeMatch(match))
+		}
+		if len(ruleReport.Matches) == 0 {
+			ruleReport.Matches = []string{"*"}
+		}
+		for _, ref := range rule.BackendRefs {
+			if ptr.Deref(ref.Kind, "Service") != "Service" || ptr.Deref(ref.Group, "") != "" {
+				report.Issues = append(report.Issues, fmt.Sprintf("backendRef %s is not a Service and was not resolved", ref.describe("Service", route.Namespace)))
+				continue
+			}
+			port := ""
+			if ref.Port != nil {
+				port = strconv.Itoa(int(*ref.Port))
+			}
+			b := resolveServiceBackend(ctx, client, ptr.Deref(ref.Namespace, route.Namespace), ref.Name, port)
+			if ref.Weight != nil {
+				b.Weight = ptr.To(int64(*ref.Weight))
+			}
+			if b.Namespace != route.Namespace && refNotPermitted {
+				b.Issues = append(b.Issues, fmt.Sprintf("cross-namespace backendRef to Service %s/%s may not be permitted, check that a ReferenceGrant in namespace %s allows %ss from namespace %s",
+					b.Namespace, b.Service, b.Namespace, kind, route.Namespace))
+			}
+			report.Issues = append(report.Issues, b.Issues...)
+			ruleReport.Backends = append(ruleReport.Backends, b)
+		}
+		report.Rules = append(report.Rules, ruleReport)
+	}
+	return report
+}
+
+// summarizeMatch renders an HTTPRoute or GRPCRoute match in a compact form, e.g. "PathPrefix /api" or "GET Exact /login".
+func summarizeMatch(match map[string]any) string {
+	var parts []string
+	switch method := match["method"].(type) {
+	case string:
+		parts = append(parts, method)
+	case map[string]any:
+		service, _ := method["service"].(string)
+		name, _ := method["method"].(string)
+		parts = append(parts, fmt.Sprintf("%s/%s", cmp.Or(service, "*"), cmp.Or(name, "*")))
+	}
+	if path, ok := match["path"].(map[string]any); ok {
+		pathType, _ := path["type"].(string)
+		value, _ := path["value"].(string)
+		parts = append(parts, fmt.Sprintf("%s %s", cmp.Or(pathType, "PathPrefix"), cmp.Or(value, "/")))
+	}
+	if headers, ok := match["headers"].([]any); ok {
+		for _, h := range headers {
+			if header, ok := h.(map[string]any); ok {
+				parts = append(parts, fmt.Sprintf("header %v=%v", header["name"], header["value"]))
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
+
+// summarizeConditions renders the given condition types as "Status" or "Status (Reason: Message)"
+// and returns an issue for each condition that is missing or not True.
+func summarizeConditions(subject string, conditions []metav1.Condition, types ...string) (map[string]string, []string) {
+	summary := map[string]string{}
+	var issues []string
+	for _, conditionType := range types {
+		condition := findCondition(conditions, conditionType)
+		if condition == nil {
+			summary[conditionType] = "Unknown (not reported)"
+			continue
+		}
+		summary[conditionType] = string(condition.Status)
+		if condition.Status == metav1.ConditionTrue {
+			continue
+		}
+		summary[conditionType] = fmt.Sprintf("%s (%s: %s)", condition.Status, condition.Reason, condition.Message)
+		issues = append(issues, fmt.Sprintf("%s is not %s: %s: %s", subject, conditionType, condition.Reason, condition.Message))
+	}
+	return summary, issues
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func getOrList(params api.ToolHandlerParams, gvr schema.GroupVersionResource, namespace, name string) ([]unstructured.Unstructured, error) {
+	resource := params.DynamicClient().Resource(gvr).Namespace(namespace)
+	if name != "" {
+		item, err := resource.Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []unstructured.Unstructured{*item}, nil
+	}
+	list, err := resource.List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
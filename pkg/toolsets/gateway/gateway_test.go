@@ -0,0 +1,282 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+type GatewaySuite struct {
+	suite.Suite
+	client *fake.Clientset
+}
+
+func TestGatewaySuite(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+func (s *GatewaySuite) SetupTest() {
+	s.client = fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 80}}},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "ns-1", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080))}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+			},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "ns-1"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-tls", Namespace: "ns-1"},
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{v1.TLSCertKey: []byte("cert"), v1.TLSPrivateKeyKey: []byte("key")},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty-tls", Namespace: "ns-1"},
+		},
+	)
+}
+
+func (s *GatewaySuite) TestResolveServiceBackend() {
+	s.Run("resolves ready endpoints with target port", func() {
+		b := resolveServiceBackend(s.T().Context(), s.client, "ns-1", "web", "80")
+		s.Equal([]string{"10.0.0.1:8080"}, b.ReadyEndpoints)
+		s.Equal(1, b.NotReadyEndpoints)
+		s.Empty(b.Issues)
+	})
+	s.Run("resolves port by name", func() {
+		b := resolveServiceBackend(s.T().Context(), s.client, "ns-1", "web", "http")
+		s.Equal([]string{"10.0.0.1:8080"}, b.ReadyEndpoints)
+	})
+	s.Run("flags missing Service", func() {
+		b := resolveServiceBackend(s.T().Context(), s.client, "ns-1", "missing", "80")
+		s.Require().Len(b.Issues, 1)
+		s.Equal("dangling backend: Service ns-1/missing does not exist", b.Issues[0])
+	})
+	s.Run("flags missing port", func() {
+		b := resolveServiceBackend(s.T().Context(), s.client, "ns-1", "web", "443")
+		s.Require().Len(b.Issues, 1)
+		s.Equal("dangling backend: Service ns-1/web has no port 443", b.Issues[0])
+	})
+	s.Run("flags Service without ready endpoints", func() {
+		b := resolveServiceBackend(s.T().Context(), s.client, "ns-1", "idle", "80")
+		s.Require().Len(b.Issues, 1)
+		s.Contains(b.Issues[0], "has no ready endpoints")
+	})
+}
+
+func (s *GatewaySuite) TestCheckTLSSecret() {
+	s.Run("accepts valid TLS secret", func() {
+		s.Empty(checkTLSSecret(s.T().Context(), s.client, "ns-1", "web-tls"))
+	})
+	s.Run("flags missing TLS secret", func() {
+		s.Equal("missing TLS secret: Secret ns-1/missing-tls does not exist", checkTLSSecret(s.T().Context(), s.client, "ns-1", "missing-tls"))
+	})
+	s.Run("flags TLS secret without certificate", func() {
+		s.Contains(checkTLSSecret(s.T().Context(), s.client, "ns-1", "empty-tls"), "invalid TLS secret")
+	})
+}
+
+func (s *GatewaySuite) TestHostMatches() {
+	s.True(hostMatches("", "example.com"))
+	s.True(hostMatches("example.com", ""))
+	s.True(hostMatches("Example.com", "example.com"))
+	s.True(hostMatches("*.example.com", "www.example.com"))
+	s.False(hostMatches("*.example.com", "example.com"))
+	s.False(hostMatches("www.example.com", "api.example.com"))
+}
+
+func (s *GatewaySuite) TestInspectIngress() {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ptr.To("nginx"),
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"www.example.com"}, SecretName: "web-tls"},
+				{Hosts: []string{"api.example.com"}, SecretName: "missing-tls"},
+			},
+			Rules: []networkingv1.IngressRule{
+				{Host: "www.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: ptr.To(networkingv1.PathTypePrefix),
+						Backend:  networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}}},
+					}},
+				}}},
+				{Host: "api.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:    "/api",
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Name: "http"}}},
+					}},
+				}}},
+			},
+		},
+	}
+	s.Run("resolves all rules and flags dangling backends and TLS secrets", func() {
+		report := inspectIngress(s.T().Context(), s.client, ingress, "")
+		s.Require().NotNil(report)
+		s.Equal("nginx", report.Class)
+		s.Require().Len(report.Rules, 2)
+		s.Equal([]string{"10.0.0.1:8080"}, report.Rules[0].Backend.ReadyEndpoints)
+		s.Contains(report.Issues, "dangling backend: Service ns-1/api does not exist")
+		s.Contains(report.Issues, "missing TLS secret: Secret ns-1/missing-tls does not exist")
+		s.Contains(report.Issues, "Ingress has no load balancer address, the Ingress controller may not have admitted it")
+	})
+	s.Run("filters rules by host", func() {
+		report := inspectIngress(s.T().Context(), s.client, ingress, "www.example.com")
+		s.Require().NotNil(report)
+		s.Require().Len(report.Rules, 1)
+		s.Equal("www.example.com", report.Rules[0].Host)
+	})
+	s.Run("returns nil when no rule serves host", func() {
+		s.Nil(inspectIngress(s.T().Context(), s.client, ingress, "other.example.com"))
+	})
+}
+
+func (s *GatewaySuite) TestInspectGateway() {
+	gateway := &gatewayObject{}
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]any{
+		"metadata": map[string]any{"name": "public", "namespace": "ns-1"},
+		"spec": map[string]any{
+			"gatewayClassName": "istio",
+			"listeners": []any{
+				map[string]any{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]any{"name": "https", "port": int64(443), "protocol": "HTTPS", "hostname": "*.example.com",
+					"tls": map[string]any{"certificateRefs": []any{map[string]any{"name": "missing-tls"}}}},
+			},
+		},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Accepted", "status": "True", "reason": "Accepted", "message": "", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+				map[string]any{"type": "Programmed", "status": "False", "reason": "AddressNotAssigned", "message": "no address", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+			},
+			"listeners": []any{
+				map[string]any{"name": "http", "attachedRoutes": int64(2)},
+			},
+		},
+	}, gateway))
+	report := inspectGateway(s.T().Context(), s.client, gateway)
+	s.Equal("istio", report.Class)
+	s.Equal("True", report.Conditions["Accepted"])
+	s.Equal("False (AddressNotAssigned: no address)", report.Conditions["Programmed"])
+	s.Require().Len(report.Listeners, 2)
+	s.Equal(int32(2), report.Listeners[0].AttachedRoutes)
+	s.Equal("*", report.Listeners[0].Hostname)
+	s.Equal([]string{"Secret ns-1/missing-tls"}, report.Listeners[1].CertificateRefs)
+	s.Contains(report.Issues, "Gateway is not Programmed: AddressNotAssigned: no address")
+	s.Contains(report.Issues, `Listener "https": missing TLS secret: Secret ns-1/missing-tls does not exist`)
+}
+
+func (s *GatewaySuite) TestInspectRoute() {
+	route := &routeObject{}
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]any{
+		"metadata": map[string]any{"name": "web", "namespace": "ns-1"},
+		"spec": map[string]any{
+			"parentRefs": []any{map[string]any{"name": "public"}, map[string]any{"name": "internal", "namespace": "infra"}},
+			"hostnames":  []any{"www.example.com"},
+			"rules": []any{
+				map[string]any{
+					"matches": []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/"}, "method": "GET"}},
+					"backendRefs": []any{
+						map[string]any{"name": "web", "port": int64(80), "weight": int64(90)},
+						map[string]any{"name": "gone", "port": int64(80), "weight": int64(10)},
+						map[string]any{"name": "bucket", "group": "example.com", "kind": "Bucket"},
+					},
+				},
+			},
+		},
+		"status": map[string]any{
+			"parents": []any{map[string]any{
+				"parentRef":      map[string]any{"name": "public"},
+				"controllerName": "istio.io/gateway-controller",
+				"conditions": []any{
+					map[string]any{"type": "Accepted", "status": "True", "reason": "Accepted", "message": "", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+					map[string]any{"type": "ResolvedRefs", "status": "False", "reason": "BackendNotFound", "message": "service gone not found", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+				},
+			}},
+		},
+	}, route))
+	s.Run("serves matching hosts only", func() {
+		s.True(routeServesHost(route, "www.example.com"))
+		s.False(routeServesHost(route, "api.example.com"))
+	})
+	report := inspectRoute(s.T().Context(), s.client, "HTTPRoute", route)
+	s.Run("reports parent conditions", func() {
+		s.Require().Len(report.Parents, 2)
+		s.Equal("Gateway ns-1/public", report.Parents[0].Ref)
+		s.Equal("True", report.Parents[0].Conditions["Accepted"])
+		s.Equal("False (BackendNotFound: service gone not found)", report.Parents[0].Conditions["ResolvedRefs"])
+		s.Contains(report.Issues, "Gateway ns-1/public is not ResolvedRefs: BackendNotFound: service gone not found")
+		s.Contains(report.Issues, "Gateway infra/internal has not reported any status for this route, check that the Gateway exists and its controller is running")
+	})
+	s.Run("resolves backends and flags dangling refs", func() {
+		s.Require().Len(report.Rules, 1)
+		s.Equal([]string{"GET PathPrefix /"}, report.Rules[0].Matches)
+		s.Require().Len(report.Rules[0].Backends, 2)
+		s.Equal(ptr.To(int64(90)), report.Rules[0].Backends[0].Weight)
+		s.Equal([]string{"10.0.0.1:8080"}, report.Rules[0].Backends[0].ReadyEndpoints)
+		s.Contains(report.Issues, "dangling backend: Service ns-1/gone does not exist")
+		s.Contains(report.Issues, "backendRef Bucket.example.com ns-1/bucket is not a Service and was not resolved")
+	})
+}
+
+func (s *GatewaySuite) TestInspectRouteCrossNamespaceBackend() {
+	crossNamespaceRoute := func(resolvedRefs, reason string) *routeObject {
+		route := &routeObject{}
+		s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]any{
+			"metadata": map[string]any{"name": "web", "namespace": "ns-2"},
+			"spec": map[string]any{
+				"parentRefs": []any{map[string]any{"name": "public"}},
+				"rules": []any{
+					map[string]any{"backendRefs": []any{map[string]any{"name": "web", "namespace": "ns-1", "port": int64(80)}}},
+				},
+			},
+			"status": map[string]any{
+				"parents": []any{map[string]any{
+					"parentRef":      map[string]any{"name": "public"},
+					"controllerName": "istio.io/gateway-controller",
+					"conditions": []any{
+						map[string]any{"type": "Accepted", "status": "True", "reason": "Accepted", "message": "", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+						map[string]any{"type": "ResolvedRefs", "status": resolvedRefs, "reason": reason, "message": "", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+					},
+				}},
+			},
+		}, route))
+		return route
+	}
+	s.Run("does not flag backendRefs permitted by a ReferenceGrant", func() {
+		report := inspectRoute(s.T().Context(), s.client, "HTTPRoute", crossNamespaceRoute("True", "ResolvedRefs"))
+		s.Require().Len(report.Rules, 1)
+		s.Require().Len(report.Rules[0].Backends, 1)
+		s.Equal([]string{"10.0.0.1:8080"}, report.Rules[0].Backends[0].ReadyEndpoints)
+		s.Empty(report.Issues)
+	})
+	s.Run("flags backendRefs not permitted by a ReferenceGrant", func() {
+		report := inspectRoute(s.T().Context(), s.client, "HTTPRoute", crossNamespaceRoute("False", "RefNotPermitted"))
+		s.Contains(report.Issues, "cross-namespace backendRef to Service ns-1/web may not be permitted, check that a ReferenceGrant in namespace ns-1 allows HTTPRoutes from namespace ns-2")
+	})
+}
+
+func (s *GatewaySuite) TestSummarizeMatch() {
+	s.Equal("*", summarizeMatch(map[string]any{}))
+	s.Equal("Exact /login", summarizeMatch(map[string]any{"path": map[string]any{"type": "Exact", "value": "/login"}}))
+	s.Equal("helloworld.Greeter/SayHello", summarizeMatch(map[string]any{"method": map[string]any{"service": "helloworld.Greeter", "method": "SayHello"}}))
+	s.Equal("PathPrefix / header x-env=canary", summarizeMatch(map[string]any{
+		"path":    map[string]any{"value": "/"},
+		"headers": []any{map[string]any{"name": "x-env", "value": "canary"}},
+	}))
+}
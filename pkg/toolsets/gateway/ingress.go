@@ -0,0 +1,193 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initIngresses() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "ingresses_inspect",
+			Description: "Inspect Kubernetes Ingresses and resolve each host and path to its backend Service and the Service's ready endpoints. " +
+				"Reports the Ingress class, load balancer status, TLS configuration, and flags dangling backends (missing Service or port), " +
+				"backends without ready endpoints, and missing or invalid TLS secrets",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Ingresses to inspect (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Ingress to inspect (Optional, all Ingresses in the namespace if not provided)",
+					},
+					"host": {
+						Type:        "string",
+						Description: "Only report rules serving this hostname, wildcard rules are matched too (Optional)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Ingresses: Inspect",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: ingressesInspect},
+	}
+}
+
+type ingressReport struct {
+	Name         string             `json:"name"`
+	Namespace    string             `json:"namespace"`
+	Class        string             `json:"class,omitempty"`
+	LoadBalancer []string           `json:"loadBalancer,omitempty"`
+	TLS          []ingressTLSReport `json:"tls,omitempty"`
+	Rules        []ingressRoute     `json:"rules,omitempty"`
+	Default      *backend           `json:"defaultBackend,omitempty"`
+	Issues       []string           `json:"issues,omitempty"`
+}
+
+type ingressTLSReport struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+type ingressRoute struct {
+	Host     string   `json:"host"`
+	Path     string   `json:"path"`
+	PathType string   `json:"pathType,omitempty"`
+	Backend  *backend `json:"backend,omitempty"`
+	Resource string   `json:"resource,omitempty"`
+}
+
+func ingressesInspect(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.OptionalString("name", "")
+	host := p.OptionalString("host", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect ingresses: %w", err)), nil
+	}
+
+	var ingresses []networkingv1.Ingress
+	if name != "" {
+		ingress, err := params.NetworkingV1().Ingresses(namespace).Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get ingress %s/%s: %w", namespace, name, err)), nil
+		}
+		ingresses = append(ingresses, *ingress)
+	} else {
+		list, err := params.NetworkingV1().Ingresses(namespace).List(params.Context, metav1.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list ingresses in namespace %s: %w", namespace, err)), nil
+		}
+		ingresses = list.Items
+	}
+
+	reports := make([]ingressReport, 0, len(ingresses))
+	for i := range ingresses {
+		if report := inspectIngress(params.Context, params.KubernetesClient, &ingresses[i], host); report != nil {
+			reports = append(reports, *report)
+		}
+	}
+	if len(reports) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No Ingresses found in namespace %s", namespace), nil), nil
+	}
+	ret, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect ingresses: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// inspectIngress resolves the rules of an Ingress matching the given host to their backends.
+// Returns nil if a host filter is provided and no rule of the Ingress serves it.
+func inspectIngress(ctx context.Context, client kubernetes.Interface, ingress *networkingv1.Ingress, host string) *ingressReport {
+	report := &ingressReport{Name: ingress.Name, Namespace: ingress.Namespace}
+	if ingress.Spec.IngressClassName != nil {
+		report.Class = *ingress.Spec.IngressClassName
+	} else if class, ok := ingress.Annotations["kubernetes.io/ingress.class"]; ok {
+		report.Class = class
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			report.LoadBalancer = append(report.LoadBalancer, lb.Hostname)
+		} else if lb.IP != "" {
+			report.LoadBalancer = append(report.LoadBalancer, lb.IP)
+		}
+	}
+	if len(report.LoadBalancer) == 0 {
+		report.Issues = append(report.Issues, "Ingress has no load balancer address, the Ingress controller may not have admitted it")
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if !hostMatches(rule.Host, host) || rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			route := ingressRoute{Host: rule.Host, Path: path.Path}
+			if route.Host == "" {
+				route.Host = "*"
+			}
+			if path.PathType != nil {
+				route.PathType = string(*path.PathType)
+			}
+			route.Backend, route.Resource = resolveIngressBackend(ctx, client, ingress.Namespace, path.Backend)
+			if route.Backend != nil {
+				report.Issues = append(report.Issues, route.Backend.Issues...)
+			}
+			report.Rules = append(report.Rules, route)
+		}
+	}
+	if host != "" && len(report.Rules) == 0 {
+		return nil
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		report.Default, _ = resolveIngressBackend(ctx, client, ingress.Namespace, *ingress.Spec.DefaultBackend)
+		if report.Default != nil {
+			report.Issues = append(report.Issues, report.Default.Issues...)
+		}
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		report.TLS = append(report.TLS, ingressTLSReport{Hosts: tls.Hosts, SecretName: tls.SecretName})
+		if problem := checkTLSSecret(ctx, client, ingress.Namespace, tls.SecretName); problem != "" {
+			report.Issues = append(report.Issues, problem)
+		}
+	}
+	return report
+}
+
+// resolveIngressBackend resolves an Ingress backend to its Service, or describes the referenced resource for non-Service backends.
+func resolveIngressBackend(ctx context.Context, client kubernetes.Interface, namespace string, ib networkingv1.IngressBackend) (*backend, string) {
+	if ib.Resource != nil {
+		group := ""
+		if ib.Resource.APIGroup != nil {
+			group = *ib.Resource.APIGroup
+		}
+		return nil, fmt.Sprintf("%s.%s/%s", ib.Resource.Kind, group, ib.Resource.Name)
+	}
+	if ib.Service == nil {
+		return nil, ""
+	}
+	port := ib.Service.Port.Name
+	if ib.Service.Port.Number != 0 {
+		port = strconv.Itoa(int(ib.Service.Port.Number))
+	}
+	return resolveServiceBackend(ctx, client, namespace, ib.Service.Name, port), ""
+}
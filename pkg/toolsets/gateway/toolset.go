@@ -0,0 +1,44 @@
+package gateway
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides tools to trace north-south traffic through Ingress and Gateway API routes.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "gateway"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Trace north-south traffic through Ingress and Gateway API (Gateway, HTTPRoute, GRPCRoute) objects down to backend Services and their ready endpoints"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initIngresses(),
+		initGateways(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
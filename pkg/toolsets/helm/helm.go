@@ -2,6 +2,7 @@ package helm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/helm"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -45,6 +46,188 @@ func initHelm() []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: helmInstall},
+		{Tool: api.Tool{
+			Name: "helm_template",
+			Description: "Render a Helm chart with values into the manifests it would create, without installing it. " +
+				"The values are validated against the values.schema.json of the chart and the data of the rendered Secrets is redacted. " +
+				"Use server_side for charts using lookup functions and summary to get the rendered resources grouped by kind",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"chart": {
+						Type:        "string",
+						Description: "Chart reference to render (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "Version of the chart to render (Optional, latest version if not provided)",
+					},
+					"values": {
+						Type:        "object",
+						Description: "Values to pass to the Helm chart (Optional)",
+						Properties:  make(map[string]*jsonschema.Schema),
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release used to render the chart (Optional, release-name if not provided)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace used to render the chart (Optional, current namespace if not provided)",
+					},
+					"server_side": {
+						Type:        "boolean",
+						Description: "Render the chart in server-side dry-run mode so that the lookup functions query the cluster, nothing is applied (Optional, client-side rendering if not provided)",
+					},
+					"summary": {
+						Type:        "boolean",
+						Description: "Return the rendered resources grouped by kind instead of the manifests (Optional)",
+					},
+				},
+				Required: []string{"chart"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Template",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmTemplate},
+		{Tool: api.Tool{
+			Name: "helm_show",
+			Description: "Show the Chart.yaml metadata, default values, values.schema.json and README (truncated) of a Helm chart, to pick a chart and its values before installing it. " +
+				"Charts are pulled from OCI registries or HTTPS repositories and cached on local disk when an exact version is provided (e.g. 1.2.3, not a range such as ^1.2)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"chart": {
+						Type:        "string",
+						Description: "Chart reference to show (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress, https://charts.example.com/web-1.0.0.tgz)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "Version of the chart to show (Optional, latest version if not provided)",
+					},
+				},
+				Required: []string{"chart"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Show Chart",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmShow},
+		{Tool: api.Tool{
+			Name: "helm_upgrade",
+			Description: "Upgrade a Helm release in the current or provided namespace to a new chart version or new values. " +
+				"Use helm_upgrade_preview first to get the per-resource diff against the current release manifest without applying it, " +
+				"and atomic to roll the release back automatically if the upgrade fails",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release to upgrade",
+					},
+					"chart": {
+						Type:        "string",
+						Description: "Chart reference to upgrade to (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "Version of the chart to upgrade to (Optional, latest version if not provided)",
+					},
+					"values": {
+						Type:        "object",
+						Description: "Values to pass to the Helm chart (Optional)",
+						Properties:  make(map[string]*jsonschema.Schema),
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"reuse_values": {
+						Type:        "boolean",
+						Description: "Merge the provided values into the values of the current release (Optional, cannot be combined with reset_values)",
+					},
+					"reset_values": {
+						Type:        "boolean",
+						Description: "Discard the values of the current release and only use the chart defaults and the provided values (Optional, cannot be combined with reuse_values)",
+					},
+					"atomic": {
+						Type:        "boolean",
+						Description: "Roll the release back to the previous revision if the upgrade fails, implies wait (Optional)",
+					},
+					"wait": {
+						Type:        "boolean",
+						Description: "Wait until the upgraded resources are ready (Optional, defaults to true)",
+						Default:     api.ToRawMessage(true),
+					},
+					"timeout": {
+						Type:        "string",
+						Description: "Maximum time to wait for the upgraded resources to be ready, e.g. 10m (Optional, defaults to 5m)",
+					},
+				},
+				Required: []string{"name", "chart"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Upgrade",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmUpgrade},
+		{Tool: api.Tool{
+			Name: "helm_upgrade_preview",
+			Description: "Preview the upgrade of a Helm release in the current or provided namespace to a new chart version or new values: " +
+				"render the upgrade without applying it and return the per-resource diff against the current release manifest. " +
+				"Use helm_upgrade to apply the upgrade",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release to preview the upgrade of",
+					},
+					"chart": {
+						Type:        "string",
+						Description: "Chart reference to upgrade to (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "Version of the chart to upgrade to (Optional, latest version if not provided)",
+					},
+					"values": {
+						Type:        "object",
+						Description: "Values to pass to the Helm chart (Optional)",
+						Properties:  make(map[string]*jsonschema.Schema),
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"reuse_values": {
+						Type:        "boolean",
+						Description: "Merge the provided values into the values of the current release (Optional, cannot be combined with reset_values)",
+					},
+					"reset_values": {
+						Type:        "boolean",
+						Description: "Discard the values of the current release and only use the chart defaults and the provided values (Optional, cannot be combined with reuse_values)",
+					},
+				},
+				Required: []string{"name", "chart"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Upgrade Preview",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmUpgradePreview},
 		{Tool: api.Tool{
 			Name:        "helm_list",
 			Description: "List all the Helm releases in the current or provided namespace (or in all namespaces if specified)",
@@ -92,9 +275,153 @@ func initHelm() []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: helmUninstall},
+		{Tool: api.Tool{
+			Name:        "helm_history",
+			Description: "List the revisions of a Helm release in the current or provided namespace, the most recent first, with their status, chart version, app version and description",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"max": {
+						Type:        "integer",
+						Description: "Maximum number of revisions to return (Optional, defaults to 10)",
+						Minimum:     ptr.To(float64(1)),
+						Default:     api.ToRawMessage(defaultHistoryMax),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: History",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmHistory},
+		{Tool: api.Tool{
+			Name: "helm_get",
+			Description: "Get the values (user-supplied or computed), rendered manifest, notes or hooks of a Helm release in the current or provided namespace, optionally at a specific revision. " +
+				"Credentials found in the values and the data of Secrets are redacted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"what": {
+						Type:        "string",
+						Description: "Part of the release to get: values, manifest, notes or hooks",
+						Enum:        []any{helm.GetValues, helm.GetManifest, helm.GetNotes, helm.GetHooks},
+					},
+					"revision": {
+						Type:        "integer",
+						Description: "Revision of the release (Optional, latest revision if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"all_values": {
+						Type:        "boolean",
+						Description: "With what=values, return the computed values merging the chart defaults instead of only the user-supplied values (Optional)",
+					},
+				},
+				Required: []string{"name", "what"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Get",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmGet},
+		{Tool: api.Tool{
+			Name: "helm_drift",
+			Description: "Detect the drift of a Helm release in the current or provided namespace from its stored manifest, e.g. after a kubectl edit: " +
+				"compares every object of the manifest with the live object, ignoring the fields populated by the server, and reports the modified fields, " +
+				"the deleted objects, the extra objects carrying the release labels and the objects of kinds the cluster does not serve",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Drift",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmDrift},
+		{Tool: api.Tool{
+			Name: "helm_rollback",
+			Description: "Roll a Helm release in the current or provided namespace back to a previous revision, creating a new revision with the configuration of the target one. " +
+				"Use helm_history to choose the revision",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release to roll back",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"revision": {
+						Type:        "integer",
+						Description: "Revision to roll back to (Optional, previous revision if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"wait": {
+						Type:        "boolean",
+						Description: "Wait until the rolled back resources are ready (Optional, defaults to true)",
+						Default:     api.ToRawMessage(true),
+					},
+					"cleanup_on_fail": {
+						Type:        "boolean",
+						Description: "Delete the resources created by the rollback if it fails (Optional)",
+					},
+					"timeout": {
+						Type:        "string",
+						Description: "Maximum time to wait for the rolled back resources to be ready, e.g. 10m (Optional, defaults to 5m)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Rollback",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmRollback},
 	}
 }
 
+// defaultHistoryMax is the default number of revisions returned by helm_history.
+const defaultHistoryMax = 10
+
 func newHelmClient(params api.ToolHandlerParams) *helm.Helm {
 	var cfg *helm.Config
 	if c, ok := params.GetToolsetConfig("helm"); ok {
@@ -130,6 +457,100 @@ func helmInstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult(ret, err), nil
 }
 
+func helmTemplate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	chart := p.RequiredString("chart")
+	name := p.OptionalString("name", "")
+	namespace := p.OptionalString("namespace", "")
+	opts := helm.TemplateOptions{
+		Version:    p.OptionalString("version", ""),
+		ServerSide: p.OptionalBool("server_side", false),
+		Summary:    p.OptionalBool("summary", false),
+	}
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to render helm chart: %w", err)), nil
+	}
+	values := map[string]interface{}{}
+	if v, ok := params.GetArguments()["values"].(map[string]interface{}); ok {
+		values = v
+	}
+	ret, err := newHelmClient(params).Template(params, chart, values, name, namespace, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to render helm chart '%s': %w", chart, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmShow(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	chart := p.RequiredString("chart")
+	version := p.OptionalString("version", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to show helm chart: %w", err)), nil
+	}
+	ret, err := newHelmClient(params).Show(chart, version)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to show helm chart '%s': %w", chart, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmUpgrade(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	chart := p.RequiredString("chart")
+	namespace := p.OptionalString("namespace", "")
+	opts := helm.UpgradeOptions{
+		Version:     p.OptionalString("version", ""),
+		ReuseValues: p.OptionalBool("reuse_values", false),
+		ResetValues: p.OptionalBool("reset_values", false),
+		Atomic:      p.OptionalBool("atomic", false),
+		Wait:        p.OptionalBool("wait", true),
+	}
+	timeout := p.OptionalString("timeout", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release: %w", err)), nil
+	}
+	var err error
+	if opts.Timeout, err = parseTimeout(timeout); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release: %w", err)), nil
+	}
+	values := map[string]interface{}{}
+	if v, ok := params.GetArguments()["values"].(map[string]interface{}); ok {
+		values = v
+	}
+	ret, err := newHelmClient(params).Upgrade(params, chart, values, name, namespace, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release '%s': %w", name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmUpgradePreview(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	chart := p.RequiredString("chart")
+	namespace := p.OptionalString("namespace", "")
+	opts := helm.UpgradeOptions{
+		Version:     p.OptionalString("version", ""),
+		ReuseValues: p.OptionalBool("reuse_values", false),
+		ResetValues: p.OptionalBool("reset_values", false),
+		Preview:     true,
+	}
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to preview helm release upgrade: %w", err)), nil
+	}
+	values := map[string]interface{}{}
+	if v, ok := params.GetArguments()["values"].(map[string]interface{}); ok {
+		values = v
+	}
+	ret, err := newHelmClient(params).Upgrade(params, chart, values, name, namespace, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to preview upgrade of helm release '%s': %w", name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
 func helmList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	allNamespaces := p.OptionalBool("all_namespaces", false)
@@ -160,3 +581,85 @@ func helmUninstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
+
+func helmHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	maxRevisions := p.OptionalInt64("max", defaultHistoryMax)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release history: %w", err)), nil
+	}
+	ret, err := newHelmClient(params).History(params.Context, name, namespace, int(maxRevisions))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get history of helm release '%s': %w", name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	what := p.RequiredString("what")
+	namespace := p.OptionalString("namespace", "")
+	revision := p.OptionalInt64("revision", 0)
+	allValues := p.OptionalBool("all_values", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release: %w", err)), nil
+	}
+	ret, err := newHelmClient(params).Get(params.Context, name, namespace, what, int(revision), allValues)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s of helm release '%s': %w", what, name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmDrift(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect helm release drift: %w", err)), nil
+	}
+	ret, err := newHelmClient(params).Drift(params.Context, name, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect drift of helm release '%s': %w", name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmRollback(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	opts := helm.RollbackOptions{
+		Revision:      int(p.OptionalInt64("revision", 0)),
+		Wait:          p.OptionalBool("wait", true),
+		CleanupOnFail: p.OptionalBool("cleanup_on_fail", false),
+	}
+	timeout := p.OptionalString("timeout", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release: %w", err)), nil
+	}
+	var err error
+	if opts.Timeout, err = parseTimeout(timeout); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release: %w", err)), nil
+	}
+	ret, err := newHelmClient(params).Rollback(params.Context, name, namespace, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release '%s': %w", name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+// parseTimeout parses an optional timeout parameter, zero if not provided.
+func parseTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q, use a positive duration such as 10m", timeout)
+	}
+	return d, nil
+}
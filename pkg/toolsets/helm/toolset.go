@@ -1,6 +1,7 @@
 package helm
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -10,6 +11,7 @@ import (
 type Toolset struct{}
 
 var _ api.Toolset = (*Toolset)(nil)
+var _ api.ManifestsProvider = (*Toolset)(nil)
 
 func (t *Toolset) GetName() string {
 	return "helm"
@@ -38,6 +40,23 @@ func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
 	return nil
 }
 
+// DeployedManifests returns the manifests of the deployed Helm releases.
+func (t *Toolset) DeployedManifests(params api.ToolHandlerParams, namespace string) ([]api.DeployedManifest, error) {
+	releases, err := newHelmClient(params).ReleaseManifests(params.Context, namespace, namespace == "")
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]api.DeployedManifest, 0, len(releases))
+	for _, r := range releases {
+		ret = append(ret, api.DeployedManifest{
+			Source:    fmt.Sprintf("helm release %s/%s (revision %d)", r.Namespace, r.Name, r.Revision),
+			Namespace: r.Namespace,
+			Manifest:  r.Manifest,
+		})
+	}
+	return ret, nil
+}
+
 func init() {
 	toolsets.Register(&Toolset{})
 }
@@ -0,0 +1,62 @@
+// Package diagnostics provides the findings and the report sections shared by the diagnose and troubleshoot tools.
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks the findings reported by a diagnose tool.
+type Severity int
+
+const (
+	SeverityCritical Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// Issue is a single finding detected by a diagnose tool, with an optional remediation hint.
+type Issue struct {
+	Severity Severity
+	Message  string
+	Fix      string
+}
+
+// FormatIssues renders the "Detected Issues" and "Suggested Fixes" sections of a diagnostic report.
+func FormatIssues(issues []Issue) string {
+	if len(issues) == 0 {
+		return "## Detected Issues\n\n*No issues automatically detected. Review the raw diagnostic data below for manual analysis.*"
+	}
+
+	var result strings.Builder
+	result.WriteString("## Detected Issues\n\n")
+	for _, iss := range issues {
+		fmt.Fprintf(&result, "- **%s**: %s\n", iss.Severity, iss.Message)
+	}
+
+	var fixes []string
+	for _, iss := range issues {
+		if iss.Fix != "" {
+			fixes = append(fixes, iss.Fix)
+		}
+	}
+	if len(fixes) > 0 {
+		result.WriteString("\n## Suggested Fixes\n\n")
+		for i, fix := range fixes {
+			fmt.Fprintf(&result, "%d. %s\n", i+1, fix)
+		}
+	}
+
+	return result.String()
+}
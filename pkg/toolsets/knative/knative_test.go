@@ -0,0 +1,159 @@
+package knative
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+type KnativeSuite struct {
+	suite.Suite
+}
+
+func TestKnative(t *testing.T) {
+	suite.Run(t, new(KnativeSuite))
+}
+
+func (s *KnativeSuite) service() *service {
+	svc := &service{}
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]any{
+		"metadata": map[string]any{"name": "web", "namespace": "shop"},
+		"status": map[string]any{
+			"url":                       "https://web.shop.example.com",
+			"latestCreatedRevisionName": "web-00003",
+			"latestReadyRevisionName":   "web-00002",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "reason": "RevisionMissing", "message": "Configuration \"web\" does not have any ready Revision."},
+			},
+			"traffic": []any{
+				map[string]any{"revisionName": "web-00002", "percent": int64(90), "latestRevision": true},
+				map[string]any{"revisionName": "web-00001", "percent": int64(10), "tag": "previous", "url": "https://previous-web.shop.example.com"},
+			},
+		},
+	}, svc))
+	return svc
+}
+
+func (s *KnativeSuite) revision(name string, conditions ...any) revision {
+	rev := revision{}
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]any{
+		"metadata": map[string]any{"name": name, "namespace": "shop", "labels": map[string]any{serviceLabel: "web"}},
+		"status":   map[string]any{"conditions": conditions},
+	}, &rev))
+	return rev
+}
+
+func ready(status string) map[string]any {
+	return map[string]any{"type": "Ready", "status": status}
+}
+
+func (s *KnativeSuite) TestSummarizeService() {
+	other := s.revision("api-00001", ready("True"))
+	other.Labels[serviceLabel] = "api"
+	report := summarizeService(s.service(), []revision{
+		s.revision("web-00003", ready("False")),
+		s.revision("web-00002", ready("True")),
+		s.revision("web-00001", ready("True")),
+		other,
+	})
+	s.Equal(serviceReport{
+		Name: "web", Namespace: "shop", URL: "https://web.shop.example.com",
+		Ready: "False", Reason: "RevisionMissing: Configuration \"web\" does not have any ready Revision.",
+		LatestCreatedRevision: "web-00003", LatestReadyRevision: "web-00002",
+		ReadyRevisions: []string{"web-00002", "web-00001"},
+		Traffic: []trafficReport{
+			{Revision: "web-00002", Percent: 90, Latest: true},
+			{Revision: "web-00001", Percent: 10, Tag: "previous", URL: "https://previous-web.shop.example.com"},
+		},
+	}, report)
+}
+
+func (s *KnativeSuite) TestParseTrafficTargets() {
+	s.Run("parses targets", func() {
+		targets, err := parseTrafficTargets([]interface{}{
+			map[string]interface{}{"latest": true, "percent": float64(80)},
+			map[string]interface{}{"revision": "web-00001", "percent": float64(20), "tag": "previous"},
+		})
+		s.Require().NoError(err)
+		s.Equal([]trafficTarget{
+			{LatestRevision: ptr.To(true), Percent: ptr.To(int64(80))},
+			{RevisionName: "web-00001", LatestRevision: ptr.To(false), Percent: ptr.To(int64(20)), Tag: "previous"},
+		}, targets)
+	})
+	s.Run("rejects empty traffic", func() {
+		_, err := parseTrafficTargets([]interface{}{})
+		s.ErrorContains(err, "traffic parameter must be a non-empty array of traffic targets")
+	})
+	s.Run("rejects invalid percentages", func() {
+		_, err := parseTrafficTargets([]interface{}{map[string]interface{}{"revision": "web-00001", "percent": 12.5}})
+		s.ErrorContains(err, "traffic target 1: percent must be an integer between 0 and 100")
+	})
+	s.Run("rejects targets with both revision and latest", func() {
+		_, err := parseTrafficTargets([]interface{}{map[string]interface{}{"revision": "web-00001", "latest": true, "percent": float64(100)}})
+		s.ErrorContains(err, "traffic target 1: provide either a revision or latest")
+	})
+}
+
+func (s *KnativeSuite) TestValidateTraffic() {
+	revisions := []revision{
+		s.revision("web-00002", map[string]any{"type": "Ready", "status": "False", "reason": "ExitCode1", "message": "Container failed with: panic"}),
+		s.revision("web-00001", ready("True")),
+	}
+	target := func(revision string, percent int64) trafficTarget {
+		return trafficTarget{RevisionName: revision, LatestRevision: ptr.To(false), Percent: ptr.To(percent)}
+	}
+	s.Run("accepts a valid split", func() {
+		s.NoError(validateTraffic([]trafficTarget{target("web-00001", 100), target("web-00002", 0)}, revisions))
+	})
+	s.Run("rejects splits not adding up to 100", func() {
+		s.ErrorContains(validateTraffic([]trafficTarget{target("web-00001", 90)}, revisions), "traffic percentages add up to 90, they must add up to 100")
+	})
+	s.Run("rejects unknown revisions", func() {
+		s.ErrorContains(validateTraffic([]trafficTarget{target("api-00001", 100)}, revisions), "revision api-00001 not found in the revisions of the service")
+	})
+	s.Run("rejects traffic to revisions not ready", func() {
+		s.ErrorContains(validateTraffic([]trafficTarget{target("web-00002", 50), target("web-00001", 50)}, revisions),
+			"revision web-00002 is not ready and cannot receive traffic: ExitCode1: Container failed with: panic")
+	})
+	s.Run("rejects duplicated tags", func() {
+		a, b := target("web-00001", 50), target("web-00001", 50)
+		a.Tag, b.Tag = "stable", "stable"
+		s.ErrorContains(validateTraffic([]trafficTarget{a, b}, revisions), "tag stable is used by several traffic targets")
+	})
+}
+
+func (s *KnativeSuite) TestDiagnoseRevisions() {
+	s.Run("flags revisions serving errors", func() {
+		failing := s.revision("web-00002",
+			map[string]any{"type": "Active", "status": "False", "severity": "Info", "reason": "NoTraffic"},
+			map[string]any{"type": "ContainerHealthy", "status": "False", "reason": "ExitCode1", "message": "Container failed with: panic"},
+			map[string]any{"type": "Ready", "status": "False", "reason": "ExitCode1", "message": "Container failed with: panic"},
+		)
+		diagnosis := diagnoseRevisions(s.service(), []revision{
+			s.revision("web-00003", map[string]any{"type": "Ready", "status": "False", "reason": "ProgressDeadlineExceeded"}),
+			failing,
+			s.revision("web-00001", ready("True")),
+		})
+		s.Equal("shop/web", diagnosis.Service)
+		s.Equal("False", diagnosis.Ready)
+		s.Equal([]string{
+			"The Service is not ready: RevisionMissing: Configuration \"web\" does not have any ready Revision.",
+			"The latest created Revision web-00003 failed to become ready, the traffic stays on web-00002: Ready=False (ProgressDeadlineExceeded)",
+			"Revision web-00002 receives 90% of the traffic but is not ready, it is serving errors: " +
+				"ContainerHealthy=False (ExitCode1: Container failed with: panic), Ready=False (ExitCode1: Container failed with: panic)",
+		}, diagnosis.Findings)
+		s.Require().Len(diagnosis.Revisions, 3)
+		s.Equal(int64(90), diagnosis.Revisions[1].Traffic)
+		s.Equal(int64(10), diagnosis.Revisions[2].Traffic)
+		s.Empty(diagnosis.Revisions[2].Conditions)
+	})
+	s.Run("reports healthy services", func() {
+		svc := s.service()
+		svc.Status.Conditions = nil
+		svc.Status.LatestCreatedRevisionName = "web-00002"
+		diagnosis := diagnoseRevisions(svc, []revision{s.revision("web-00002", ready("True")), s.revision("web-00001", ready("True"))})
+		s.Equal([]string{"All the Revisions receiving traffic are ready"}, diagnosis.Findings)
+	})
+}
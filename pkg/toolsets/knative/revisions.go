@@ -0,0 +1,158 @@
+package knative
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// severityInfo is the severity of the informational Revision conditions, e.g. Active when the Revision is scaled to zero.
+const severityInfo = "Info"
+
+func initRevisions(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "knative_revisions_diagnose",
+			Description: "Diagnose the Revisions of a Knative Service to find which one is serving errors: reports the traffic percentage, replicas and failing conditions " +
+				"(Ready, ContainerHealthy, ResourcesAvailable) of each Revision, flags the Revisions receiving traffic while not ready and a latest created Revision that failed to become ready",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Knative Service (Optional, current namespace if not provided)",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the Knative Service",
+					},
+				},
+				Required: []string{"service"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Knative: Diagnose Revisions",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: revisionsDiagnose, TargetCompatibilityFilters: []func() bool{hasKnativeServing(p)}},
+	}
+}
+
+type revisionsDiagnosis struct {
+	Service   string           `json:"service"`
+	Ready     string           `json:"ready"`
+	Findings  []string         `json:"findings"`
+	Revisions []revisionReport `json:"revisions"`
+}
+
+type revisionReport struct {
+	Name       string   `json:"name"`
+	Created    string   `json:"created,omitempty"`
+	Ready      string   `json:"ready"`
+	Traffic    int64    `json:"trafficPercent"`
+	Replicas   string   `json:"replicas,omitempty"`
+	Images     []string `json:"images,omitempty"`
+	Conditions []string `json:"failingConditions,omitempty"`
+}
+
+func revisionsDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.RequiredString("service")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose knative revisions: %w", err)), nil
+	}
+
+	item, err := params.DynamicClient().Resource(serviceGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get knative service %s/%s: %w", namespace, name, err)), nil
+	}
+	svc := &service{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, svc); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to decode knative service %s/%s: %w", namespace, name, err)), nil
+	}
+	revisions, err := listRevisions(params, namespace, metav1.ListOptions{LabelSelector: serviceLabel + "=" + name})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list revisions of knative service %s/%s: %w", namespace, name, err)), nil
+	}
+	ret, err := output.MarshalYaml(diagnoseRevisions(svc, revisions))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose knative revisions: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// diagnoseRevisions reports the Revisions of a Service, the most recent first, and the ones serving errors.
+func diagnoseRevisions(svc *service, revisions []revision) revisionsDiagnosis {
+	diagnosis := revisionsDiagnosis{
+		Service:   svc.Namespace + "/" + svc.Name,
+		Ready:     "Unknown",
+		Findings:  []string{},
+		Revisions: []revisionReport{},
+	}
+	if ready := findCondition(svc.Status.Conditions, "Ready"); ready != nil {
+		diagnosis.Ready = ready.Status
+		if ready.Status != "True" {
+			diagnosis.Findings = append(diagnosis.Findings, "The Service is not ready: "+conditionMessage(ready))
+		}
+	}
+	traffic := map[string]int64{}
+	for _, t := range svc.Status.Traffic {
+		traffic[t.RevisionName] += ptr.Deref(t.Percent, 0)
+	}
+
+	for _, rev := range revisions {
+		report := revisionReport{Name: rev.Name, Ready: "Unknown", Traffic: traffic[rev.Name]}
+		if !rev.CreationTimestamp.IsZero() {
+			report.Created = rev.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		if rev.Status.ActualReplicas != nil || rev.Status.DesiredReplicas != nil {
+			report.Replicas = fmt.Sprintf("%d/%d", ptr.Deref(rev.Status.ActualReplicas, 0), ptr.Deref(rev.Status.DesiredReplicas, 0))
+		}
+		for _, c := range rev.Status.ContainerStatuses {
+			if c.ImageDigest != "" {
+				report.Images = append(report.Images, c.ImageDigest)
+			}
+		}
+		if ready := findCondition(rev.Status.Conditions, "Ready"); ready != nil {
+			report.Ready = ready.Status
+		}
+		for i := range rev.Status.Conditions {
+			c := &rev.Status.Conditions[i]
+			if c.Status != "True" && c.Severity != severityInfo {
+				report.Conditions = append(report.Conditions, fmt.Sprintf("%s=%s (%s)", c.Type, c.Status, conditionMessage(c)))
+			}
+		}
+		diagnosis.Revisions = append(diagnosis.Revisions, report)
+
+		switch {
+		case report.Traffic > 0 && report.Ready != "True":
+			diagnosis.Findings = append(diagnosis.Findings, fmt.Sprintf("Revision %s receives %d%% of the traffic but is not ready, it is serving errors: %s",
+				rev.Name, report.Traffic, failingConditions(report)))
+		case rev.Name == svc.Status.LatestCreatedRevisionName && report.Ready == "False":
+			diagnosis.Findings = append(diagnosis.Findings, fmt.Sprintf("The latest created Revision %s failed to become ready, the traffic stays on %s: %s",
+				rev.Name, cmp.Or(svc.Status.LatestReadyRevisionName, "no ready Revision"), failingConditions(report)))
+		}
+	}
+	if len(diagnosis.Findings) == 0 {
+		diagnosis.Findings = append(diagnosis.Findings, "All the Revisions receiving traffic are ready")
+	}
+	return diagnosis
+}
+
+func failingConditions(report revisionReport) string {
+	if len(report.Conditions) == 0 {
+		return "its conditions are not reported yet"
+	}
+	return strings.Join(report.Conditions, ", ")
+}
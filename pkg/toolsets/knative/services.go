@@ -0,0 +1,242 @@
+package knative
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	servingGroup = "serving.knative.dev"
+	// serviceLabel is the label Knative sets on the Revisions of a Service.
+	serviceLabel = servingGroup + "/service"
+)
+
+var (
+	serviceGVR  = schema.GroupVersionResource{Group: servingGroup, Version: "v1", Resource: "services"}
+	revisionGVR = schema.GroupVersionResource{Group: servingGroup, Version: "v1", Resource: "revisions"}
+)
+
+// hasKnativeServing returns a filter that hides the tools when the Knative Serving API group is not served.
+func hasKnativeServing(p api.FilteringProvider) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{
+			{Group: servingGroup, Version: "v1", Kind: "Service"},
+			{Group: servingGroup, Version: "v1", Kind: "Revision"},
+		})
+	}
+}
+
+func initServices(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "knative_services_list",
+			Description: "List Knative Services with their URL, readiness, latest created and latest ready Revisions, the ready Revisions that can receive traffic, " +
+				"and the current traffic split between Revisions (percent, tag and whether the target follows the latest ready Revision)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Knative Services (Optional, all namespaces if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Knative: List Services",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: servicesList, TargetCompatibilityFilters: []func() bool{hasKnativeServing(p)}},
+	}
+}
+
+type condition struct {
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Severity string `json:"severity,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+type trafficTarget struct {
+	Tag            string `json:"tag,omitempty"`
+	RevisionName   string `json:"revisionName,omitempty"`
+	LatestRevision *bool  `json:"latestRevision,omitempty"`
+	Percent        *int64 `json:"percent,omitempty"`
+	URL            string `json:"url,omitempty"`
+}
+
+// service is the subset of a Knative Service inspected by this toolset.
+type service struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Traffic []trafficTarget `json:"traffic,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		URL                       string          `json:"url,omitempty"`
+		LatestCreatedRevisionName string          `json:"latestCreatedRevisionName,omitempty"`
+		LatestReadyRevisionName   string          `json:"latestReadyRevisionName,omitempty"`
+		Conditions                []condition     `json:"conditions,omitempty"`
+		Traffic                   []trafficTarget `json:"traffic,omitempty"`
+	} `json:"status"`
+}
+
+// revision is the subset of a Knative Revision inspected by this toolset.
+type revision struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		Conditions        []condition `json:"conditions,omitempty"`
+		ActualReplicas    *int32      `json:"actualReplicas,omitempty"`
+		DesiredReplicas   *int32      `json:"desiredReplicas,omitempty"`
+		ContainerStatuses []struct {
+			Name        string `json:"name"`
+			ImageDigest string `json:"imageDigest,omitempty"`
+		} `json:"containerStatuses,omitempty"`
+	} `json:"status"`
+}
+
+type servicesReport struct {
+	Services []serviceReport `json:"services"`
+	Errors   []string        `json:"errors,omitempty"`
+}
+
+type serviceReport struct {
+	Name                  string          `json:"name"`
+	Namespace             string          `json:"namespace"`
+	URL                   string          `json:"url,omitempty"`
+	Ready                 string          `json:"ready"`
+	Reason                string          `json:"reason,omitempty"`
+	LatestCreatedRevision string          `json:"latestCreatedRevision,omitempty"`
+	LatestReadyRevision   string          `json:"latestReadyRevision,omitempty"`
+	ReadyRevisions        []string        `json:"readyRevisions,omitempty"`
+	Traffic               []trafficReport `json:"traffic,omitempty"`
+}
+
+type trafficReport struct {
+	Revision string `json:"revision"`
+	Percent  int64  `json:"percent"`
+	Latest   bool   `json:"latest,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+func servicesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list knative services: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(serviceGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list knative services: %w", err)), nil
+	}
+	report := servicesReport{Services: []serviceReport{}}
+	revisions, err := listRevisions(params, namespace, metav1.ListOptions{})
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to list revisions: %v", err))
+	}
+	for _, item := range list.Items {
+		svc := &service{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, svc); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to decode knative service %s/%s: %v", item.GetNamespace(), item.GetName(), err))
+			continue
+		}
+		report.Services = append(report.Services, summarizeService(svc, revisions))
+	}
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list knative services: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// listRevisions returns the Revisions of a namespace, the most recent first.
+func listRevisions(params api.ToolHandlerParams, namespace string, opts metav1.ListOptions) ([]revision, error) {
+	list, err := params.DynamicClient().Resource(revisionGVR).Namespace(namespace).List(params.Context, opts)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]revision, 0, len(list.Items))
+	for _, item := range list.Items {
+		rev := revision{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &rev); err != nil {
+			return nil, fmt.Errorf("failed to decode revision %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		ret = append(ret, rev)
+	}
+	slices.SortFunc(ret, func(a, b revision) int {
+		return cmp.Or(b.CreationTimestamp.Compare(a.CreationTimestamp.Time), strings.Compare(b.Name, a.Name))
+	})
+	return ret, nil
+}
+
+// summarizeService reports the readiness, ready Revisions and traffic split of a Service, revisions may include the ones of other Services.
+func summarizeService(svc *service, revisions []revision) serviceReport {
+	report := serviceReport{
+		Name:                  svc.Name,
+		Namespace:             svc.Namespace,
+		URL:                   svc.Status.URL,
+		Ready:                 "Unknown",
+		LatestCreatedRevision: svc.Status.LatestCreatedRevisionName,
+		LatestReadyRevision:   svc.Status.LatestReadyRevisionName,
+	}
+	if ready := findCondition(svc.Status.Conditions, "Ready"); ready != nil {
+		report.Ready = ready.Status
+		if ready.Status != "True" {
+			report.Reason = conditionMessage(ready)
+		}
+	}
+	for _, rev := range revisions {
+		if rev.Namespace != svc.Namespace || rev.Labels[serviceLabel] != svc.Name {
+			continue
+		}
+		if ready := findCondition(rev.Status.Conditions, "Ready"); ready != nil && ready.Status == "True" {
+			report.ReadyRevisions = append(report.ReadyRevisions, rev.Name)
+		}
+	}
+	for _, t := range svc.Status.Traffic {
+		report.Traffic = append(report.Traffic, trafficReport{
+			Revision: t.RevisionName,
+			Percent:  ptr.Deref(t.Percent, 0),
+			Latest:   ptr.Deref(t.LatestRevision, false),
+			Tag:      t.Tag,
+			URL:      t.URL,
+		})
+	}
+	return report
+}
+
+func findCondition(conditions []condition, conditionType string) *condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func conditionMessage(c *condition) string {
+	switch {
+	case c.Reason != "" && c.Message != "":
+		return c.Reason + ": " + c.Message
+	case c.Message != "":
+		return c.Message
+	default:
+		return c.Reason
+	}
+}
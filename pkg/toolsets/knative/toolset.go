@@ -0,0 +1,45 @@
+package knative
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides Knative Serving Service, Revision and traffic tools.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "knative"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Knative Serving tools to list Services with their ready Revisions and traffic split, shift the traffic percentages between Revisions, and diagnose which Revision is serving errors from the Revision conditions"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initServices(p),
+		initTraffic(p),
+		initRevisions(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
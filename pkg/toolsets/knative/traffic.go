@@ -0,0 +1,199 @@
+package knative
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+func initTraffic(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "knative_traffic_set",
+			Description: "Set the traffic split of a Knative Service between its Revisions, for instance to canary a new Revision or roll back to a previous one. " +
+				"The provided targets replace the current traffic block, their percentages must add up to 100 and the Revisions receiving traffic must be ready. " +
+				"Use knative_services_list to get the current split and the ready Revisions",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Knative Service (Optional, current namespace if not provided)",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the Knative Service",
+					},
+					"traffic": {
+						Type:        "array",
+						Description: "Traffic targets, e.g. [{\"revision\": \"web-00002\", \"percent\": 90}, {\"revision\": \"web-00001\", \"percent\": 10, \"tag\": \"previous\"}]",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"revision": {
+									Type:        "string",
+									Description: "Name of the Revision receiving the traffic, omit it and set latest to follow the latest ready Revision",
+								},
+								"latest": {
+									Type:        "boolean",
+									Description: "Send the traffic to the latest ready Revision, updated on each new deployment (Optional)",
+								},
+								"percent": {
+									Type:        "integer",
+									Description: "Percentage of the traffic, 0 to 100",
+									Minimum:     ptr.To(float64(0)),
+									Maximum:     ptr.To(float64(100)),
+								},
+								"tag": {
+									Type:        "string",
+									Description: "Tag exposing the target on its own URL, e.g. previous (Optional)",
+								},
+							},
+							Required: []string{"percent"},
+						},
+					},
+				},
+				Required: []string{"service", "traffic"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Knative: Set Traffic",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: trafficSet, TargetCompatibilityFilters: []func() bool{hasKnativeServing(p)}},
+	}
+}
+
+func trafficSet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.RequiredString("service")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set knative traffic: %w", err)), nil
+	}
+	targets, err := parseTrafficTargets(params.GetArguments()["traffic"])
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set knative traffic: %w", err)), nil
+	}
+
+	if _, err = params.DynamicClient().Resource(serviceGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get knative service %s/%s: %w", namespace, name, err)), nil
+	}
+	revisions, err := listRevisions(params, namespace, metav1.ListOptions{LabelSelector: serviceLabel + "=" + name})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list revisions of knative service %s/%s: %w", namespace, name, err)), nil
+	}
+	if err = validateTraffic(targets, revisions); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set knative traffic of %s/%s: %w", namespace, name, err)), nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"traffic": targets}})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set knative traffic: %w", err)), nil
+	}
+	if _, err = params.DynamicClient().Resource(serviceGVR).Namespace(namespace).
+		Patch(params.Context, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set knative traffic of %s/%s: %w", namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Traffic of Knative Service '%s' in namespace '%s' set to: %s. "+
+		"Use knative_services_list to check that the Route has applied the new split", name, namespace, formatTraffic(targets)), nil), nil
+}
+
+// parseTrafficTargets converts the traffic parameter into Knative traffic targets.
+func parseTrafficTargets(val any) ([]trafficTarget, error) {
+	items, ok := val.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, errors.New("traffic parameter must be a non-empty array of traffic targets")
+	}
+	ret := make([]trafficTarget, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("traffic target %d must be an object", i+1)
+		}
+		revision, _ := m["revision"].(string)
+		latest, _ := m["latest"].(bool)
+		tag, _ := m["tag"].(string)
+		percent, ok := m["percent"].(float64)
+		if !ok || percent != math.Trunc(percent) || percent < 0 || percent > 100 {
+			return nil, fmt.Errorf("traffic target %d: percent must be an integer between 0 and 100", i+1)
+		}
+		if (revision == "") == !latest {
+			return nil, fmt.Errorf("traffic target %d: provide either a revision or latest", i+1)
+		}
+		ret = append(ret, trafficTarget{
+			RevisionName:   revision,
+			LatestRevision: ptr.To(latest),
+			Percent:        ptr.To(int64(percent)),
+			Tag:            tag,
+		})
+	}
+	return ret, nil
+}
+
+// validateTraffic checks that the traffic targets add up to 100 and only send traffic to ready Revisions of the Service.
+func validateTraffic(targets []trafficTarget, revisions []revision) error {
+	var total int64
+	tags := map[string]bool{}
+	for _, t := range targets {
+		total += ptr.Deref(t.Percent, 0)
+		if t.Tag != "" {
+			if tags[t.Tag] {
+				return fmt.Errorf("tag %s is used by several traffic targets", t.Tag)
+			}
+			tags[t.Tag] = true
+		}
+		if t.RevisionName == "" {
+			continue
+		}
+		idx := -1
+		for i := range revisions {
+			if revisions[i].Name == t.RevisionName {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("revision %s not found in the revisions of the service", t.RevisionName)
+		}
+		ready := findCondition(revisions[idx].Status.Conditions, "Ready")
+		if ptr.Deref(t.Percent, 0) > 0 && (ready == nil || ready.Status != "True") {
+			reason := "its Ready condition is not reported yet"
+			if ready != nil {
+				reason = conditionMessage(ready)
+			}
+			return fmt.Errorf("revision %s is not ready and cannot receive traffic: %s", t.RevisionName, reason)
+		}
+	}
+	if total != 100 {
+		return fmt.Errorf("traffic percentages add up to %d, they must add up to 100", total)
+	}
+	return nil
+}
+
+func formatTraffic(targets []trafficTarget) string {
+	parts := make([]string, 0, len(targets))
+	for _, t := range targets {
+		target := t.RevisionName
+		if ptr.Deref(t.LatestRevision, false) {
+			target = "latest ready revision"
+		}
+		part := fmt.Sprintf("%s %d%%", target, ptr.Deref(t.Percent, 0))
+		if t.Tag != "" {
+			part += " (tag " + t.Tag + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -8,39 +8,15 @@ import (
 	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 )
 
-type severity int
-
-const (
-	severityCritical severity = iota
-	severityWarning
-	severityInfo
-)
-
-func (s severity) String() string {
-	switch s {
-	case severityCritical:
-		return "CRITICAL"
-	case severityWarning:
-		return "WARNING"
-	default:
-		return "INFO"
-	}
-}
-
-type issue struct {
-	Severity severity
-	Message  string
-	Fix      string
-}
-
 func analyzeIssues(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string, vm, vmi *unstructured.Unstructured, podDiagnostics []map[string]interface{}) string {
-	var issues []issue
+	var issues []diagnostics.Issue
 
 	issues = append(issues, checkVMConditions(vm)...)
 
@@ -53,38 +29,15 @@ func analyzeIssues(ctx context.Context, dynamicClient dynamic.Interface, namespa
 	issues = append(issues, checkPodHealth(podDiagnostics)...)
 	issues = append(issues, checkMigrationStatus(ctx, dynamicClient, namespace, name)...)
 
-	if len(issues) == 0 {
-		return "## Detected Issues\n\n*No issues automatically detected. Review the raw diagnostic data below for manual analysis.*"
-	}
-
-	var result strings.Builder
-	result.WriteString("## Detected Issues\n\n")
-	for _, iss := range issues {
-		fmt.Fprintf(&result, "- **%s**: %s\n", iss.Severity, iss.Message)
-	}
-
-	var fixes []string
-	for _, iss := range issues {
-		if iss.Fix != "" {
-			fixes = append(fixes, iss.Fix)
-		}
-	}
-	if len(fixes) > 0 {
-		result.WriteString("\n## Suggested Fixes\n\n")
-		for i, fix := range fixes {
-			fmt.Fprintf(&result, "%d. %s\n", i+1, fix)
-		}
-	}
-
-	return result.String()
+	return diagnostics.FormatIssues(issues)
 }
 
-func checkVMConditions(vm *unstructured.Unstructured) []issue {
+func checkVMConditions(vm *unstructured.Unstructured) []diagnostics.Issue {
 	if vm == nil {
 		return nil
 	}
 
-	var issues []issue
+	var issues []diagnostics.Issue
 
 	conditions, _, _ := unstructured.NestedSlice(vm.Object, "status", "conditions")
 	for _, cond := range conditions {
@@ -103,14 +56,14 @@ func checkVMConditions(vm *unstructured.Unstructured) []issue {
 
 		switch reason {
 		case "VMINotExists":
-			issues = append(issues, issue{
-				Severity: severityWarning,
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
 				Message:  "VM condition Ready=False with reason VMINotExists. The VirtualMachineInstance has not been created.",
 			})
 		default:
 			if message != "" && (strings.Contains(message, "Guest") || strings.Contains(strings.ToLower(message), "error")) {
-				issues = append(issues, issue{
-					Severity: severityWarning,
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityWarning,
 					Message:  fmt.Sprintf("VM condition Ready=False: %s", message),
 				})
 			}
@@ -120,19 +73,19 @@ func checkVMConditions(vm *unstructured.Unstructured) []issue {
 	printableStatus, _, _ := unstructured.NestedString(vm.Object, "status", "printableStatus")
 	switch printableStatus {
 	case "Provisioning":
-		issues = append(issues, issue{
-			Severity: severityWarning,
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityWarning,
 			Message:  "VM is stuck in Provisioning state. This typically means a DataVolume or PVC cannot be created.",
 		})
 	case "CrashLoopBackOff":
-		issues = append(issues, issue{
-			Severity: severityCritical,
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityCritical,
 			Message:  "VM is in CrashLoopBackOff state. The guest OS or cloud-init is causing repeated failures.",
 			Fix:      "Check the cloud-init userData for commands that shut down or crash the guest (e.g., shutdown -h now, exit 1).",
 		})
 	case "ErrImagePull", "ImagePullBackOff":
-		issues = append(issues, issue{
-			Severity: severityCritical,
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityCritical,
 			Message:  fmt.Sprintf("VM is in %s state. The container disk image cannot be pulled.", printableStatus),
 			Fix:      "Verify the containerDisk image reference exists and is accessible from the cluster.",
 		})
@@ -141,20 +94,20 @@ func checkVMConditions(vm *unstructured.Unstructured) []issue {
 	return issues
 }
 
-func checkDataVolumeErrors(vm *unstructured.Unstructured, scIssues []issue) []issue {
+func checkDataVolumeErrors(vm *unstructured.Unstructured, scIssues []diagnostics.Issue) []diagnostics.Issue {
 	if vm == nil {
 		return nil
 	}
 
 	hasMissingSC := false
 	for _, sci := range scIssues {
-		if sci.Severity == severityCritical {
+		if sci.Severity == diagnostics.SeverityCritical {
 			hasMissingSC = true
 			break
 		}
 	}
 
-	var issues []issue
+	var issues []diagnostics.Issue
 
 	volumeStatuses, _, _ := unstructured.NestedSlice(vm.Object, "status", "volumeSnapshotStatuses")
 	for _, vs := range volumeStatuses {
@@ -168,8 +121,8 @@ func checkDataVolumeErrors(vm *unstructured.Unstructured, scIssues []issue) []is
 			if hasMissingSC {
 				continue
 			}
-			issues = append(issues, issue{
-				Severity: severityCritical,
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
 				Message:  fmt.Sprintf("Volume %q: PVC not found. The backing storage does not exist.", volName),
 				Fix:      fmt.Sprintf("Ensure the PVC or DataVolume for volume %q is created and in Bound state.", volName),
 			})
@@ -179,7 +132,7 @@ func checkDataVolumeErrors(vm *unstructured.Unstructured, scIssues []issue) []is
 	return issues
 }
 
-func checkStorageClass(ctx context.Context, dynamicClient dynamic.Interface, vm *unstructured.Unstructured) []issue {
+func checkStorageClass(ctx context.Context, dynamicClient dynamic.Interface, vm *unstructured.Unstructured) []diagnostics.Issue {
 	if vm == nil || dynamicClient == nil {
 		return nil
 	}
@@ -189,7 +142,7 @@ func checkStorageClass(ctx context.Context, dynamicClient dynamic.Interface, vm
 		return nil
 	}
 
-	var issues []issue
+	var issues []diagnostics.Issue
 	for _, dvTemplate := range dvTemplates {
 		dvMap, ok := dvTemplate.(map[string]interface{})
 		if !ok {
@@ -211,15 +164,15 @@ func checkStorageClass(ctx context.Context, dynamicClient dynamic.Interface, vm
 		_, err := dynamicClient.Resource(kubevirt.StorageClassGVR).Get(ctx, scName, metav1.GetOptions{})
 		if err != nil {
 			if !apierrors.IsNotFound(err) {
-				issues = append(issues, issue{
-					Severity: severityWarning,
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityWarning,
 					Message:  fmt.Sprintf("Unable to verify StorageClass %q for DataVolume %q: %v", scName, dvName, err),
 				})
 				continue
 			}
 			availableSCs := listAvailableStorageClasses(ctx, dynamicClient)
-			issues = append(issues, issue{
-				Severity: severityCritical,
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityCritical,
 				Message:  fmt.Sprintf("StorageClass %q referenced by DataVolume %q does not exist on this cluster. Available StorageClasses: %s.", scName, dvName, availableSCs),
 				Fix:      fmt.Sprintf("Change the DataVolume storageClassName from %q to an existing StorageClass (e.g., %s).", scName, availableSCs),
 			})
@@ -261,7 +214,7 @@ func listAvailableStorageClasses(ctx context.Context, dynamicClient dynamic.Inte
 	return strings.Join(names, ", ")
 }
 
-func checkCloudInitCommands(vm, vmi *unstructured.Unstructured) []issue {
+func checkCloudInitCommands(vm, vmi *unstructured.Unstructured) []diagnostics.Issue {
 	var volumes []interface{}
 	if vm != nil {
 		volumes, _, _ = unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
@@ -278,7 +231,7 @@ func checkCloudInitCommands(vm, vmi *unstructured.Unstructured) []issue {
 		runStrategy, _, _ = unstructured.NestedString(vm.Object, "spec", "runStrategy")
 	}
 
-	var issues []issue
+	var issues []diagnostics.Issue
 
 	for _, vol := range volumes {
 		volMap, ok := vol.(map[string]interface{})
@@ -303,8 +256,8 @@ func checkCloudInitCommands(vm, vmi *unstructured.Unstructured) []issue {
 					msg += " Combined with runStrategy Always, this causes a crashloop."
 				}
 				fix := fmt.Sprintf("Remove the %q command from cloud-init userData, or change runStrategy to Manual/Halted if the shutdown is intentional.", cmd)
-				issues = append(issues, issue{
-					Severity: severityCritical,
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
 					Message:  msg,
 					Fix:      fix,
 				})
@@ -445,7 +398,7 @@ func extractFirstArrayElement(line string) string {
 	return strings.ToLower(elem)
 }
 
-func checkNodeSelector(vm, vmi *unstructured.Unstructured) []issue {
+func checkNodeSelector(vm, vmi *unstructured.Unstructured) []diagnostics.Issue {
 	var nodeSelector map[string]interface{}
 
 	if vm != nil {
@@ -462,8 +415,8 @@ func checkNodeSelector(vm, vmi *unstructured.Unstructured) []issue {
 	for key, val := range nodeSelector {
 		if key == "kubernetes.io/hostname" {
 			hostname, _ := val.(string)
-			return []issue{{
-				Severity: severityWarning,
+			return []diagnostics.Issue{{
+				Severity: diagnostics.SeverityWarning,
 				Message:  fmt.Sprintf("VM is pinned to a specific node via nodeSelector (kubernetes.io/hostname=%s). This prevents live migration to other nodes.", hostname),
 				Fix:      "Remove the kubernetes.io/hostname nodeSelector to allow migration, or use a broader label selector that matches multiple nodes.",
 			}}
@@ -474,24 +427,24 @@ func checkNodeSelector(vm, vmi *unstructured.Unstructured) []issue {
 	for k, v := range nodeSelector {
 		labels = append(labels, fmt.Sprintf("%s=%v", k, v))
 	}
-	return []issue{{
-		Severity: severityInfo,
+	return []diagnostics.Issue{{
+		Severity: diagnostics.SeverityInfo,
 		Message:  fmt.Sprintf("VM has nodeSelector constraints: %s. This may limit scheduling and migration options.", strings.Join(labels, ", ")),
 	}}
 }
 
-func checkPodHealth(podDiagnostics []map[string]interface{}) []issue {
+func checkPodHealth(podDiagnostics []map[string]interface{}) []diagnostics.Issue {
 	if len(podDiagnostics) == 0 {
 		return nil
 	}
 
-	var issues []issue
+	var issues []diagnostics.Issue
 
 	for _, diag := range podDiagnostics {
 		phase, _ := diag["phase"].(string)
 		if phase == "Pending" {
-			issues = append(issues, issue{
-				Severity: severityWarning,
+			issues = append(issues, diagnostics.Issue{
+				Severity: diagnostics.SeverityWarning,
 				Message:  "virt-launcher pod is in Pending state. The pod has not been scheduled yet (possible resource constraints or nodeSelector mismatch).",
 				Fix:      "Check node resources and scheduling constraints. Ensure at least one node satisfies the pod's resource requests and node selectors.",
 			})
@@ -502,8 +455,8 @@ func checkPodHealth(podDiagnostics []map[string]interface{}) []issue {
 			restartCount := toInt64(cs["restartCount"])
 			if restartCount > 3 {
 				containerName, _ := cs["name"].(string)
-				issues = append(issues, issue{
-					Severity: severityCritical,
+				issues = append(issues, diagnostics.Issue{
+					Severity: diagnostics.SeverityCritical,
 					Message:  fmt.Sprintf("Container %q in virt-launcher pod has restarted %d times, indicating a crashloop.", containerName, restartCount),
 				})
 			}
@@ -516,8 +469,8 @@ func checkPodHealth(podDiagnostics []map[string]interface{}) []issue {
 				reason, _ := waiting["reason"].(string)
 				if reason == "CrashLoopBackOff" || reason == "ErrImagePull" || reason == "ImagePullBackOff" {
 					message, _ := waiting["message"].(string)
-					issues = append(issues, issue{
-						Severity: severityCritical,
+					issues = append(issues, diagnostics.Issue{
+						Severity: diagnostics.SeverityCritical,
 						Message:  fmt.Sprintf("virt-launcher container is in %s state: %s", reason, message),
 					})
 				}
@@ -525,8 +478,8 @@ func checkPodHealth(podDiagnostics []map[string]interface{}) []issue {
 			if terminated, ok := state["terminated"].(map[string]interface{}); ok {
 				reason, _ := terminated["reason"].(string)
 				if reason == "OOMKilled" {
-					issues = append(issues, issue{
-						Severity: severityCritical,
+					issues = append(issues, diagnostics.Issue{
+						Severity: diagnostics.SeverityCritical,
 						Message:  "virt-launcher container was OOMKilled. The VM requires more memory than allocated.",
 						Fix:      "Increase the memory resource limits for the VM or reduce the guest memory requirements.",
 					})
@@ -540,7 +493,7 @@ func checkPodHealth(podDiagnostics []map[string]interface{}) []issue {
 
 const maxReportedFailedMigrations = 3
 
-func checkMigrationStatus(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) []issue {
+func checkMigrationStatus(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) []diagnostics.Issue {
 	if dynamicClient == nil {
 		return nil
 	}
@@ -574,7 +527,7 @@ func checkMigrationStatus(ctx context.Context, dynamicClient dynamic.Interface,
 		return ti.After(tj)
 	})
 
-	var issues []issue
+	var issues []diagnostics.Issue
 	now := time.Now()
 	limit := maxReportedFailedMigrations
 	if len(failed) < limit {
@@ -594,16 +547,16 @@ func checkMigrationStatus(ctx context.Context, dynamicClient dynamic.Interface,
 		if failureReason != "" {
 			msg += " Reason: " + failureReason
 		}
-		issues = append(issues, issue{
-			Severity: severityCritical,
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityCritical,
 			Message:  msg,
 			Fix:      "Check nodeSelector constraints, resource availability on target nodes, and migration policies. Remove hostname-specific nodeSelector to allow migration.",
 		})
 	}
 
 	if len(failed) > maxReportedFailedMigrations {
-		issues = append(issues, issue{
-			Severity: severityInfo,
+		issues = append(issues, diagnostics.Issue{
+			Severity: diagnostics.SeverityInfo,
 			Message:  fmt.Sprintf("%d additional older failed migrations not shown.", len(failed)-maxReportedFailedMigrations),
 		})
 	}
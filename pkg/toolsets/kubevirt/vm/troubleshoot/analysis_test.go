@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/internal/diagnostics"
 	"github.com/stretchr/testify/suite"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -46,7 +47,7 @@ func (s *AnalysisSuite) TestCheckVMConditions() {
 		issues := checkVMConditions(vm)
 		s.Require().NotEmpty(issues)
 		s.Contains(issues[0].Message, "VMINotExists")
-		s.Equal(severityWarning, issues[0].Severity)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
 	})
 
 	s.Run("detects Provisioning printableStatus", func() {
@@ -64,7 +65,7 @@ func (s *AnalysisSuite) TestCheckVMConditions() {
 		s.Require().NotEmpty(issues)
 		found := false
 		for _, iss := range issues {
-			if iss.Severity == severityWarning && strings.Contains(iss.Message, "Provisioning") {
+			if iss.Severity == diagnostics.SeverityWarning && strings.Contains(iss.Message, "Provisioning") {
 				found = true
 				break
 			}
@@ -85,7 +86,7 @@ func (s *AnalysisSuite) TestCheckVMConditions() {
 
 		issues := checkVMConditions(vm)
 		s.Require().NotEmpty(issues)
-		s.Equal(severityCritical, issues[0].Severity)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
 		s.Contains(issues[0].Message, "CrashLoopBackOff")
 		s.NotEmpty(issues[0].Fix)
 	})
@@ -136,7 +137,7 @@ func (s *AnalysisSuite) TestCheckDataVolumeErrors() {
 
 		issues := checkDataVolumeErrors(vm, nil)
 		s.Require().Len(issues, 1)
-		s.Equal(severityCritical, issues[0].Severity)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
 		s.Contains(issues[0].Message, "rootdisk")
 		s.Contains(issues[0].Message, "PVC not found")
 	})
@@ -157,8 +158,8 @@ func (s *AnalysisSuite) TestCheckDataVolumeErrors() {
 			},
 		})
 
-		scIssues := []issue{{
-			Severity: severityCritical,
+		scIssues := []diagnostics.Issue{{
+			Severity: diagnostics.SeverityCritical,
 			Message:  "StorageClass \"premium-nvme\" does not exist",
 		}}
 
@@ -229,7 +230,7 @@ func (s *AnalysisSuite) TestCheckStorageClass() {
 
 		issues := checkStorageClass(ctx, client, vm)
 		s.Require().Len(issues, 1)
-		s.Equal(severityCritical, issues[0].Severity)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
 		s.Contains(issues[0].Message, "premium-nvme-storage")
 		s.Contains(issues[0].Message, "does not exist")
 		s.Contains(issues[0].Message, "standard-csi (default)")
@@ -316,7 +317,7 @@ func (s *AnalysisSuite) TestCheckCloudInitCommands() {
 
 		issues := checkCloudInitCommands(vm, nil)
 		s.Require().Len(issues, 1)
-		s.Equal(severityCritical, issues[0].Severity)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
 		s.Contains(issues[0].Message, "shutdown")
 		s.Contains(issues[0].Message, "crashloop")
 		s.NotEmpty(issues[0].Fix)
@@ -666,7 +667,7 @@ func (s *AnalysisSuite) TestCheckNodeSelector() {
 
 		issues := checkNodeSelector(vm, nil)
 		s.Require().Len(issues, 1)
-		s.Equal(severityWarning, issues[0].Severity)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
 		s.Contains(issues[0].Message, "worker-0")
 		s.Contains(issues[0].Message, "prevents live migration")
 		s.NotEmpty(issues[0].Fix)
@@ -691,7 +692,7 @@ func (s *AnalysisSuite) TestCheckNodeSelector() {
 
 		issues := checkNodeSelector(vm, nil)
 		s.Require().Len(issues, 1)
-		s.Equal(severityInfo, issues[0].Severity)
+		s.Equal(diagnostics.SeverityInfo, issues[0].Severity)
 	})
 
 	s.Run("returns nil when no nodeSelector", func() {
@@ -738,7 +739,7 @@ func (s *AnalysisSuite) TestCheckPodHealth() {
 
 		issues := checkPodHealth(podDiags)
 		s.Require().Len(issues, 1)
-		s.Equal(severityWarning, issues[0].Severity)
+		s.Equal(diagnostics.SeverityWarning, issues[0].Severity)
 		s.Contains(issues[0].Message, "Pending")
 	})
 
@@ -768,7 +769,7 @@ func (s *AnalysisSuite) TestCheckPodHealth() {
 		for _, iss := range issues {
 			if strings.Contains(iss.Message, "CrashLoopBackOff") {
 				foundCrashloop = true
-				s.Equal(severityCritical, iss.Severity)
+				s.Equal(diagnostics.SeverityCritical, iss.Severity)
 			}
 		}
 		s.True(foundCrashloop)
@@ -799,7 +800,7 @@ func (s *AnalysisSuite) TestCheckPodHealth() {
 		for _, iss := range issues {
 			if strings.Contains(iss.Message, "OOMKilled") {
 				found = true
-				s.Equal(severityCritical, iss.Severity)
+				s.Equal(diagnostics.SeverityCritical, iss.Severity)
 				s.NotEmpty(iss.Fix)
 			}
 		}
@@ -899,7 +900,7 @@ func (s *AnalysisSuite) TestCheckMigrationStatus() {
 
 		issues := checkMigrationStatus(ctx, client, "test-ns", "test-vm")
 		s.Require().Len(issues, 1)
-		s.Equal(severityCritical, issues[0].Severity)
+		s.Equal(diagnostics.SeverityCritical, issues[0].Severity)
 		s.Contains(issues[0].Message, "test-vm-migration")
 		s.Contains(issues[0].Message, "failed")
 		s.Contains(issues[0].Message, "no suitable target node found")
@@ -1041,7 +1042,7 @@ func (s *AnalysisSuite) TestCheckMigrationStatus() {
 		s.Contains(issues[0].Message, "mig-0")
 		s.Contains(issues[1].Message, "mig-1")
 		s.Contains(issues[2].Message, "mig-2")
-		s.Equal(severityInfo, issues[3].Severity)
+		s.Equal(diagnostics.SeverityInfo, issues[3].Severity)
 		s.Contains(issues[3].Message, "2 additional older failed migrations not shown")
 	})
 }
@@ -0,0 +1,74 @@
+package loki
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/loki"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initLabels() []api.ServerTool {
+	properties := commonProperties()
+	properties["label"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Label name whose values are returned, e.g. kubernetes_namespace_name (Optional, label names are returned if not provided)",
+	}
+	properties["selector"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Stream selector restricting the streams whose labels are considered, e.g. {kubernetes_namespace_name=\"my-app\"} (Optional)",
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "loki_labels",
+			Description: "Discover the Loki stream label names, or the values of a label when label is provided. " +
+				"Use it to build the stream selectors of loki_query and loki_patterns",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Loki: Labels",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: lokiLabels},
+	}
+}
+
+func lokiLabels(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	tenant := p.OptionalString("tenant", loki.DefaultTenant)
+	label := p.OptionalString("label", "")
+	selector := p.OptionalString("selector", "")
+	start, end, err := timeRange(p)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki labels: %w", err)), nil
+	}
+	client, err := loki.NewLoki(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki labels: %w", err)), nil
+	}
+	var labels []string
+	if label == "" {
+		labels, err = client.Labels(params.Context, tenant, selector, start, end)
+	} else {
+		labels, err = client.LabelValues(params.Context, tenant, label, selector, start, end)
+	}
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki labels: %w", err)), nil
+	}
+	if len(labels) == 0 {
+		return api.NewToolCallResult("No labels found", nil), nil
+	}
+	out, err := output.MarshalYaml(labels)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal loki labels: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
@@ -0,0 +1,59 @@
+package loki
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/loki"
+)
+
+type LokiSuite struct {
+	suite.Suite
+}
+
+func (s *LokiSuite) TestStreamsReport() {
+	t0 := time.Unix(1700000000, 0).UTC()
+	s.Run("groups entries by stream", func() {
+		report := streamsReport([]loki.Stream{
+			{Labels: map[string]string{"pod": "a"}, Entries: []loki.Entry{{Time: t0, Line: "one"}, {Time: t0, Line: "two"}}},
+			{Labels: map[string]string{"pod": "b"}, Entries: []loki.Entry{{Time: t0, Line: "three"}}},
+		}, 100)
+		s.Equal(3, report.Entries)
+		s.Require().Len(report.Streams, 2)
+		s.Equal("a", report.Streams[0].Labels["pod"])
+		s.Len(report.Streams[0].Entries, 2)
+		s.Empty(report.Hint)
+	})
+	s.Run("hints when the limit is reached", func() {
+		report := streamsReport([]loki.Stream{
+			{Labels: map[string]string{"pod": "a"}, Entries: []loki.Entry{{Time: t0, Line: "one"}, {Time: t0, Line: "two"}}},
+		}, 2)
+		s.Contains(report.Hint, "the limit of 2 log lines was reached")
+	})
+	s.Run("truncates long lines", func() {
+		report := streamsReport([]loki.Stream{
+			{Labels: map[string]string{"pod": "a"}, Entries: []loki.Entry{{Time: t0, Line: strings.Repeat("x", maxLineLength+10)}}},
+		}, 100)
+		s.Equal(strings.Repeat("x", maxLineLength)+"... [10 bytes truncated]", report.Streams[0].Entries[0].Line)
+	})
+	s.Run("caps the output size", func() {
+		var entries []loki.Entry
+		for range 2 * maxOutputBytes / maxLineLength {
+			entries = append(entries, loki.Entry{Time: t0, Line: strings.Repeat("x", maxLineLength)})
+		}
+		report := streamsReport([]loki.Stream{
+			{Labels: map[string]string{"pod": "a"}, Entries: entries},
+			{Labels: map[string]string{"pod": "b"}, Entries: entries},
+		}, 2000)
+		s.Equal(maxOutputBytes/maxLineLength, report.Entries)
+		s.Len(report.Streams, 1)
+		s.Contains(report.Hint, "log lines are shown to limit the output size")
+	})
+}
+
+func TestLoki(t *testing.T) {
+	suite.Run(t, new(LokiSuite))
+}
@@ -0,0 +1,59 @@
+package loki
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/loki"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+)
+
+// commonProperties returns the tenant and time range properties shared by all the Loki tools.
+func commonProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"tenant": {
+			Type:        "string",
+			Description: fmt.Sprintf("LokiStack tenant to query, ignored when Loki is not served through a LokiStack gateway (Optional, defaults to %s)", loki.DefaultTenant),
+			Enum:        []any{"application", "infrastructure", "audit"},
+			Default:     api.ToRawMessage(loki.DefaultTenant),
+		},
+		"start": {
+			Type:        "string",
+			Description: "Start of the range as RFC3339, Unix timestamp, or relative time such as -1h (Optional, defaults to -1h)",
+			Default:     api.ToRawMessage("-1h"),
+		},
+		"end": {
+			Type:        "string",
+			Description: "End of the range as RFC3339, Unix timestamp, or relative time such as -5m (Optional, defaults to now)",
+			Default:     api.ToRawMessage("now"),
+		},
+	}
+}
+
+// timeRange extracts and parses the start and end parameters.
+func timeRange(p *api.Params) (start, end time.Time, err error) {
+	startParam := p.OptionalString("start", "-1h")
+	endParam := p.OptionalString("end", "now")
+	if err = p.Err(); err != nil {
+		return
+	}
+	now := time.Now()
+	if start, err = observability.ParseTime(startParam, now); err != nil {
+		return start, end, fmt.Errorf("start: %w", err)
+	}
+	if end, err = observability.ParseTime(endParam, now); err != nil {
+		return start, end, fmt.Errorf("end: %w", err)
+	}
+	if !end.After(start) {
+		err = fmt.Errorf("end (%s) must be after start (%s)", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	return
+}
+
+// clamp bounds v to [lo, hi].
+func clamp(v, lo, hi int64) int {
+	return int(min(max(v, lo), hi))
+}
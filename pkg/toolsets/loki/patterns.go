@@ -0,0 +1,108 @@
+package loki
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/loki"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	defaultPatternsLines = 1000
+	maxPatternsLines     = 5000
+	defaultMaxPatterns   = 20
+	maxMaxPatterns       = 200
+)
+
+func initPatterns() []api.ServerTool {
+	properties := commonProperties()
+	properties["query"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "LogQL log query selecting the lines to analyze, e.g. {kubernetes_namespace_name=\"my-app\"} |= \"error\"",
+	}
+	properties["lines"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: fmt.Sprintf("Number of most recent log lines analyzed (Optional, defaults to %d, max %d)", defaultPatternsLines, maxPatternsLines),
+		Default:     api.ToRawMessage(defaultPatternsLines),
+		Minimum:     ptr.To(float64(1)),
+		Maximum:     ptr.To(float64(maxPatternsLines)),
+	}
+	properties["max_patterns"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: fmt.Sprintf("Maximum number of patterns to return (Optional, defaults to %d, max %d)", defaultMaxPatterns, maxMaxPatterns),
+		Default:     api.ToRawMessage(defaultMaxPatterns),
+		Minimum:     ptr.To(float64(1)),
+		Maximum:     ptr.To(float64(maxMaxPatterns)),
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "loki_patterns",
+			Description: "Aggregate the most recent Loki log lines matching a LogQL query into patterns, masking variable parts (timestamps, IDs, IPs, numbers). " +
+				"Returns the most frequent patterns with their number of occurrences, the number of streams they appear in, and an example line. " +
+				"Use it to get an overview of noisy logs before querying specific lines with loki_query",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Loki: Log Patterns",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: lokiPatterns},
+	}
+}
+
+type patternsReport struct {
+	Lines    int            `json:"lines"`
+	Patterns int            `json:"patterns"`
+	Top      []loki.Pattern `json:"top"`
+}
+
+func lokiPatterns(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	query := p.RequiredString("query")
+	tenant := p.OptionalString("tenant", loki.DefaultTenant)
+	lines := clamp(p.OptionalInt64("lines", defaultPatternsLines), 1, maxPatternsLines)
+	maxPatterns := clamp(p.OptionalInt64("max_patterns", defaultMaxPatterns), 1, maxMaxPatterns)
+	start, end, err := timeRange(p)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki log patterns: %w", err)), nil
+	}
+	client, err := loki.NewLoki(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki log patterns: %w", err)), nil
+	}
+	result, err := client.QueryRange(params.Context, tenant, query, loki.QueryRangeOptions{Start: start, End: end, Limit: lines})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki log patterns: %w", err)), nil
+	}
+	if result.ResultType != "streams" {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get loki log patterns: query must be a log query, got a %s result", result.ResultType)), nil
+	}
+	report := &patternsReport{}
+	for _, stream := range result.Streams {
+		report.Lines += len(stream.Entries)
+	}
+	if report.Lines == 0 {
+		return api.NewToolCallResult("No logs found", nil), nil
+	}
+	patterns := loki.Patterns(result.Streams)
+	report.Patterns = len(patterns)
+	report.Top = patterns[:min(len(patterns), maxPatterns)]
+	for i := range report.Top {
+		report.Top[i].Example = truncateLine(report.Top[i].Example)
+	}
+	out, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal loki log patterns: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
@@ -0,0 +1,182 @@
+package loki
+
+import (
+	"fmt"
+	"maps"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/loki"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 2000
+	// maxLineLength caps each returned log line so that a single huge line doesn't fill the context.
+	maxLineLength = 2000
+	// maxOutputBytes caps the size of the log lines returned by a single query.
+	maxOutputBytes = 64 << 10
+	// maxMetricPoints caps the number of points returned per series by metric queries.
+	maxMetricPoints = 60
+	maxMetricSeries = 20
+)
+
+func initQuery() []api.ServerTool {
+	properties := commonProperties()
+	properties["query"] = &jsonschema.Schema{
+		Type: "string",
+		Description: "LogQL query, e.g. {kubernetes_namespace_name=\"my-app\"} |= \"error\" for log lines, " +
+			"or sum by (kubernetes_pod_name) (count_over_time({kubernetes_namespace_name=\"my-app\"} |= \"error\" [5m])) for metrics",
+	}
+	properties["limit"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: fmt.Sprintf("Maximum number of log lines to return (Optional, defaults to %d, max %d)", defaultQueryLimit, maxQueryLimit),
+		Default:     api.ToRawMessage(defaultQueryLimit),
+		Minimum:     ptr.To(float64(1)),
+		Maximum:     ptr.To(float64(maxQueryLimit)),
+	}
+	properties["direction"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Return the newest (backward) or the oldest (forward) log lines first (Optional, defaults to backward)",
+		Enum:        []any{"backward", "forward"},
+		Default:     api.ToRawMessage("backward"),
+	}
+	properties["step"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Resolution step of metric queries as a duration such as 1m (Optional, computed by Loki if not provided)",
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "loki_query",
+			Description: "Run a LogQL range query against Loki to retrieve historical logs, including those of pods and containers that no longer exist. " +
+				"Log lines are grouped by stream labels, long lines are truncated and the output is capped in size. " +
+				fmt.Sprintf("Metric queries return at most %d series downsampled to %d points", maxMetricSeries, maxMetricPoints),
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Loki: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: lokiQuery},
+	}
+}
+
+type entryReport struct {
+	Time time.Time `json:"time"`
+	Line string    `json:"line"`
+}
+
+type streamReport struct {
+	Labels  map[string]string `json:"labels"`
+	Entries []entryReport     `json:"entries"`
+}
+
+type queryReport struct {
+	ResultType string                            `json:"resultType"`
+	Entries    int                               `json:"entries,omitempty"`
+	Streams    []streamReport                    `json:"streams,omitempty"`
+	Series     []observability.DownsampledSeries `json:"series,omitempty"`
+	Hint       string                            `json:"hint,omitempty"`
+}
+
+func lokiQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	query := p.RequiredString("query")
+	tenant := p.OptionalString("tenant", loki.DefaultTenant)
+	limit := clamp(p.OptionalInt64("limit", defaultQueryLimit), 1, maxQueryLimit)
+	direction := p.OptionalString("direction", "backward")
+	stepParam := p.OptionalString("step", "")
+	start, end, err := timeRange(p)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query loki: %w", err)), nil
+	}
+	opts := loki.QueryRangeOptions{Start: start, End: end, Limit: limit, Forward: direction == "forward"}
+	if stepParam != "" {
+		if opts.Step, err = time.ParseDuration(stepParam); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to query loki: invalid step %q: %w", stepParam, err)), nil
+		}
+	}
+	client, err := loki.NewLoki(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query loki: %w", err)), nil
+	}
+	result, err := client.QueryRange(params.Context, tenant, query, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query loki: %w", err)), nil
+	}
+	var report *queryReport
+	if result.ResultType == "streams" {
+		report = streamsReport(result.Streams, limit)
+	} else {
+		report = &queryReport{ResultType: result.ResultType}
+		for _, series := range result.Matrix[:min(len(result.Matrix), maxMetricSeries)] {
+			report.Series = append(report.Series, observability.Downsample(series, maxMetricPoints))
+		}
+		if len(result.Matrix) > maxMetricSeries {
+			report.Hint = fmt.Sprintf("only the first %d of %d series are shown, aggregate the query (e.g. topk or sum by) to reduce the number of series", maxMetricSeries, len(result.Matrix))
+		}
+	}
+	if report.Entries == 0 && len(report.Series) == 0 {
+		return api.NewToolCallResult("No logs found", nil), nil
+	}
+	out, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal loki query result: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
+
+// streamsReport groups the log lines by stream, truncating long lines and stopping once the output budget is exhausted.
+func streamsReport(streams []loki.Stream, limit int) *queryReport {
+	report := &queryReport{ResultType: "streams"}
+	total, budget := 0, maxOutputBytes
+	for _, stream := range streams {
+		total += len(stream.Entries)
+		if budget <= 0 {
+			continue
+		}
+		sr := streamReport{Labels: maps.Clone(stream.Labels)}
+		for _, entry := range stream.Entries {
+			line := truncateLine(entry.Line)
+			budget -= len(line)
+			if budget < 0 {
+				break
+			}
+			sr.Entries = append(sr.Entries, entryReport{Time: entry.Time, Line: line})
+		}
+		if len(sr.Entries) > 0 {
+			report.Streams = append(report.Streams, sr)
+			report.Entries += len(sr.Entries)
+		}
+	}
+	switch {
+	case report.Entries < total:
+		report.Hint = fmt.Sprintf("only %d of %d log lines are shown to limit the output size, narrow down the query with label matchers or line filters", report.Entries, total)
+	case total >= limit:
+		report.Hint = fmt.Sprintf("the limit of %d log lines was reached, more lines may match: narrow down the time range or the query", limit)
+	}
+	return report
+}
+
+func truncateLine(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+	cut := maxLineLength
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [%d bytes truncated]", line[:cut], len(line)-cut)
+}
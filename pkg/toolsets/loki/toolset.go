@@ -0,0 +1,44 @@
+package loki
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "loki"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Loki log tools (LogQL queries, label discovery, log pattern aggregation) to reach the logs of containers that no longer exist. Check the [Loki documentation](https://github.com/containers/kubernetes-mcp-server/blob/main/docs/LOKI.md) for more details."
+}
+
+func (t *Toolset) GetTools(_ api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initQuery(),
+		initLabels(),
+		initPatterns(),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
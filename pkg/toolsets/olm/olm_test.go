@@ -0,0 +1,190 @@
+package olm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type OLMSuite struct {
+	suite.Suite
+}
+
+func TestOLM(t *testing.T) {
+	suite.Run(t, new(OLMSuite))
+}
+
+func (s *OLMSuite) decode(obj map[string]any, into any) {
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, into))
+}
+
+func (s *OLMSuite) subscription(status map[string]any) *subscription {
+	sub := &subscription{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "cert-manager", "namespace": "cert-manager-operator"},
+		"spec": map[string]any{
+			"name": "openshift-cert-manager-operator", "channel": "stable-v1", "source": "redhat-operators",
+			"sourceNamespace": "openshift-marketplace", "installPlanApproval": "Manual",
+		},
+		"status": status,
+	}, sub)
+	return sub
+}
+
+func (s *OLMSuite) TestSummarizeSubscription() {
+	s.Run("reports an installed CSV", func() {
+		csv := &clusterServiceVersion{}
+		csv.Name = "cert-manager-operator.v1.15.0"
+		csv.Status.Phase = "Succeeded"
+		report := summarizeSubscription(s.subscription(map[string]any{
+			"state": "AtLatestKnown", "currentCSV": "cert-manager-operator.v1.15.0", "installedCSV": "cert-manager-operator.v1.15.0",
+		}), csv, nil)
+		s.Equal("openshift-marketplace/redhat-operators", report.Catalog)
+		s.Equal("Manual", report.Approval)
+		s.Equal("Succeeded", report.CSVPhase)
+		s.Empty(report.Issues)
+	})
+	s.Run("reports an upgrade waiting for approval", func() {
+		csv := &clusterServiceVersion{}
+		csv.Name = "cert-manager-operator.v1.15.0"
+		csv.Status.Phase = "Succeeded"
+		report := summarizeSubscription(s.subscription(map[string]any{
+			"state": "UpgradePending", "currentCSV": "cert-manager-operator.v1.16.0", "installedCSV": "cert-manager-operator.v1.15.0",
+			"installPlanRef": map[string]any{"name": "install-abcde"},
+			"conditions": []any{
+				map[string]any{"type": "InstallPlanPending", "status": "True", "reason": "RequiresApproval"},
+				map[string]any{"type": "CatalogSourcesUnhealthy", "status": "False"},
+			},
+		}), csv, nil)
+		s.Equal("install-abcde (RequiresApproval)", report.InstallPlan)
+		s.Equal([]string{"the install or upgrade to cert-manager-operator.v1.16.0 waits for the manual approval of InstallPlan install-abcde, approve it with olm_installplan_approve"}, report.Issues)
+	})
+	s.Run("reports failed resolutions", func() {
+		report := summarizeSubscription(s.subscription(map[string]any{
+			"conditions": []any{map[string]any{"type": "ResolutionFailed", "status": "True", "reason": "ConstraintsNotSatisfiable",
+				"message": "no operators found in channel stable-v2"}},
+		}), nil, nil)
+		s.Equal([]string{"dependency resolution failed (ConstraintsNotSatisfiable): no operators found in channel stable-v2"}, report.Issues)
+	})
+	s.Run("reports subscriptions without CSV", func() {
+		report := summarizeSubscription(s.subscription(map[string]any{}), nil, nil)
+		s.Equal("Manual", report.Approval)
+		s.Equal([]string{"no ClusterServiceVersion installed yet"}, report.Issues)
+	})
+	s.Run("reports failed and missing CSVs", func() {
+		csv := &clusterServiceVersion{}
+		csv.Name = "cert-manager-operator.v1.15.0"
+		csv.Status.Phase = "Failed"
+		csv.Status.Reason = "InstallCheckFailed"
+		csv.Status.Message = "install timeout"
+		status := map[string]any{"installedCSV": "cert-manager-operator.v1.15.0"}
+		report := summarizeSubscription(s.subscription(status), csv, nil)
+		s.Equal("Failed (InstallCheckFailed: install timeout)", report.CSVPhase)
+		s.Equal([]string{"ClusterServiceVersion cert-manager-operator.v1.15.0 is in phase Failed"}, report.Issues)
+
+		notFound := apierrors.NewNotFound(clusterServiceVersionGVR.GroupResource(), "cert-manager-operator.v1.15.0")
+		report = summarizeSubscription(s.subscription(status), nil, notFound)
+		s.Equal("Missing", report.CSVPhase)
+		s.Equal([]string{"the installed ClusterServiceVersion cert-manager-operator.v1.15.0 was not found"}, report.Issues)
+	})
+}
+
+func (s *OLMSuite) TestPendingInstallPlans() {
+	plans := make([]installPlan, 3)
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "install-b", "namespace": "operators",
+			"ownerReferences": []any{map[string]any{"apiVersion": "operators.coreos.com/v1alpha1", "kind": "Subscription", "name": "etcd", "uid": "1"}}},
+		"spec":   map[string]any{"approval": "Manual", "approved": false, "clusterServiceVersionNames": []any{"etcdoperator.v0.9.4"}},
+		"status": map[string]any{"phase": "RequiresApproval"},
+	}, &plans[0])
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "install-a", "namespace": "operators"},
+		"spec":     map[string]any{"approval": "Manual", "approved": true, "clusterServiceVersionNames": []any{"etcdoperator.v0.9.2"}},
+		"status":   map[string]any{"phase": "Complete"},
+	}, &plans[1])
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "install-a", "namespace": "cert-manager"},
+		"spec":     map[string]any{"approval": "Manual", "approved": false, "clusterServiceVersionNames": []any{"cert-manager.v1.16.0"}},
+		"status":   map[string]any{"phase": "RequiresApproval"},
+	}, &plans[2])
+	s.Equal([]installPlanReport{
+		{Name: "install-a", Namespace: "cert-manager", CSVs: []string{"cert-manager.v1.16.0"}},
+		{Name: "install-b", Namespace: "operators", Subscriptions: []string{"etcd"}, CSVs: []string{"etcdoperator.v0.9.4"}},
+	}, pendingInstallPlans(plans))
+}
+
+func (s *OLMSuite) TestApproveInstallPlan() {
+	item := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"approved": false}}}
+	approved, err := approveInstallPlan(item)
+	s.Require().NoError(err)
+	s.True(approved)
+	s.Equal(true, item.Object["spec"].(map[string]any)["approved"])
+
+	approved, err = approveInstallPlan(item)
+	s.Require().NoError(err)
+	s.False(approved)
+}
+
+func (s *OLMSuite) TestSearchPackages() {
+	manifest := func(name, catalog, namespace string) packageManifest {
+		m := packageManifest{}
+		s.decode(map[string]any{
+			"metadata": map[string]any{"name": name, "namespace": namespace},
+			"status": map[string]any{
+				"catalogSource": catalog, "catalogSourceNamespace": "openshift-marketplace", "packageName": name,
+				"defaultChannel": "stable", "provider": map[string]any{"name": "Red Hat"},
+				"channels": []any{
+					map[string]any{"name": "stable", "currentCSV": name + ".v1.2.0", "currentCSVDesc": map[string]any{
+						"displayName": "OpenShift GitOps", "version": "1.2.0",
+						"installModes": []any{
+							map[string]any{"type": "OwnNamespace", "supported": false},
+							map[string]any{"type": "AllNamespaces", "supported": true},
+						},
+					}},
+					map[string]any{"name": "latest", "currentCSV": name + ".v1.3.0"},
+				},
+			},
+		}, &m)
+		return m
+	}
+	manifests := []packageManifest{
+		manifest("gitops-extras", "community-operators", "openshift-marketplace"),
+		manifest("openshift-gitops-operator", "redhat-operators", "openshift-marketplace"),
+		// Global catalogs are listed in every namespace
+		manifest("openshift-gitops-operator", "redhat-operators", "default"),
+		manifest("cert-manager", "community-operators", "openshift-marketplace"),
+	}
+
+	s.Run("matches the package name and display name", func() {
+		packages := searchPackages(manifests, "GitOps", "")
+		s.Require().Len(packages, 3)
+		s.Equal("cert-manager", packages[0].Package, "display name matches")
+		s.Equal("gitops-extras", packages[1].Package)
+		s.Equal(packageReport{
+			Package:        "openshift-gitops-operator",
+			DisplayName:    "OpenShift GitOps",
+			Provider:       "Red Hat",
+			Catalog:        "openshift-marketplace/redhat-operators",
+			DefaultChannel: "stable",
+			Channels:       []string{"stable: openshift-gitops-operator.v1.2.0 (1.2.0)", "latest: openshift-gitops-operator.v1.3.0"},
+			InstallModes:   []string{"AllNamespaces"},
+			Subscription: subscriptionSpec{
+				Name: "openshift-gitops-operator", Channel: "stable", Source: "redhat-operators", SourceNamespace: "openshift-marketplace",
+			},
+		}, packages[2])
+	})
+	s.Run("returns exact matches first", func() {
+		packages := searchPackages(manifests, "gitops-extras", "")
+		s.Require().Len(packages, 1)
+		packages = searchPackages(manifests, "openshift-gitops-operator", "")
+		s.Equal("openshift-gitops-operator", packages[0].Package)
+	})
+	s.Run("filters by catalog", func() {
+		packages := searchPackages(manifests, "gitops", "redhat-operators")
+		s.Require().Len(packages, 1)
+		s.Equal("openshift-gitops-operator", packages[0].Package)
+	})
+}
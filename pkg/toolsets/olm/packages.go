@@ -0,0 +1,202 @@
+package olm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// maxPackages bounds the number of packages returned by olm_packages_search.
+const maxPackages = 20
+
+var packageManifestGVR = schema.GroupVersionResource{Group: "packages.operators.coreos.com", Version: "v1", Resource: "packagemanifests"}
+
+func initPackages(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "olm_packages_search",
+			Description: "Search the operator packages available in the OLM catalogs (PackageManifests) by name, display name or provider, " +
+				"returning their catalog, default channel, channels with their head ClusterServiceVersion, and supported install modes, " +
+				"together with the Subscription spec (name, channel, source, sourceNamespace) to install them",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "Case-insensitive text to search in the package name, display name and provider, e.g. cert-manager or gitops",
+					},
+					"catalog": {
+						Type:        "string",
+						Description: "Only search the packages of this CatalogSource, e.g. redhat-operators or community-operators (Optional)",
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OLM: Search Packages",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: packagesSearch, TargetCompatibilityFilters: []func() bool{
+			hasOLM(p, schema.GroupVersionKind{Group: packageManifestGVR.Group, Version: "v1", Kind: "PackageManifest"}),
+		}},
+	}
+}
+
+// packageManifest is the subset of an OLM PackageManifest inspected by this toolset.
+type packageManifest struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		CatalogSource          string `json:"catalogSource"`
+		CatalogSourceNamespace string `json:"catalogSourceNamespace"`
+		PackageName            string `json:"packageName"`
+		DefaultChannel         string `json:"defaultChannel"`
+		Provider               struct {
+			Name string `json:"name,omitempty"`
+		} `json:"provider"`
+		Channels []struct {
+			Name           string `json:"name"`
+			CurrentCSV     string `json:"currentCSV"`
+			CurrentCSVDesc struct {
+				DisplayName  string `json:"displayName,omitempty"`
+				Version      string `json:"version,omitempty"`
+				InstallModes []struct {
+					Type      string `json:"type"`
+					Supported bool   `json:"supported"`
+				} `json:"installModes,omitempty"`
+			} `json:"currentCSVDesc"`
+		} `json:"channels,omitempty"`
+	} `json:"status"`
+}
+
+type packageReport struct {
+	Package        string           `json:"package"`
+	DisplayName    string           `json:"displayName,omitempty"`
+	Provider       string           `json:"provider,omitempty"`
+	Catalog        string           `json:"catalog"`
+	DefaultChannel string           `json:"defaultChannel"`
+	Channels       []string         `json:"channels"`
+	InstallModes   []string         `json:"installModes,omitempty"`
+	Subscription   subscriptionSpec `json:"subscription"`
+}
+
+type subscriptionSpec struct {
+	Name            string `json:"name"`
+	Channel         string `json:"channel"`
+	Source          string `json:"source"`
+	SourceNamespace string `json:"sourceNamespace"`
+}
+
+func packagesSearch(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	query := p.RequiredString("query")
+	catalog := p.OptionalString("catalog", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search packages: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(packageManifestGVR).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list packagemanifests: %w", err)), nil
+	}
+	manifests := make([]packageManifest, len(list.Items))
+	for i := range list.Items {
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &manifests[i]); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode packagemanifest %s: %w", list.Items[i].GetName(), err)), nil
+		}
+	}
+	packages := searchPackages(manifests, query, catalog)
+	if len(packages) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No packages matching '%s' found in the catalogs", query), nil), nil
+	}
+	ret := ""
+	if len(packages) > maxPackages {
+		ret = fmt.Sprintf("# %d packages match, only the first %d are returned, refine the query\n", len(packages), maxPackages)
+		packages = packages[:maxPackages]
+	}
+	yaml, err := output.MarshalYaml(packages)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search packages: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret+yaml, nil), nil
+}
+
+// searchPackages returns the packages matching the query, exact package name matches first.
+func searchPackages(manifests []packageManifest, query, catalog string) []packageReport {
+	query = strings.ToLower(query)
+	seen := map[string]bool{}
+	var ret []packageReport
+	for _, m := range manifests {
+		if catalog != "" && m.Status.CatalogSource != catalog {
+			continue
+		}
+		report := summarizePackage(&m)
+		if !strings.Contains(strings.ToLower(report.Package), query) &&
+			!strings.Contains(strings.ToLower(report.DisplayName), query) &&
+			!strings.Contains(strings.ToLower(report.Provider), query) {
+			continue
+		}
+		// Global catalogs are served in every namespace
+		if key := report.Catalog + "/" + report.Package; !seen[key] {
+			seen[key] = true
+			ret = append(ret, report)
+		}
+	}
+	slices.SortFunc(ret, func(a, b packageReport) int {
+		if exactA, exactB := strings.ToLower(a.Package) == query, strings.ToLower(b.Package) == query; exactA != exactB {
+			if exactA {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Package+" "+a.Catalog, b.Package+" "+b.Catalog)
+	})
+	return ret
+}
+
+func summarizePackage(m *packageManifest) packageReport {
+	report := packageReport{
+		Package:        m.Status.PackageName,
+		Provider:       m.Status.Provider.Name,
+		Catalog:        m.Status.CatalogSourceNamespace + "/" + m.Status.CatalogSource,
+		DefaultChannel: m.Status.DefaultChannel,
+		Subscription: subscriptionSpec{
+			Name:            m.Status.PackageName,
+			Channel:         m.Status.DefaultChannel,
+			Source:          m.Status.CatalogSource,
+			SourceNamespace: m.Status.CatalogSourceNamespace,
+		},
+	}
+	if report.Package == "" {
+		report.Package = m.Name
+		report.Subscription.Name = m.Name
+	}
+	for _, c := range m.Status.Channels {
+		description := c.Name + ": " + c.CurrentCSV
+		if c.CurrentCSVDesc.Version != "" {
+			description += " (" + c.CurrentCSVDesc.Version + ")"
+		}
+		report.Channels = append(report.Channels, description)
+		if c.Name != m.Status.DefaultChannel {
+			continue
+		}
+		report.DisplayName = c.CurrentCSVDesc.DisplayName
+		for _, mode := range c.CurrentCSVDesc.InstallModes {
+			if mode.Supported {
+				report.InstallModes = append(report.InstallModes, mode.Type)
+			}
+		}
+	}
+	return report
+}
@@ -0,0 +1,380 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	olmGroup = "operators.coreos.com"
+	// requiresApprovalPhase is the phase of the InstallPlans waiting for a manual approval.
+	requiresApprovalPhase = "RequiresApproval"
+)
+
+var (
+	subscriptionGVR          = schema.GroupVersionResource{Group: olmGroup, Version: "v1alpha1", Resource: "subscriptions"}
+	clusterServiceVersionGVR = schema.GroupVersionResource{Group: olmGroup, Version: "v1alpha1", Resource: "clusterserviceversions"}
+	installPlanGVR           = schema.GroupVersionResource{Group: olmGroup, Version: "v1alpha1", Resource: "installplans"}
+)
+
+// hasOLM returns a filter that hides the tools when the provided OLM kind is not served.
+func hasOLM(p api.FilteringProvider, gvk schema.GroupVersionKind) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{gvk})
+	}
+}
+
+func initSubscriptions(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "olm_subscriptions_list",
+			Description: "List OLM operator Subscriptions with their package, channel, catalog, approval strategy, current and installed ClusterServiceVersion (CSV) and its phase, " +
+				"and the InstallPlans waiting for a manual approval. Flags the Subscriptions whose install or upgrade is stalled (pending approval, failed resolution, unhealthy catalog, failed CSV)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Subscriptions (Optional, all namespaces if not provided)",
+					},
+					"package": {
+						Type:        "string",
+						Description: "Only list the Subscriptions to this operator package (Optional)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OLM: List Subscriptions",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: subscriptionsList, TargetCompatibilityFilters: []func() bool{
+			hasOLM(p, schema.GroupVersionKind{Group: olmGroup, Version: "v1alpha1", Kind: "Subscription"}),
+		}},
+		{Tool: api.Tool{
+			Name: "olm_installplan_approve",
+			Description: "Approve an OLM InstallPlan waiting for a manual approval, so that OLM installs or upgrades the ClusterServiceVersions it lists. " +
+				"Use olm_subscriptions_list to find the pending InstallPlans and the CSVs they install before approving them",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the InstallPlan (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the InstallPlan to approve",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OLM: Approve InstallPlan",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: installPlanApprove, TargetCompatibilityFilters: []func() bool{
+			hasOLM(p, schema.GroupVersionKind{Group: olmGroup, Version: "v1alpha1", Kind: "InstallPlan"}),
+		}},
+	}
+}
+
+type olmCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// subscription is the subset of an OLM Subscription inspected by this toolset.
+type subscription struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Package             string `json:"name"`
+		Channel             string `json:"channel,omitempty"`
+		Source              string `json:"source"`
+		SourceNamespace     string `json:"sourceNamespace"`
+		InstallPlanApproval string `json:"installPlanApproval,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		State          string `json:"state,omitempty"`
+		CurrentCSV     string `json:"currentCSV,omitempty"`
+		InstalledCSV   string `json:"installedCSV,omitempty"`
+		InstallPlanRef *struct {
+			Name string `json:"name"`
+		} `json:"installPlanRef,omitempty"`
+		Conditions []olmCondition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+// clusterServiceVersion is the subset of an OLM ClusterServiceVersion inspected by this toolset.
+type clusterServiceVersion struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Version string `json:"version,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Phase   string `json:"phase,omitempty"`
+		Reason  string `json:"reason,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+// installPlan is the subset of an OLM InstallPlan inspected by this toolset.
+type installPlan struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Approval                   string   `json:"approval"`
+		Approved                   bool     `json:"approved"`
+		ClusterServiceVersionNames []string `json:"clusterServiceVersionNames"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase,omitempty"`
+	} `json:"status"`
+}
+
+type subscriptionsReport struct {
+	Subscriptions       []subscriptionReport `json:"subscriptions"`
+	PendingInstallPlans []installPlanReport  `json:"pendingInstallPlans,omitempty"`
+}
+
+type subscriptionReport struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	Package      string   `json:"package"`
+	Channel      string   `json:"channel,omitempty"`
+	Catalog      string   `json:"catalog"`
+	Approval     string   `json:"approval"`
+	State        string   `json:"state,omitempty"`
+	CurrentCSV   string   `json:"currentCSV,omitempty"`
+	InstalledCSV string   `json:"installedCSV,omitempty"`
+	CSVPhase     string   `json:"csvPhase,omitempty"`
+	InstallPlan  string   `json:"installPlan,omitempty"`
+	Issues       []string `json:"issues,omitempty"`
+}
+
+type installPlanReport struct {
+	Name          string   `json:"name"`
+	Namespace     string   `json:"namespace"`
+	Subscriptions []string `json:"subscriptions,omitempty"`
+	CSVs          []string `json:"csvs"`
+}
+
+func subscriptionsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	pkg := p.OptionalString("package", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subscriptions: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(subscriptionGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subscriptions: %w", err)), nil
+	}
+	report := subscriptionsReport{}
+	listed := map[string]bool{}
+	for _, item := range list.Items {
+		sub := &subscription{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, sub); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode subscription %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		if pkg != "" && sub.Spec.Package != pkg {
+			continue
+		}
+		// Copied CSVs make listing the CSVs of every namespace expensive, only the installed ones are retrieved
+		var csv *clusterServiceVersion
+		var csvErr error
+		if sub.Status.InstalledCSV != "" {
+			csv, csvErr = getClusterServiceVersion(params, sub.Namespace, sub.Status.InstalledCSV)
+		}
+		report.Subscriptions = append(report.Subscriptions, summarizeSubscription(sub, csv, csvErr))
+		listed[sub.Namespace+"/"+sub.Name] = true
+	}
+	if len(report.Subscriptions) == 0 {
+		return api.NewToolCallResult("No Subscriptions found", nil), nil
+	}
+
+	plans, err := params.DynamicClient().Resource(installPlanGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list installplans: %w", err)), nil
+	}
+	decoded := make([]installPlan, len(plans.Items))
+	for i := range plans.Items {
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(plans.Items[i].Object, &decoded[i]); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode installplan %s/%s: %w", plans.Items[i].GetNamespace(), plans.Items[i].GetName(), err)), nil
+		}
+	}
+	for _, plan := range pendingInstallPlans(decoded) {
+		if pkg == "" || slices.ContainsFunc(plan.Subscriptions, func(s string) bool { return listed[plan.Namespace+"/"+s] }) {
+			report.PendingInstallPlans = append(report.PendingInstallPlans, plan)
+		}
+	}
+
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subscriptions: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func getClusterServiceVersion(params api.ToolHandlerParams, namespace, name string) (*clusterServiceVersion, error) {
+	item, err := params.DynamicClient().Resource(clusterServiceVersionGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	csv := &clusterServiceVersion{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, csv); err != nil {
+		return nil, err
+	}
+	return csv, nil
+}
+
+// summarizeSubscription reports the install and upgrade status of a Subscription,
+// csv is the installed ClusterServiceVersion and csvErr the error retrieving it.
+func summarizeSubscription(sub *subscription, csv *clusterServiceVersion, csvErr error) subscriptionReport {
+	report := subscriptionReport{
+		Name:         sub.Name,
+		Namespace:    sub.Namespace,
+		Package:      sub.Spec.Package,
+		Channel:      sub.Spec.Channel,
+		Catalog:      sub.Spec.SourceNamespace + "/" + sub.Spec.Source,
+		Approval:     sub.Spec.InstallPlanApproval,
+		State:        sub.Status.State,
+		CurrentCSV:   sub.Status.CurrentCSV,
+		InstalledCSV: sub.Status.InstalledCSV,
+	}
+	if report.Approval == "" {
+		report.Approval = "Automatic"
+	}
+
+	var pendingApproval bool
+	for _, c := range sub.Status.Conditions {
+		if c.Status != "True" {
+			continue
+		}
+		switch c.Type {
+		case "ResolutionFailed":
+			report.Issues = append(report.Issues, fmt.Sprintf("dependency resolution failed (%s): %s", c.Reason, c.Message))
+		case "CatalogSourcesUnhealthy":
+			report.Issues = append(report.Issues, "catalog sources are unhealthy: "+c.Message)
+		case "InstallPlanMissing":
+			report.Issues = append(report.Issues, fmt.Sprintf("the InstallPlan is missing (%s): %s", c.Reason, c.Message))
+		case "InstallPlanFailed":
+			report.Issues = append(report.Issues, fmt.Sprintf("the InstallPlan failed (%s): %s", c.Reason, c.Message))
+		case "InstallPlanPending":
+			pendingApproval = c.Reason == requiresApprovalPhase
+		}
+	}
+	if sub.Status.InstallPlanRef != nil {
+		report.InstallPlan = sub.Status.InstallPlanRef.Name
+		if pendingApproval {
+			report.InstallPlan += " (" + requiresApprovalPhase + ")"
+			target := sub.Status.CurrentCSV
+			if target == "" {
+				target = "the latest CSV of the channel"
+			}
+			report.Issues = append(report.Issues, fmt.Sprintf("the install or upgrade to %s waits for the manual approval of InstallPlan %s, approve it with olm_installplan_approve",
+				target, sub.Status.InstallPlanRef.Name))
+		}
+	}
+
+	switch {
+	case sub.Status.InstalledCSV == "":
+		if len(report.Issues) == 0 {
+			report.Issues = append(report.Issues, "no ClusterServiceVersion installed yet")
+		}
+	case apierrors.IsNotFound(csvErr):
+		report.CSVPhase = "Missing"
+		report.Issues = append(report.Issues, fmt.Sprintf("the installed ClusterServiceVersion %s was not found", sub.Status.InstalledCSV))
+	case csvErr != nil:
+		report.Issues = append(report.Issues, fmt.Sprintf("failed to get ClusterServiceVersion %s: %v", sub.Status.InstalledCSV, csvErr))
+	case csv != nil:
+		report.CSVPhase = csv.Status.Phase
+		if csv.Status.Phase != "Succeeded" {
+			report.CSVPhase = fmt.Sprintf("%s (%s: %s)", csv.Status.Phase, csv.Status.Reason, csv.Status.Message)
+			report.Issues = append(report.Issues, fmt.Sprintf("ClusterServiceVersion %s is in phase %s", csv.Name, csv.Status.Phase))
+		}
+	}
+	return report
+}
+
+// pendingInstallPlans returns the InstallPlans waiting for a manual approval.
+func pendingInstallPlans(plans []installPlan) []installPlanReport {
+	var ret []installPlanReport
+	for _, plan := range plans {
+		if plan.Spec.Approved || plan.Status.Phase != requiresApprovalPhase {
+			continue
+		}
+		report := installPlanReport{Name: plan.Name, Namespace: plan.Namespace, CSVs: plan.Spec.ClusterServiceVersionNames}
+		for _, owner := range plan.OwnerReferences {
+			if owner.Kind == "Subscription" {
+				report.Subscriptions = append(report.Subscriptions, owner.Name)
+			}
+		}
+		ret = append(ret, report)
+	}
+	slices.SortFunc(ret, func(a, b installPlanReport) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	return ret
+}
+
+func installPlanApprove(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve installplan: %w", err)), nil
+	}
+
+	installPlans := params.DynamicClient().Resource(installPlanGVR).Namespace(namespace)
+	item, err := installPlans.Get(params.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get installplan %s/%s: %w", namespace, name, err)), nil
+	}
+	csvs, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "clusterServiceVersionNames")
+	approved, err := approveInstallPlan(item)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve installplan %s/%s: %w", namespace, name, err)), nil
+	}
+	if !approved {
+		phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+		return api.NewToolCallResult(fmt.Sprintf("InstallPlan '%s' in namespace '%s' is already approved (phase: %s)", name, namespace, phase), nil), nil
+	}
+	if _, err = installPlans.Update(params.Context, item, metav1.UpdateOptions{}); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve installplan %s/%s: %w", namespace, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("InstallPlan '%s' in namespace '%s' approved, OLM installs the ClusterServiceVersions: %s\n"+
+		"Use olm_subscriptions_list to follow the installation", name, namespace, strings.Join(csvs, ", ")), nil), nil
+}
+
+// approveInstallPlan sets the approved field of an InstallPlan, it returns false if the InstallPlan is already approved.
+func approveInstallPlan(item *unstructured.Unstructured) (bool, error) {
+	approved, _, err := unstructured.NestedBool(item.Object, "spec", "approved")
+	if err != nil {
+		return false, err
+	}
+	if approved {
+		return false, nil
+	}
+	return true, unstructured.SetNestedField(item.Object, true, "spec", "approved")
+}
@@ -0,0 +1,44 @@
+package olm
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides Operator Lifecycle Manager Subscription, InstallPlan and PackageManifest tools.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "olm"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Operator Lifecycle Manager (OLM) tools to list Subscriptions with their installed ClusterServiceVersions and pending InstallPlans, approve InstallPlans, and search PackageManifests to install new operators"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initSubscriptions(p),
+		initPackages(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,237 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// defaultBuildTimeout and maxBuildTimeout bound, in seconds, how long openshift_build_start follows a build.
+	defaultBuildTimeout = 300
+	maxBuildTimeout     = 1800
+	// maxBuildLogBytes bounds the build logs returned, the most recent lines are kept.
+	maxBuildLogBytes = 64 * 1024
+	// buildPollInterval is the interval used to wait for the build pod to start.
+	buildPollInterval = 2 * time.Second
+)
+
+var (
+	buildConfigGVR = schema.GroupVersionResource{Group: "build.openshift.io", Version: "v1", Resource: "buildconfigs"}
+	buildGVR       = schema.GroupVersionResource{Group: "build.openshift.io", Version: "v1", Resource: "builds"}
+)
+
+// hasOpenShiftKind returns a filter that hides the tools when the target is not an OpenShift cluster serving the provided kind.
+func hasOpenShiftKind(p api.FilteringProvider, gvk schema.GroupVersionKind) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{gvk})
+	}
+}
+
+func initBuilds(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "openshift_build_start",
+			Description: "Start a new build from an OpenShift BuildConfig, as oc start-build does, and by default follow it until it completes, " +
+				"returning its final phase, output image and the most recent build logs",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the BuildConfig",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the BuildConfig (Optional, current namespace if not provided)",
+					},
+					"commit": {
+						Type:        "string",
+						Description: "Git commit to build instead of the latest commit of the BuildConfig source (Optional)",
+					},
+					"env": {
+						Type:        "array",
+						Description: "Environment variables to set for the build, as KEY=VALUE (Optional)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"follow": {
+						Type:        "boolean",
+						Description: "Wait for the build to complete and return its logs (Optional, defaults to true)",
+						Default:     api.ToRawMessage(true),
+					},
+					"timeout": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of seconds to follow the build, the build keeps running afterwards (Optional, defaults to %d, max %d)", defaultBuildTimeout, maxBuildTimeout),
+						Default:     api.ToRawMessage(defaultBuildTimeout),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(maxBuildTimeout)),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OpenShift: Start Build",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: buildStart, TargetCompatibilityFilters: []func() bool{
+			hasOpenShiftKind(p, schema.GroupVersionKind{Group: "build.openshift.io", Version: "v1", Kind: "BuildConfig"}),
+		}},
+	}
+}
+
+func buildStart(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	commit := p.OptionalString("commit", "")
+	follow := p.OptionalBool("follow", true)
+	timeout := p.OptionalInt64("timeout", defaultBuildTimeout)
+	env := p.OptionalStringArray("env")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to start build: %w", err)), nil
+	}
+	request, err := buildRequest(name, commit, env)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to start build: %w", err)), nil
+	}
+
+	build, err := params.DynamicClient().Resource(buildConfigGVR).Namespace(namespace).Create(params.Context, request, metav1.CreateOptions{}, "instantiate")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to start build from buildconfig %s/%s: %w", namespace, name, err)), nil
+	}
+	started := fmt.Sprintf("Build '%s' in namespace '%s' started from BuildConfig '%s'\n", build.GetName(), namespace, name)
+	if !follow {
+		return api.NewToolCallResult(started+"Run the tool with follow=true, or read the logs of the pod "+build.GetName()+"-build, to follow it", nil), nil
+	}
+
+	timeout = min(max(timeout, 1), maxBuildTimeout)
+	ctx, cancel := context.WithTimeout(params.Context, time.Duration(timeout)*time.Second)
+	defer cancel()
+	logs := &tailBuffer{max: maxBuildLogBytes}
+	followErr := followBuild(ctx, params, namespace, build.GetName(), logs)
+	// ctx may have expired, use the request context to read the final state of the build
+	if latest, err := params.DynamicClient().Resource(buildGVR).Namespace(namespace).Get(params.Context, build.GetName(), metav1.GetOptions{}); err == nil {
+		build = latest
+	}
+	ret := started + describeBuild(build)
+	switch {
+	case errors.Is(followErr, context.DeadlineExceeded):
+		ret += fmt.Sprintf("Stopped following the build after %d seconds, the build keeps running\n", timeout)
+	case followErr != nil:
+		ret += fmt.Sprintf("Failed to follow the build logs: %v\n", followErr)
+	}
+	return api.NewToolCallResult(ret+"# Logs\n"+logs.String(), nil), nil
+}
+
+// buildRequest returns the BuildRequest instantiating a BuildConfig.
+func buildRequest(name, commit string, env []string) (*unstructured.Unstructured, error) {
+	request := map[string]any{
+		"apiVersion": "build.openshift.io/v1",
+		"kind":       "BuildRequest",
+		"metadata":   map[string]any{"name": name},
+	}
+	if commit != "" {
+		request["revision"] = map[string]any{"git": map[string]any{"commit": commit}}
+	}
+	if len(env) > 0 {
+		vars := make([]any, 0, len(env))
+		for _, e := range env {
+			key, value, ok := strings.Cut(e, "=")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid env %q, must be KEY=VALUE", e)
+			}
+			vars = append(vars, map[string]any{"name": key, "value": value})
+		}
+		request["env"] = vars
+	}
+	return &unstructured.Unstructured{Object: request}, nil
+}
+
+// followBuild waits for the build pod to start and then streams the build logs into w until the build completes.
+func followBuild(ctx context.Context, params api.ToolHandlerParams, namespace, name string, w io.Writer) error {
+	builds := params.DynamicClient().Resource(buildGVR).Namespace(namespace)
+	for {
+		build, err := builds.Get(ctx, name, metav1.GetOptions{})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			return err
+		}
+		phase, _, _ := unstructured.NestedString(build.Object, "status", "phase")
+		if phase != "New" && phase != "Pending" && phase != "" {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(buildPollInterval):
+		}
+	}
+	stream, err := params.CoreV1().RESTClient().Get().
+		AbsPath("apis", buildGVR.Group, buildGVR.Version, "namespaces", namespace, "builds", name, "log").
+		Param("follow", "true").
+		Stream(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	} else if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+	if _, err = io.Copy(w, stream); err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func describeBuild(build *unstructured.Unstructured) string {
+	phase, _, _ := unstructured.NestedString(build.Object, "status", "phase")
+	ret := "Phase: " + phase + "\n"
+	if reason, _, _ := unstructured.NestedString(build.Object, "status", "reason"); reason != "" {
+		message, _, _ := unstructured.NestedString(build.Object, "status", "message")
+		ret += fmt.Sprintf("Reason: %s: %s\n", reason, message)
+	}
+	if image, _, _ := unstructured.NestedString(build.Object, "status", "outputDockerImageReference"); image != "" {
+		ret += "Output image: " + image + "\n"
+	}
+	if digest, _, _ := unstructured.NestedString(build.Object, "status", "output", "to", "imageDigest"); digest != "" {
+		ret += "Output image digest: " + digest + "\n"
+	}
+	return ret
+}
+
+// tailBuffer is an io.Writer keeping the last max bytes written to it.
+type tailBuffer struct {
+	max     int
+	buf     []byte
+	dropped int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if extra := len(t.buf) - t.max; extra > 0 {
+		t.dropped += extra
+		t.buf = t.buf[:copy(t.buf, t.buf[extra:])]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	if t.dropped > 0 {
+		return fmt.Sprintf("... [%d bytes truncated]\n%s", t.dropped, t.buf)
+	}
+	return string(t.buf)
+}
@@ -0,0 +1,271 @@
+package openshift
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"golang.org/x/mod/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// clusterVersionName is the name of the singleton ClusterVersion.
+	clusterVersionName = "version"
+	// maxAvailableUpdates bounds the available update versions reported.
+	maxAvailableUpdates = 10
+)
+
+var (
+	clusterOperatorGVR = schema.GroupVersionResource{Group: "config.openshift.io", Version: "v1", Resource: "clusteroperators"}
+	clusterVersionGVR  = schema.GroupVersionResource{Group: "config.openshift.io", Version: "v1", Resource: "clusterversions"}
+)
+
+func initCluster(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "openshift_cluster_status",
+			Description: "Report the health of an OpenShift cluster: the Available, Progressing and Degraded conditions of every ClusterOperator, " +
+				"and the ClusterVersion with its current and desired version, upgrade progress, channel, available updates, and the conditions blocking upgrades (Failing, Upgradeable=False)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"unhealthy_only": {
+						Type:        "boolean",
+						Description: "Only report the ClusterOperators that are not Available, are Degraded or are Progressing (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OpenShift: Cluster Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterStatus, TargetCompatibilityFilters: []func() bool{
+			hasOpenShiftKind(p, schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterOperator"}),
+		}},
+	}
+}
+
+type statusCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// clusterOperator is the subset of an OpenShift ClusterOperator inspected by this toolset.
+type clusterOperator struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		Conditions []statusCondition `json:"conditions,omitempty"`
+		Versions   []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"versions,omitempty"`
+	} `json:"status"`
+}
+
+// clusterVersion is the subset of the OpenShift ClusterVersion inspected by this toolset.
+type clusterVersion struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Channel string `json:"channel,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Desired struct {
+			Version string `json:"version,omitempty"`
+		} `json:"desired"`
+		History []struct {
+			State          string `json:"state"`
+			Version        string `json:"version"`
+			StartedTime    string `json:"startedTime"`
+			CompletionTime string `json:"completionTime,omitempty"`
+		} `json:"history,omitempty"`
+		Conditions       []statusCondition `json:"conditions,omitempty"`
+		AvailableUpdates []struct {
+			Version string `json:"version"`
+		} `json:"availableUpdates,omitempty"`
+	} `json:"status"`
+}
+
+type clusterStatusReport struct {
+	Summary          string                  `json:"summary"`
+	ClusterVersion   *clusterVersionReport   `json:"clusterVersion,omitempty"`
+	ClusterOperators []clusterOperatorReport `json:"clusterOperators,omitempty"`
+	Errors           []string                `json:"errors,omitempty"`
+}
+
+type clusterVersionReport struct {
+	Version          string   `json:"version"`
+	Desired          string   `json:"desired,omitempty"`
+	Channel          string   `json:"channel,omitempty"`
+	Upgrade          string   `json:"upgrade,omitempty"`
+	Conditions       []string `json:"conditions,omitempty"`
+	AvailableUpdates []string `json:"availableUpdates,omitempty"`
+	Issues           []string `json:"issues,omitempty"`
+}
+
+type clusterOperatorReport struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Available   string `json:"available"`
+	Progressing string `json:"progressing"`
+	Degraded    string `json:"degraded"`
+	healthy     bool
+}
+
+func clusterStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	unhealthyOnly := p.OptionalBool("unhealthy_only", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster status: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(clusterOperatorGVR).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list clusteroperators: %w", err)), nil
+	}
+	operators := make([]clusterOperator, 0, len(list.Items))
+	for _, item := range list.Items {
+		operator := clusterOperator{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &operator); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode clusteroperator %s: %w", item.GetName(), err)), nil
+		}
+		operators = append(operators, operator)
+	}
+
+	report := clusterStatusReport{}
+	var version *clusterVersion
+	if item, err := params.DynamicClient().Resource(clusterVersionGVR).Get(params.Context, clusterVersionName, metav1.GetOptions{}); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to get clusterversion %s: %v", clusterVersionName, err))
+	} else {
+		version = &clusterVersion{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, version); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode clusterversion %s: %w", clusterVersionName, err)), nil
+		}
+	}
+
+	summarizeClusterStatus(&report, version, operators, unhealthyOnly)
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster status: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func summarizeClusterStatus(report *clusterStatusReport, version *clusterVersion, operators []clusterOperator, unhealthyOnly bool) {
+	if version != nil {
+		report.ClusterVersion = summarizeClusterVersion(version)
+	}
+	var unavailable, degraded, progressing int
+	for i := range operators {
+		operator := summarizeClusterOperator(&operators[i])
+		if !strings.HasPrefix(operator.Available, "True") {
+			unavailable++
+		}
+		if strings.HasPrefix(operator.Degraded, "True") {
+			degraded++
+		}
+		if strings.HasPrefix(operator.Progressing, "True") {
+			progressing++
+		}
+		if !unhealthyOnly || !operator.healthy {
+			report.ClusterOperators = append(report.ClusterOperators, operator)
+		}
+	}
+	report.Summary = fmt.Sprintf("%d ClusterOperators: %d not Available, %d Degraded, %d Progressing", len(operators), unavailable, degraded, progressing)
+	if report.ClusterVersion != nil {
+		report.Summary = fmt.Sprintf("OpenShift %s, %s", report.ClusterVersion.Version, report.Summary)
+		if report.ClusterVersion.Upgrade != "" {
+			report.Summary += ", " + report.ClusterVersion.Upgrade
+		}
+	}
+}
+
+func summarizeClusterOperator(operator *clusterOperator) clusterOperatorReport {
+	report := clusterOperatorReport{
+		Name:        operator.Name,
+		Available:   describeStatusCondition(operator.Status.Conditions, "Available", "True"),
+		Progressing: describeStatusCondition(operator.Status.Conditions, "Progressing", "False"),
+		Degraded:    describeStatusCondition(operator.Status.Conditions, "Degraded", "False"),
+	}
+	for _, v := range operator.Status.Versions {
+		if v.Name == "operator" {
+			report.Version = v.Version
+		}
+	}
+	report.healthy = report.Available == "True" && report.Progressing == "False" && report.Degraded == "False"
+	return report
+}
+
+func summarizeClusterVersion(version *clusterVersion) *clusterVersionReport {
+	report := &clusterVersionReport{Channel: version.Spec.Channel}
+	// The history is ordered from the most recent update, the current version is the most recent completed one
+	for _, h := range version.Status.History {
+		if h.State == "Completed" {
+			report.Version = h.Version
+			break
+		}
+	}
+	if len(version.Status.History) > 0 {
+		if latest := version.Status.History[0]; latest.State != "Completed" {
+			report.Upgrade = fmt.Sprintf("upgrading to %s since %s", latest.Version, latest.StartedTime)
+		}
+	}
+	if version.Status.Desired.Version != report.Version {
+		report.Desired = version.Status.Desired.Version
+	}
+	for _, c := range version.Status.Conditions {
+		description := c.Type + "=" + c.Status
+		if c.Message != "" {
+			description += fmt.Sprintf(" (%s: %s)", c.Reason, c.Message)
+		}
+		report.Conditions = append(report.Conditions, description)
+		switch {
+		case c.Type == "Failing" && c.Status == "True":
+			report.Issues = append(report.Issues, "the cluster version operator is failing: "+c.Message)
+		case c.Type == "Upgradeable" && c.Status == "False":
+			report.Issues = append(report.Issues, "minor version upgrades are blocked: "+c.Message)
+		case c.Type == "ReleaseAccepted" && c.Status == "False":
+			report.Issues = append(report.Issues, "the desired release was not accepted: "+c.Message)
+		case c.Type == "RetrievedUpdates" && c.Status == "False":
+			report.Issues = append(report.Issues, "available updates could not be retrieved: "+c.Message)
+		}
+	}
+	for _, u := range version.Status.AvailableUpdates {
+		report.AvailableUpdates = append(report.AvailableUpdates, u.Version)
+	}
+	// Most recent updates first
+	slices.SortFunc(report.AvailableUpdates, func(a, b string) int {
+		return semver.Compare("v"+b, "v"+a)
+	})
+	if len(report.AvailableUpdates) > maxAvailableUpdates {
+		report.AvailableUpdates = report.AvailableUpdates[:maxAvailableUpdates]
+	}
+	return report
+}
+
+// describeStatusCondition describes a condition, adding its reason and message when its status is not the expected one.
+func describeStatusCondition(conditions []statusCondition, conditionType, expected string) string {
+	for _, c := range conditions {
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status == expected {
+			return c.Status
+		}
+		return fmt.Sprintf("%s (%s: %s)", c.Status, c.Reason, c.Message)
+	}
+	return "Unknown (not reported)"
+}
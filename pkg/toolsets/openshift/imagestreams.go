@@ -0,0 +1,188 @@
+package openshift
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+var imageStreamGVR = schema.GroupVersionResource{Group: "image.openshift.io", Version: "v1", Resource: "imagestreams"}
+
+func initImageStreams(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "openshift_imagestream_tags",
+			Description: "List the tags of OpenShift ImageStreams with the image digest and pull specification they currently point to, " +
+				"the source they are imported or built from, and the tag import errors",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ImageStreams (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ImageStream (Optional, all ImageStreams in the namespace if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OpenShift: List ImageStream Tags",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: imageStreamTags, TargetCompatibilityFilters: []func() bool{
+			hasOpenShiftKind(p, schema.GroupVersionKind{Group: "image.openshift.io", Version: "v1", Kind: "ImageStream"}),
+		}},
+	}
+}
+
+// imageStream is the subset of an OpenShift ImageStream inspected by this toolset.
+type imageStream struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Tags []struct {
+			Name string `json:"name"`
+			From *struct {
+				Kind      string `json:"kind"`
+				Name      string `json:"name"`
+				Namespace string `json:"namespace,omitempty"`
+			} `json:"from,omitempty"`
+			ImportPolicy *struct {
+				Scheduled bool `json:"scheduled,omitempty"`
+			} `json:"importPolicy,omitempty"`
+		} `json:"tags,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		DockerImageRepository string `json:"dockerImageRepository,omitempty"`
+		Tags                  []struct {
+			Tag   string `json:"tag"`
+			Items []struct {
+				Created              string `json:"created"`
+				DockerImageReference string `json:"dockerImageReference"`
+				Image                string `json:"image"`
+			} `json:"items,omitempty"`
+			Conditions []struct {
+				Type    string `json:"type"`
+				Status  string `json:"status"`
+				Reason  string `json:"reason,omitempty"`
+				Message string `json:"message,omitempty"`
+			} `json:"conditions,omitempty"`
+		} `json:"tags,omitempty"`
+	} `json:"status"`
+}
+
+type imageStreamTag struct {
+	ImageStream string `json:"imageStream"`
+	Tag         string `json:"tag"`
+	Digest      string `json:"digest,omitempty"`
+	PullSpec    string `json:"pullSpec,omitempty"`
+	Created     string `json:"created,omitempty"`
+	From        string `json:"from,omitempty"`
+	History     int    `json:"history,omitempty"`
+	Issues      string `json:"issues,omitempty"`
+}
+
+func imageStreamTags(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list imagestream tags: %w", err)), nil
+	}
+
+	var items []unstructured.Unstructured
+	if name != "" {
+		item, err := params.DynamicClient().Resource(imageStreamGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get imagestream %s/%s: %w", namespace, name, err)), nil
+		}
+		items = append(items, *item)
+	} else {
+		list, err := params.DynamicClient().Resource(imageStreamGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list imagestreams in namespace %s: %w", namespace, err)), nil
+		}
+		items = list.Items
+	}
+
+	var tags []imageStreamTag
+	for _, item := range items {
+		stream := &imageStream{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, stream); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode imagestream %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		tags = append(tags, summarizeImageStreamTags(stream)...)
+	}
+	if len(tags) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No ImageStream tags found in namespace %s", namespace), nil), nil
+	}
+	ret, err := output.MarshalYaml(tags)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list imagestream tags: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// summarizeImageStreamTags returns the tags declared in the spec or reported in the status of an ImageStream, sorted by name.
+func summarizeImageStreamTags(stream *imageStream) []imageStreamTag {
+	tags := map[string]*imageStreamTag{}
+	tag := func(name string) *imageStreamTag {
+		if tags[name] == nil {
+			tags[name] = &imageStreamTag{ImageStream: stream.Namespace + "/" + stream.Name, Tag: name}
+		}
+		return tags[name]
+	}
+	for _, spec := range stream.Spec.Tags {
+		t := tag(spec.Name)
+		if spec.From != nil {
+			t.From = spec.From.Kind + " " + spec.From.Name
+			if spec.From.Namespace != "" && spec.From.Kind != "DockerImage" {
+				t.From = fmt.Sprintf("%s %s/%s", spec.From.Kind, spec.From.Namespace, spec.From.Name)
+			}
+			if spec.ImportPolicy != nil && spec.ImportPolicy.Scheduled {
+				t.From += " (scheduled import)"
+			}
+		}
+	}
+	for _, status := range stream.Status.Tags {
+		t := tag(status.Tag)
+		if len(status.Items) > 0 {
+			t.Digest = status.Items[0].Image
+			t.PullSpec = status.Items[0].DockerImageReference
+			t.Created = status.Items[0].Created
+			t.History = len(status.Items)
+		}
+		var issues []string
+		for _, c := range status.Conditions {
+			if c.Type == "ImportSuccess" && c.Status == "False" {
+				issues = append(issues, fmt.Sprintf("import failed (%s): %s", c.Reason, c.Message))
+			}
+		}
+		t.Issues = strings.Join(issues, "; ")
+	}
+	ret := make([]imageStreamTag, 0, len(tags))
+	for _, t := range tags {
+		if t.Digest == "" && t.Issues == "" {
+			t.Issues = "no image, the tag was not imported or built yet"
+		}
+		ret = append(ret, *t)
+	}
+	slices.SortFunc(ret, func(a, b imageStreamTag) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return ret
+}
@@ -0,0 +1,194 @@
+package openshift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type OpenShiftSuite struct {
+	suite.Suite
+}
+
+func TestOpenShift(t *testing.T) {
+	suite.Run(t, new(OpenShiftSuite))
+}
+
+func (s *OpenShiftSuite) decode(obj map[string]any, into any) {
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, into))
+}
+
+func (s *OpenShiftSuite) TestBuildRequest() {
+	s.Run("instantiates the BuildConfig with a commit and env", func() {
+		request, err := buildRequest("ruby-ex", "abc123", []string{"DEBUG=true", "EMPTY="})
+		s.Require().NoError(err)
+		s.Equal("BuildRequest", request.GetKind())
+		s.Equal("ruby-ex", request.GetName())
+		commit, _, _ := unstructured.NestedString(request.Object, "revision", "git", "commit")
+		s.Equal("abc123", commit)
+		s.Equal([]any{
+			map[string]any{"name": "DEBUG", "value": "true"},
+			map[string]any{"name": "EMPTY", "value": ""},
+		}, request.Object["env"])
+	})
+	s.Run("rejects invalid env", func() {
+		_, err := buildRequest("ruby-ex", "", []string{"DEBUG"})
+		s.ErrorContains(err, `invalid env "DEBUG"`)
+	})
+}
+
+func (s *OpenShiftSuite) TestTailBuffer() {
+	logs := &tailBuffer{max: 10}
+	_, _ = logs.Write([]byte("0123456789"))
+	_, _ = logs.Write([]byte("abcde"))
+	s.Equal("... [5 bytes truncated]\n56789abcde", logs.String())
+}
+
+func (s *OpenShiftSuite) TestSummarizeImageStreamTags() {
+	stream := &imageStream{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "ruby", "namespace": "openshift"},
+		"spec": map[string]any{"tags": []any{
+			map[string]any{"name": "3.3", "from": map[string]any{"kind": "DockerImage", "name": "registry.access.redhat.com/ubi9/ruby-33:latest"},
+				"importPolicy": map[string]any{"scheduled": true}},
+			map[string]any{"name": "latest", "from": map[string]any{"kind": "ImageStreamTag", "name": "ruby:3.3"}},
+			map[string]any{"name": "broken", "from": map[string]any{"kind": "DockerImage", "name": "quay.io/missing/ruby:1"}},
+		}},
+		"status": map[string]any{"tags": []any{
+			map[string]any{"tag": "3.3", "items": []any{
+				map[string]any{"created": "2026-01-02T03:04:05Z", "dockerImageReference": "registry.access.redhat.com/ubi9/ruby-33@sha256:1111", "image": "sha256:1111"},
+				map[string]any{"created": "2025-12-01T03:04:05Z", "dockerImageReference": "registry.access.redhat.com/ubi9/ruby-33@sha256:0000", "image": "sha256:0000"},
+			}},
+			map[string]any{"tag": "broken", "conditions": []any{
+				map[string]any{"type": "ImportSuccess", "status": "False", "reason": "NotFound", "message": "manifest unknown"},
+			}},
+		}},
+	}, stream)
+	tags := summarizeImageStreamTags(stream)
+	s.Require().Len(tags, 3)
+	s.Equal(imageStreamTag{
+		ImageStream: "openshift/ruby",
+		Tag:         "3.3",
+		Digest:      "sha256:1111",
+		PullSpec:    "registry.access.redhat.com/ubi9/ruby-33@sha256:1111",
+		Created:     "2026-01-02T03:04:05Z",
+		From:        "DockerImage registry.access.redhat.com/ubi9/ruby-33:latest (scheduled import)",
+		History:     2,
+	}, tags[0])
+	s.Equal("broken", tags[1].Tag)
+	s.Equal("import failed (NotFound): manifest unknown", tags[1].Issues)
+	s.Equal("latest", tags[2].Tag)
+	s.Equal("no image, the tag was not imported or built yet", tags[2].Issues)
+}
+
+func (s *OpenShiftSuite) TestInspectRoute() {
+	s.Run("reports an admitted edge Route with weighted backends", func() {
+		r := &route{}
+		s.decode(map[string]any{
+			"metadata": map[string]any{"name": "web", "namespace": "apps"},
+			"spec": map[string]any{
+				"host":              "web.apps.example.com",
+				"path":              "/shop",
+				"to":                map[string]any{"kind": "Service", "name": "web", "weight": int64(90)},
+				"alternateBackends": []any{map[string]any{"kind": "Service", "name": "web-canary", "weight": int64(10)}},
+				"port":              map[string]any{"targetPort": "http"},
+				"tls":               map[string]any{"termination": "edge", "insecureEdgeTerminationPolicy": "Redirect"},
+			},
+			"status": map[string]any{"ingress": []any{map[string]any{
+				"host": "web.apps.example.com", "routerName": "default", "routerCanonicalHostname": "router-default.apps.example.com",
+				"conditions": []any{map[string]any{"type": "Admitted", "status": "True"}},
+			}}},
+		}, r)
+		report := inspectRoute(r)
+		s.Equal("https://web.apps.example.com/shop", report.URL)
+		s.Equal([]string{"Service web (weight 90)", "Service web-canary (weight 10)"}, report.Backends)
+		s.Equal("http", report.Port)
+		s.Equal("edge (insecure traffic: Redirect)", report.TLS)
+		s.Equal([]string{"default (router-default.apps.example.com): True"}, report.Admitted)
+		s.Empty(report.Issues)
+	})
+	s.Run("reports rejected Routes", func() {
+		r := &route{}
+		s.decode(map[string]any{
+			"metadata": map[string]any{"name": "web", "namespace": "apps"},
+			"spec":     map[string]any{"host": "web.apps.example.com", "to": map[string]any{"kind": "Service", "name": "web"}},
+			"status": map[string]any{"ingress": []any{map[string]any{
+				"routerName": "default",
+				"conditions": []any{map[string]any{"type": "Admitted", "status": "False", "reason": "HostAlreadyClaimed",
+					"message": "route web already exposes web.apps.example.com and is older"}},
+			}}},
+		}, r)
+		report := inspectRoute(r)
+		s.Equal("http://web.apps.example.com", report.URL)
+		s.Equal([]string{"Route rejected by router default (HostAlreadyClaimed): route web already exposes web.apps.example.com and is older"}, report.Issues)
+	})
+	s.Run("reports Routes not admitted yet", func() {
+		r := &route{}
+		r.Spec.To = routeBackend{Kind: "Service", Name: "web"}
+		report := inspectRoute(r)
+		s.Require().Len(report.Issues, 1)
+		s.True(strings.HasPrefix(report.Issues[0], "Route is not admitted by any router yet"))
+	})
+}
+
+func (s *OpenShiftSuite) TestSummarizeClusterStatus() {
+	version := &clusterVersion{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "version"},
+		"spec":     map[string]any{"channel": "stable-4.19"},
+		"status": map[string]any{
+			"desired": map[string]any{"version": "4.19.3"},
+			"history": []any{
+				map[string]any{"state": "Partial", "version": "4.19.3", "startedTime": "2026-01-02T03:04:05Z"},
+				map[string]any{"state": "Completed", "version": "4.19.1", "startedTime": "2025-12-01T03:04:05Z", "completionTime": "2025-12-01T04:04:05Z"},
+			},
+			"conditions": []any{
+				map[string]any{"type": "Progressing", "status": "True", "reason": "ClusterOperatorUpdating", "message": "Working towards 4.19.3: 700 of 900 done"},
+				map[string]any{"type": "Upgradeable", "status": "False", "reason": "AdminAckRequired", "message": "Kubernetes 1.33 removes APIs"},
+			},
+			"availableUpdates": []any{map[string]any{"version": "4.19.4"}, map[string]any{"version": "4.19.10"}},
+		},
+	}, version)
+	operators := make([]clusterOperator, 2)
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "authentication"},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+				map[string]any{"type": "Progressing", "status": "False"},
+				map[string]any{"type": "Degraded", "status": "False"},
+			},
+			"versions": []any{map[string]any{"name": "operator", "version": "4.19.3"}},
+		},
+	}, &operators[0])
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "ingress"},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Available", "status": "False", "reason": "IngressUnavailable", "message": "router pods are not ready"},
+			map[string]any{"type": "Progressing", "status": "False"},
+			map[string]any{"type": "Degraded", "status": "True", "reason": "IngressDegraded", "message": "router pods are not ready"},
+		}},
+	}, &operators[1])
+
+	s.Run("reports all ClusterOperators and the upgrade", func() {
+		report := &clusterStatusReport{}
+		summarizeClusterStatus(report, version, operators, false)
+		s.Equal("OpenShift 4.19.1, 2 ClusterOperators: 1 not Available, 1 Degraded, 0 Progressing, upgrading to 4.19.3 since 2026-01-02T03:04:05Z", report.Summary)
+		s.Require().Len(report.ClusterOperators, 2)
+		s.Equal("4.19.3", report.ClusterOperators[0].Version)
+		s.Equal("False (IngressUnavailable: router pods are not ready)", report.ClusterOperators[1].Available)
+		s.Equal("4.19.3", report.ClusterVersion.Desired)
+		s.Equal([]string{"4.19.10", "4.19.4"}, report.ClusterVersion.AvailableUpdates)
+		s.Equal([]string{"minor version upgrades are blocked: Kubernetes 1.33 removes APIs"}, report.ClusterVersion.Issues)
+	})
+	s.Run("filters healthy ClusterOperators", func() {
+		report := &clusterStatusReport{}
+		summarizeClusterStatus(report, nil, operators, true)
+		s.Equal("2 ClusterOperators: 1 not Available, 1 Degraded, 0 Progressing", report.Summary)
+		s.Require().Len(report.ClusterOperators, 1)
+		s.Equal("ingress", report.ClusterOperators[0].Name)
+	})
+}
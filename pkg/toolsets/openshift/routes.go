@@ -0,0 +1,211 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+var routeGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+
+func initRoutes(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "openshift_routes_inspect",
+			Description: "Inspect OpenShift Routes with their URL, backend Services and weights, TLS termination, and whether each router admitted them. " +
+				"Flags Routes not admitted by any router, Routes rejected by a router (e.g. HostAlreadyClaimed), and backends whose Service does not exist",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Routes to inspect (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Route to inspect (Optional, all Routes in the namespace if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "OpenShift: Inspect Routes",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: routesInspect, TargetCompatibilityFilters: []func() bool{
+			hasOpenShiftKind(p, schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}),
+		}},
+	}
+}
+
+// route is the subset of an OpenShift Route inspected by this toolset.
+type route struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Host              string         `json:"host,omitempty"`
+		Path              string         `json:"path,omitempty"`
+		To                routeBackend   `json:"to"`
+		AlternateBackends []routeBackend `json:"alternateBackends,omitempty"`
+		Port              *struct {
+			TargetPort intstr.IntOrString `json:"targetPort"`
+		} `json:"port,omitempty"`
+		TLS *struct {
+			Termination                   string `json:"termination"`
+			InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+		} `json:"tls,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Ingress []struct {
+			Host                    string `json:"host,omitempty"`
+			RouterName              string `json:"routerName,omitempty"`
+			RouterCanonicalHostname string `json:"routerCanonicalHostname,omitempty"`
+			Conditions              []struct {
+				Type    string `json:"type"`
+				Status  string `json:"status"`
+				Reason  string `json:"reason,omitempty"`
+				Message string `json:"message,omitempty"`
+			} `json:"conditions,omitempty"`
+		} `json:"ingress,omitempty"`
+	} `json:"status"`
+}
+
+type routeBackend struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+type routeReport struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	URL       string   `json:"url"`
+	Backends  []string `json:"backends"`
+	Port      string   `json:"port,omitempty"`
+	TLS       string   `json:"tls,omitempty"`
+	Admitted  []string `json:"admitted,omitempty"`
+	Issues    []string `json:"issues,omitempty"`
+}
+
+func routesInspect(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect routes: %w", err)), nil
+	}
+
+	var items []unstructured.Unstructured
+	if name != "" {
+		item, err := params.DynamicClient().Resource(routeGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get route %s/%s: %w", namespace, name, err)), nil
+		}
+		items = append(items, *item)
+	} else {
+		list, err := params.DynamicClient().Resource(routeGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list routes in namespace %s: %w", namespace, err)), nil
+		}
+		items = list.Items
+	}
+	if len(items) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No Routes found in namespace %s", namespace), nil), nil
+	}
+
+	reports := make([]routeReport, 0, len(items))
+	for _, item := range items {
+		r := &route{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, r); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode route %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		report := inspectRoute(r)
+		report.Issues = append(report.Issues, checkRouteBackends(params.Context, params.KubernetesClient, r)...)
+		reports = append(reports, report)
+	}
+	ret, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to inspect routes: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// inspectRoute reports the URL, backends, TLS configuration and admission status of a Route.
+func inspectRoute(r *route) routeReport {
+	report := routeReport{Name: r.Name, Namespace: r.Namespace}
+	scheme := "http"
+	if r.Spec.TLS != nil {
+		scheme = "https"
+		report.TLS = r.Spec.TLS.Termination
+		if r.Spec.TLS.InsecureEdgeTerminationPolicy != "" {
+			report.TLS += fmt.Sprintf(" (insecure traffic: %s)", r.Spec.TLS.InsecureEdgeTerminationPolicy)
+		}
+	}
+	host := r.Spec.Host
+	if host == "" && len(r.Status.Ingress) > 0 {
+		host = r.Status.Ingress[0].Host
+	}
+	report.URL = fmt.Sprintf("%s://%s%s", scheme, host, r.Spec.Path)
+	for _, backend := range append([]routeBackend{r.Spec.To}, r.Spec.AlternateBackends...) {
+		description := backend.Kind + " " + backend.Name
+		if backend.Weight != nil {
+			description += fmt.Sprintf(" (weight %d)", *backend.Weight)
+		}
+		report.Backends = append(report.Backends, description)
+	}
+	if r.Spec.Port != nil {
+		report.Port = r.Spec.Port.TargetPort.String()
+	}
+
+	for _, ingress := range r.Status.Ingress {
+		router := ingress.RouterName
+		if ingress.RouterCanonicalHostname != "" {
+			router += " (" + ingress.RouterCanonicalHostname + ")"
+		}
+		for _, c := range ingress.Conditions {
+			if c.Type != "Admitted" {
+				continue
+			}
+			if c.Status == "True" {
+				report.Admitted = append(report.Admitted, router+": True")
+			} else {
+				report.Admitted = append(report.Admitted, fmt.Sprintf("%s: %s (%s: %s)", router, c.Status, c.Reason, c.Message))
+				report.Issues = append(report.Issues, fmt.Sprintf("Route rejected by router %s (%s): %s", ingress.RouterName, c.Reason, c.Message))
+			}
+		}
+	}
+	if len(r.Status.Ingress) == 0 {
+		report.Issues = append(report.Issues, "Route is not admitted by any router yet, check that a router (IngressController) selects its namespace and labels")
+	}
+	return report
+}
+
+// checkRouteBackends reports the Service backends of a Route that do not exist.
+func checkRouteBackends(ctx context.Context, client kubernetes.Interface, r *route) []string {
+	var issues []string
+	for _, backend := range append([]routeBackend{r.Spec.To}, r.Spec.AlternateBackends...) {
+		if backend.Kind != "" && backend.Kind != "Service" {
+			continue
+		}
+		_, err := client.CoreV1().Services(r.Namespace).Get(ctx, backend.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			issues = append(issues, fmt.Sprintf("backend Service %s/%s not found", r.Namespace, backend.Name))
+		} else if err != nil {
+			issues = append(issues, fmt.Sprintf("failed to get backend Service %s/%s: %v", r.Namespace, backend.Name, err))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,46 @@
+package openshift
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides OpenShift specific tools for builds, image streams, routes and the cluster version.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "openshift"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "OpenShift tools to start builds from BuildConfigs and follow their logs, list ImageStream tags, inspect Routes and their admission, and report the ClusterOperators and ClusterVersion upgrade status"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initBuilds(p),
+		initImageStreams(p),
+		initRoutes(p),
+		initCluster(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
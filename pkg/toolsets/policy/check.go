@@ -0,0 +1,203 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+var (
+	manifestSeparator = regexp.MustCompile(`\r?\n---\r?\n`)
+	// webhookDenial matches the message of a request denied by a validating admission webhook.
+	webhookDenial = regexp.MustCompile(`(?s)admission webhook "([^"]+)" denied the request:\s*(.*)`)
+	// kyvernoBlocked precedes the YAML map of the Kyverno policies and rules that blocked a resource.
+	kyvernoBlocked = regexp.MustCompile(`(?s)was blocked due to the following policies\s*\n(.*)`)
+	// gatekeeperViolation matches each "[constraint] message" line of a Gatekeeper denial.
+	gatekeeperViolation = regexp.MustCompile(`(?m)^\s*\[([^\]]+)\]\s*(.*)$`)
+	// admissionPolicyDenial matches the message of a request denied by a ValidatingAdmissionPolicy.
+	admissionPolicyDenial = regexp.MustCompile(`(?s)ValidatingAdmissionPolicy '([^']+)' with binding '([^']+)' denied request:\s*(.*)`)
+)
+
+func initCheck(_ api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "policy_check_manifest",
+			Description: "Check whether a manifest would pass the admission policies (Kyverno, OPA Gatekeeper, ValidatingAdmissionPolicies and other validating webhooks) " +
+				"without changing the cluster, by applying it with a server-side dry-run. " +
+				"Denials are parsed into the individual policies, rules and messages that rejected each resource, so that the manifest can be fixed before it is applied",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"manifest": {
+						Type:        "string",
+						Description: "YAML or JSON manifest of the resources to check, multiple YAML documents are separated by ---",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the namespaced resources that do not set one (Optional, current namespace if not provided)",
+					},
+				},
+				Required: []string{"manifest"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Policy: Check Manifest",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: checkManifest},
+	}
+}
+
+type checkResult struct {
+	Resource string   `json:"resource"`
+	Allowed  bool     `json:"allowed"`
+	Denials  []denial `json:"denials,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// denial is a policy rule that rejected a resource.
+type denial struct {
+	Source  string `json:"source"`
+	Policy  string `json:"policy,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+func checkManifest(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	manifest := p.RequiredString("manifest")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check manifest: %w", err)), nil
+	}
+
+	objects, err := parseManifest(manifest)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to parse manifest: %w", err)), nil
+	}
+	results := make([]checkResult, 0, len(objects))
+	for _, obj := range objects {
+		results = append(results, dryRunApply(params, obj, namespace))
+	}
+	ret, err := output.MarshalYaml(results)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check manifest: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// parseManifest decodes the YAML or JSON documents of a manifest, skipping the empty ones.
+func parseManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	var ret []*unstructured.Unstructured
+	for i, document := range manifestSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(document)).Decode(&obj.Object); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("document %d: apiVersion, kind and metadata.name are required", i+1)
+		}
+		delete(obj.Object, "status")
+		ret = append(ret, obj)
+	}
+	if len(ret) == 0 {
+		return nil, errors.New("no resources found")
+	}
+	return ret, nil
+}
+
+// dryRunApply server-side applies an object in dry-run mode and reports the policies denying it.
+func dryRunApply(params api.ToolHandlerParams, obj *unstructured.Unstructured, namespace string) checkResult {
+	gvk := obj.GroupVersionKind()
+	result := checkResult{Resource: gvk.Kind + " " + obj.GetName()}
+	mapping, err := params.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to find the resource of %s: %v", gvk.String(), err)
+		return result
+	}
+	ns := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns = obj.GetNamespace()
+		if ns == "" {
+			ns = params.NamespaceOrDefault(namespace)
+		}
+		obj.SetNamespace(ns)
+		result.Resource = gvk.Kind + " " + ns + "/" + obj.GetName()
+	}
+	_, err = params.DynamicClient().Resource(mapping.Resource).Namespace(ns).Apply(params.Context, obj.GetName(), obj, metav1.ApplyOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: version.BinaryName,
+		Force:        true,
+	})
+	if err == nil {
+		result.Allowed = true
+		return result
+	}
+	result.Denials = parseDenial(err.Error())
+	if len(result.Denials) == 0 {
+		// Not a policy denial, e.g. a schema validation error or a missing permission
+		result.Error = err.Error()
+		if apierrors.IsForbidden(err) && !strings.Contains(err.Error(), "denied") {
+			result.Error += " (the dry-run requires the same permissions as applying the resource)"
+		}
+	}
+	return result
+}
+
+// parseDenial parses the denial of an admission request into the individual policies and rules that rejected it.
+func parseDenial(message string) []denial {
+	if m := admissionPolicyDenial.FindStringSubmatch(message); m != nil {
+		return []denial{{Source: "ValidatingAdmissionPolicy", Policy: m[1], Rule: "binding " + m[2], Message: strings.TrimSpace(m[3])}}
+	}
+	m := webhookDenial.FindStringSubmatch(message)
+	if m == nil {
+		return nil
+	}
+	webhook, body := m[1], strings.TrimSpace(m[2])
+
+	if blocked := kyvernoBlocked.FindStringSubmatch(body); blocked != nil {
+		// Kyverno reports the blocking rules as a YAML map of policy to rule to message
+		policies := map[string]map[string]string{}
+		if err := sigsyaml.Unmarshal([]byte(blocked[1]), &policies); err == nil && len(policies) > 0 {
+			var ret []denial
+			for _, policy := range slices.Sorted(maps.Keys(policies)) {
+				for _, rule := range slices.Sorted(maps.Keys(policies[policy])) {
+					ret = append(ret, denial{Source: webhook, Policy: policy, Rule: rule, Message: strings.TrimSpace(policies[policy][rule])})
+				}
+			}
+			return ret
+		}
+	}
+	if violations := gatekeeperViolation.FindAllStringSubmatch(body, -1); len(violations) > 0 {
+		ret := make([]denial, 0, len(violations))
+		for _, v := range violations {
+			ret = append(ret, denial{Source: webhook, Policy: v[1], Message: strings.TrimSpace(v[2])})
+		}
+		return ret
+	}
+	return []denial{{Source: webhook, Message: body}}
+}
@@ -0,0 +1,145 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type PolicySuite struct {
+	suite.Suite
+}
+
+func TestPolicy(t *testing.T) {
+	suite.Run(t, new(PolicySuite))
+}
+
+func (s *PolicySuite) decode(obj map[string]any, into any) {
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, into))
+}
+
+func (s *PolicySuite) TestPolicyReportViolations() {
+	report := policyReport{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "a1b2c3", "namespace": "shop"},
+		"scope":    map[string]any{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"},
+		"results": []any{
+			map[string]any{"policy": "require-labels", "rule": "check-team", "result": "fail", "severity": "medium", "message": "label team is required"},
+			map[string]any{"policy": "disallow-latest", "rule": "require-tag", "result": "pass"},
+			map[string]any{"policy": "restrict-registries", "rule": "allowed", "result": "warn", "message": "untrusted registry",
+				"resources": []any{map[string]any{"kind": "Pod", "name": "web-1", "namespace": "shop"}}},
+		},
+	}, &report)
+	violations := policyReportViolations([]policyReport{report})
+	s.Require().Len(violations, 2)
+	s.Equal(violation{
+		engine: engineKyverno, policy: "require-labels", rule: "check-team", result: "fail", severity: "medium", message: "label team is required",
+		resource: objectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "shop", Name: "web"},
+	}, violations[0])
+	s.Equal(objectRef{Kind: "Pod", Namespace: "shop", Name: "web-1"}, violations[1].resource)
+}
+
+func (s *PolicySuite) TestConstraintViolations() {
+	c := constraint{}
+	s.decode(map[string]any{
+		"kind":     "K8sRequiredLabels",
+		"metadata": map[string]any{"name": "ns-must-have-owner"},
+		"status": map[string]any{
+			"totalViolations": int64(2),
+			"violations": []any{
+				map[string]any{"enforcementAction": "deny", "version": "v1", "kind": "Namespace", "name": "shop", "message": "you must provide labels: {\"owner\"}"},
+				map[string]any{"enforcementAction": "dryrun", "group": "apps", "version": "v1", "kind": "Deployment", "namespace": "shop", "name": "web", "message": "missing owner"},
+			},
+		},
+	}, &c)
+	violations := constraintViolations(&c)
+	s.Require().Len(violations, 2)
+	s.Equal(violation{
+		engine: engineGatekeeper, policy: "K8sRequiredLabels", rule: "ns-must-have-owner", result: "deny", message: "you must provide labels: {\"owner\"}",
+		resource: objectRef{APIVersion: "v1", Kind: "Namespace", Name: "shop"},
+	}, violations[0])
+	s.Equal(objectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "shop", Name: "web"}, violations[1].resource)
+}
+
+func (s *PolicySuite) TestSummarizeViolations() {
+	s.Run("reports no violations", func() {
+		s.Equal(violationsReport{Summary: "No policy violations found"}, summarizeViolations(nil))
+	})
+	s.Run("groups violations per namespace and resource", func() {
+		web := objectRef{Kind: "Deployment", Namespace: "shop", Name: "web"}
+		report := summarizeViolations([]violation{
+			{engine: engineKyverno, policy: "require-labels", rule: "check-team", result: "fail", severity: "medium", message: "label team is required", resource: web},
+			{engine: engineGatekeeper, policy: "K8sRequiredLabels", rule: "must-have-owner", result: "deny", message: "missing owner", resource: web},
+			{engine: engineKyverno, policy: "require-labels", rule: "check-team", result: "fail", message: "label team is required", resource: objectRef{Kind: "Deployment", Namespace: "blog", Name: "api"}},
+			{engine: engineGatekeeper, policy: "K8sRequiredLabels", rule: "ns-must-have-owner", result: "deny", message: "missing owner", resource: objectRef{Kind: "Namespace", Name: "shop"}},
+		})
+		s.Equal("4 violations of 3 resources in 3 namespaces (gatekeeper: 2, kyverno: 2)", report.Summary)
+		s.Equal([]namespaceViolations{
+			{Namespace: "shop", Violations: 2, Policies: []string{"K8sRequiredLabels (1)", "require-labels (1)"}},
+			{Namespace: "(cluster)", Violations: 1, Policies: []string{"K8sRequiredLabels (1)"}},
+			{Namespace: "blog", Violations: 1, Policies: []string{"require-labels (1)"}},
+		}, report.Namespaces)
+		s.Equal(resourceViolations{Resource: "Deployment shop/web", Violations: []string{
+			"kyverno require-labels/check-team (fail, severity medium): label team is required",
+			"gatekeeper K8sRequiredLabels/must-have-owner (deny): missing owner",
+		}}, report.Resources[0])
+		s.Equal("Deployment blog/api", report.Resources[1].Resource)
+		s.Equal("Namespace shop", report.Resources[2].Resource)
+	})
+}
+
+func (s *PolicySuite) TestParseManifest() {
+	s.Run("decodes multiple documents", func() {
+		objects, err := parseManifest("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n---\n{\"apiVersion\": \"v1\", \"kind\": \"Namespace\", \"metadata\": {\"name\": \"b\"}, \"status\": {}}\n")
+		s.Require().NoError(err)
+		s.Require().Len(objects, 2)
+		s.Equal("ConfigMap", objects[0].GetKind())
+		s.Equal("b", objects[1].GetName())
+		s.NotContains(objects[1].Object, "status")
+	})
+	s.Run("rejects documents without name", func() {
+		_, err := parseManifest("apiVersion: v1\nkind: ConfigMap\n")
+		s.ErrorContains(err, "document 1: apiVersion, kind and metadata.name are required")
+	})
+	s.Run("rejects empty manifests", func() {
+		_, err := parseManifest("\n---\n")
+		s.ErrorContains(err, "no resources found")
+	})
+}
+
+func (s *PolicySuite) TestParseDenial() {
+	s.Run("parses Kyverno denials", func() {
+		denials := parseDenial("admission webhook \"validate.kyverno.svc-fail\" denied the request: \n\n" +
+			"resource Deployment/shop/web was blocked due to the following policies \n\n" +
+			"disallow-latest-tag:\n  validate-image-tag: 'validation error: Using a mutable image tag e.g. ''latest'' is not allowed. rule validate-image-tag failed at path /spec/template/spec/containers/0/image/'\n" +
+			"require-labels:\n  check-team: 'validation error: label team is required. rule check-team failed at path /metadata/labels/team/'\n")
+		s.Equal([]denial{
+			{Source: "validate.kyverno.svc-fail", Policy: "disallow-latest-tag", Rule: "validate-image-tag",
+				Message: "validation error: Using a mutable image tag e.g. 'latest' is not allowed. rule validate-image-tag failed at path /spec/template/spec/containers/0/image/"},
+			{Source: "validate.kyverno.svc-fail", Policy: "require-labels", Rule: "check-team",
+				Message: "validation error: label team is required. rule check-team failed at path /metadata/labels/team/"},
+		}, denials)
+	})
+	s.Run("parses Gatekeeper denials", func() {
+		denials := parseDenial("admission webhook \"validation.gatekeeper.sh\" denied the request: " +
+			"[ns-must-have-owner] you must provide labels: {\"owner\"}\n[allowed-repos] container <web> has an invalid image repo <nginx>")
+		s.Equal([]denial{
+			{Source: "validation.gatekeeper.sh", Policy: "ns-must-have-owner", Message: "you must provide labels: {\"owner\"}"},
+			{Source: "validation.gatekeeper.sh", Policy: "allowed-repos", Message: "container <web> has an invalid image repo <nginx>"},
+		}, denials)
+	})
+	s.Run("parses ValidatingAdmissionPolicy denials", func() {
+		denials := parseDenial("deployments.apps \"web\" is forbidden: ValidatingAdmissionPolicy 'replica-limit' with binding 'replica-limit-shop' " +
+			"denied request: failed expression: object.spec.replicas <= 5")
+		s.Equal([]denial{{Source: "ValidatingAdmissionPolicy", Policy: "replica-limit", Rule: "binding replica-limit-shop",
+			Message: "failed expression: object.spec.replicas <= 5"}}, denials)
+	})
+	s.Run("falls back to the webhook message", func() {
+		denials := parseDenial("admission webhook \"check.example.com\" denied the request: replicas must be odd")
+		s.Equal([]denial{{Source: "check.example.com", Message: "replicas must be odd"}}, denials)
+	})
+	s.Run("ignores other errors", func() {
+		s.Nil(parseDenial("Deployment.apps \"web\" is invalid: spec.replicas: Invalid value: -1"))
+	})
+}
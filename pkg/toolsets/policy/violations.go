@@ -0,0 +1,365 @@
+package policy
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	engineKyverno    = "kyverno"
+	engineGatekeeper = "gatekeeper"
+	// maxResources bounds the number of resources with violations returned.
+	maxResources = 50
+)
+
+var (
+	policyReportGVR        = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "policyreports"}
+	clusterPolicyReportGVR = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "clusterpolicyreports"}
+	constraintTemplateGVR  = schema.GroupVersionResource{Group: "templates.gatekeeper.sh", Version: "v1", Resource: "constrainttemplates"}
+	policyReportGVK        = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "PolicyReport"}
+	constraintTemplateGVK  = schema.GroupVersionKind{Group: "templates.gatekeeper.sh", Version: "v1", Kind: "ConstraintTemplate"}
+)
+
+// constraintGVR returns the resource of the Gatekeeper constraints of a ConstraintTemplate, Gatekeeper names it after the lowercase kind.
+func constraintGVR(kind string) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Resource: strings.ToLower(kind)}
+}
+
+// hasPolicyEngine returns a filter that hides the tools when neither Kyverno PolicyReports nor Gatekeeper are installed.
+func hasPolicyEngine(p api.FilteringProvider) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{policyReportGVK}) ||
+			p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{constraintTemplateGVK})
+	}
+}
+
+func initViolations(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "policy_violations_list",
+			Description: "List the admission policy violations reported by Kyverno (PolicyReports and ClusterPolicyReports) and OPA Gatekeeper (constraint audit results), " +
+				"summarized per namespace with the violated policies, and per resource with the policy, rule, result, severity and message of each violation",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Only list the violations of the resources in this namespace (Optional, all namespaces and cluster-scoped resources if not provided)",
+					},
+					"engine": {
+						Type:        "string",
+						Description: "Only list the violations reported by this policy engine (Optional, all the installed engines if not provided)",
+						Enum:        []any{engineKyverno, engineGatekeeper},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Policy: List Violations",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: violationsList, TargetCompatibilityFilters: []func() bool{hasPolicyEngine(p)}},
+	}
+}
+
+type objectRef struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// policyReport is the subset of a PolicyReport or ClusterPolicyReport inspected by this toolset.
+type policyReport struct {
+	metav1.ObjectMeta `json:"metadata"`
+	// Scope is the resource of the report, Kyverno reports the results of each resource in its own report
+	Scope   *objectRef `json:"scope,omitempty"`
+	Results []struct {
+		Policy    string      `json:"policy"`
+		Rule      string      `json:"rule,omitempty"`
+		Result    string      `json:"result"`
+		Severity  string      `json:"severity,omitempty"`
+		Message   string      `json:"message,omitempty"`
+		Resources []objectRef `json:"resources,omitempty"`
+	} `json:"results,omitempty"`
+}
+
+// constraint is the subset of a Gatekeeper constraint inspected by this toolset.
+type constraint struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Kind              string `json:"kind"`
+	Status            struct {
+		TotalViolations int `json:"totalViolations,omitempty"`
+		Violations      []struct {
+			EnforcementAction string `json:"enforcementAction"`
+			Group             string `json:"group,omitempty"`
+			Version           string `json:"version,omitempty"`
+			Kind              string `json:"kind"`
+			Namespace         string `json:"namespace,omitempty"`
+			Name              string `json:"name"`
+			Message           string `json:"message"`
+		} `json:"violations,omitempty"`
+	} `json:"status"`
+}
+
+// violation is a policy violation of a resource reported by a policy engine.
+type violation struct {
+	engine   string
+	policy   string
+	rule     string
+	result   string
+	severity string
+	message  string
+	resource objectRef
+}
+
+type violationsReport struct {
+	Summary    string                `json:"summary"`
+	Namespaces []namespaceViolations `json:"namespaces,omitempty"`
+	Resources  []resourceViolations  `json:"resources,omitempty"`
+	Errors     []string              `json:"errors,omitempty"`
+}
+
+type namespaceViolations struct {
+	Namespace  string   `json:"namespace"`
+	Violations int      `json:"violations"`
+	Policies   []string `json:"policies"`
+}
+
+type resourceViolations struct {
+	Resource   string   `json:"resource"`
+	Violations []string `json:"violations"`
+}
+
+func violationsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	engine := p.OptionalString("engine", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list policy violations: %w", err)), nil
+	}
+
+	var violations []violation
+	var errs []string
+	if engine == "" || engine == engineKyverno {
+		v, err := kyvernoViolations(params, namespace)
+		violations = append(violations, v...)
+		errs = append(errs, err...)
+	}
+	if engine == "" || engine == engineGatekeeper {
+		v, err := gatekeeperViolations(params, namespace)
+		violations = append(violations, v...)
+		errs = append(errs, err...)
+	}
+	report := summarizeViolations(violations)
+	report.Errors = errs
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list policy violations: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func kyvernoViolations(params api.ToolHandlerParams, namespace string) ([]violation, []string) {
+	var items []unstructured.Unstructured
+	var errs []string
+	list, err := params.DynamicClient().Resource(policyReportGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// PolicyReports are not installed
+		return nil, nil
+	case err != nil:
+		return nil, []string{fmt.Sprintf("failed to list policyreports: %v", err)}
+	default:
+		items = list.Items
+	}
+	if namespace == "" {
+		list, err = params.DynamicClient().Resource(clusterPolicyReportGVR).List(params.Context, metav1.ListOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("failed to list clusterpolicyreports: %v", err))
+		} else if err == nil {
+			items = append(items, list.Items...)
+		}
+	}
+	reports := make([]policyReport, 0, len(items))
+	for _, item := range items {
+		report := policyReport{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &report); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to decode policyreport %s/%s: %v", item.GetNamespace(), item.GetName(), err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return policyReportViolations(reports), errs
+}
+
+// policyReportViolations returns the failed, warned and errored results of PolicyReports.
+func policyReportViolations(reports []policyReport) []violation {
+	var ret []violation
+	for _, report := range reports {
+		for _, result := range report.Results {
+			if result.Result != "fail" && result.Result != "warn" && result.Result != "error" {
+				continue
+			}
+			resources := result.Resources
+			if len(resources) == 0 && report.Scope != nil {
+				resources = []objectRef{*report.Scope}
+			}
+			for _, resource := range resources {
+				if resource.Namespace == "" {
+					resource.Namespace = report.Namespace
+				}
+				ret = append(ret, violation{
+					engine:   engineKyverno,
+					policy:   result.Policy,
+					rule:     result.Rule,
+					result:   result.Result,
+					severity: result.Severity,
+					message:  result.Message,
+					resource: resource,
+				})
+			}
+		}
+	}
+	return ret
+}
+
+func gatekeeperViolations(params api.ToolHandlerParams, namespace string) ([]violation, []string) {
+	templates, err := params.DynamicClient().Resource(constraintTemplateGVR).List(params.Context, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		// Gatekeeper is not installed
+		return nil, nil
+	} else if err != nil {
+		return nil, []string{fmt.Sprintf("failed to list constrainttemplates: %v", err)}
+	}
+	var ret []violation
+	var errs []string
+	for _, template := range templates.Items {
+		kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+		if kind == "" {
+			continue
+		}
+		list, err := params.DynamicClient().Resource(constraintGVR(kind)).List(params.Context, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to list %s constraints: %v", kind, err))
+			continue
+		}
+		for _, item := range list.Items {
+			c := constraint{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &c); err != nil {
+				errs = append(errs, fmt.Sprintf("failed to decode %s constraint %s: %v", kind, item.GetName(), err))
+				continue
+			}
+			for _, v := range constraintViolations(&c) {
+				if namespace == "" || v.resource.Namespace == namespace {
+					ret = append(ret, v)
+				}
+			}
+		}
+	}
+	return ret, errs
+}
+
+// constraintViolations returns the audit violations of a Gatekeeper constraint.
+func constraintViolations(c *constraint) []violation {
+	ret := make([]violation, 0, len(c.Status.Violations))
+	for _, v := range c.Status.Violations {
+		apiVersion := v.Version
+		if v.Group != "" {
+			apiVersion = v.Group + "/" + v.Version
+		}
+		ret = append(ret, violation{
+			engine:   engineGatekeeper,
+			policy:   c.Kind,
+			rule:     c.Name,
+			result:   v.EnforcementAction,
+			message:  v.Message,
+			resource: objectRef{APIVersion: apiVersion, Kind: v.Kind, Namespace: v.Namespace, Name: v.Name},
+		})
+	}
+	return ret
+}
+
+// summarizeViolations groups the violations per namespace and per resource, the namespaces and resources with the most violations first.
+func summarizeViolations(violations []violation) violationsReport {
+	if len(violations) == 0 {
+		return violationsReport{Summary: "No policy violations found"}
+	}
+	byNamespace := map[string]map[string]int{}
+	byResource := map[string][]string{}
+	engines := map[string]int{}
+	for _, v := range violations {
+		ns := v.resource.Namespace
+		if ns == "" {
+			ns = "(cluster)"
+		}
+		if byNamespace[ns] == nil {
+			byNamespace[ns] = map[string]int{}
+		}
+		byNamespace[ns][v.policy]++
+		engines[v.engine]++
+
+		resource := v.resource.Kind + " " + v.resource.Name
+		if v.resource.Namespace != "" {
+			resource = v.resource.Kind + " " + v.resource.Namespace + "/" + v.resource.Name
+		}
+		description := fmt.Sprintf("%s %s", v.engine, v.policy)
+		if v.rule != "" {
+			description += "/" + v.rule
+		}
+		description += " (" + v.result
+		if v.severity != "" {
+			description += ", severity " + v.severity
+		}
+		description += "): " + v.message
+		byResource[resource] = append(byResource[resource], description)
+	}
+
+	report := violationsReport{}
+	for ns, policies := range byNamespace {
+		n := namespaceViolations{Namespace: ns}
+		for _, policy := range slices.Sorted(maps.Keys(policies)) {
+			n.Violations += policies[policy]
+			n.Policies = append(n.Policies, fmt.Sprintf("%s (%d)", policy, policies[policy]))
+		}
+		report.Namespaces = append(report.Namespaces, n)
+	}
+	slices.SortFunc(report.Namespaces, func(a, b namespaceViolations) int {
+		return cmp.Or(cmp.Compare(b.Violations, a.Violations), strings.Compare(a.Namespace, b.Namespace))
+	})
+	for resource, descriptions := range byResource {
+		report.Resources = append(report.Resources, resourceViolations{Resource: resource, Violations: descriptions})
+	}
+	slices.SortFunc(report.Resources, func(a, b resourceViolations) int {
+		return cmp.Or(cmp.Compare(len(b.Violations), len(a.Violations)), strings.Compare(a.Resource, b.Resource))
+	})
+
+	var perEngine []string
+	for _, engine := range slices.Sorted(maps.Keys(engines)) {
+		perEngine = append(perEngine, fmt.Sprintf("%s: %d", engine, engines[engine]))
+	}
+	report.Summary = fmt.Sprintf("%d violations of %d resources in %d namespaces (%s)",
+		len(violations), len(report.Resources), len(report.Namespaces), strings.Join(perEngine, ", "))
+	if len(report.Resources) > maxResources {
+		report.Summary += fmt.Sprintf(", only the %d resources with the most violations are returned", maxResources)
+		report.Resources = report.Resources[:maxResources]
+	}
+	return report
+}
@@ -0,0 +1,104 @@
+package prometheus
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+func initAlerts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "prometheus_alerts",
+			Description: "List the active (pending and firing) alerts evaluated by Prometheus, sorted by severity and activation time. " +
+				"Filter by state, alert name, or namespace label",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"state": {
+						Type:        "string",
+						Description: "Only return alerts in this state (Optional, all active alerts if not provided)",
+						Enum:        []any{"firing", "pending"},
+					},
+					"alertname": {
+						Type:        "string",
+						Description: "Only return alerts with this alertname (Optional)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Only return alerts with this namespace label (Optional)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Alerts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: prometheusAlerts},
+	}
+}
+
+// severityRank orders the conventional alert severities, unknown severities are sorted last.
+var severityRank = map[string]int{"critical": 0, "error": 1, "warning": 2, "info": 3, "none": 4}
+
+func prometheusAlerts(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	state := p.OptionalString("state", "")
+	alertName := p.OptionalString("alertname", "")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list prometheus alerts: %w", err)), nil
+	}
+	client, err := prometheus.NewPrometheus(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list prometheus alerts: %w", err)), nil
+	}
+	alerts, err := client.Alerts(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list prometheus alerts: %w", err)), nil
+	}
+	alerts = filterAlerts(alerts, state, alertName, namespace)
+	if len(alerts) == 0 {
+		return api.NewToolCallResult("No active alerts found", nil), nil
+	}
+	out, err := output.MarshalYaml(alerts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal prometheus alerts: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
+
+func filterAlerts(alerts []prometheus.Alert, state, alertName, namespace string) []prometheus.Alert {
+	alerts = slices.DeleteFunc(alerts, func(a prometheus.Alert) bool {
+		return (state != "" && a.State != state) ||
+			(alertName != "" && a.Labels["alertname"] != alertName) ||
+			(namespace != "" && a.Labels["namespace"] != namespace)
+	})
+	slices.SortStableFunc(alerts, func(a, b prometheus.Alert) int {
+		if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+			return c
+		}
+		if a.ActiveAt == nil || b.ActiveAt == nil {
+			return 0
+		}
+		return a.ActiveAt.Compare(*b.ActiveAt)
+	})
+	return alerts
+}
+
+func rank(a prometheus.Alert) int {
+	if r, ok := severityRank[a.Labels["severity"]]; ok {
+		return r
+	}
+	return len(severityRank)
+}
@@ -0,0 +1,171 @@
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+const (
+	defaultMetadataLimit = 200
+	maxMetadataLimit     = 5000
+)
+
+func initMetadata() []api.ServerTool {
+	timeRangeProperties := func(action string) map[string]*jsonschema.Schema {
+		return map[string]*jsonschema.Schema{
+			"start": {
+				Type:        "string",
+				Description: fmt.Sprintf("Only %s series present after this time, RFC3339, Unix timestamp, or relative time such as -1h (Optional)", action),
+			},
+			"end": {
+				Type:        "string",
+				Description: fmt.Sprintf("Only %s series present before this time, RFC3339, Unix timestamp, or relative time such as -5m (Optional)", action),
+			},
+			"limit": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum number of entries to return (Optional, defaults to %d, max %d)", defaultMetadataLimit, maxMetadataLimit),
+				Default:     api.ToRawMessage(defaultMetadataLimit),
+				Minimum:     ptr.To(float64(1)),
+				Maximum:     ptr.To(float64(maxMetadataLimit)),
+			},
+		}
+	}
+	seriesProperties := timeRangeProperties("return")
+	seriesProperties["match"] = &jsonschema.Schema{
+		Type:        "array",
+		Description: "Series selectors, e.g. [\"up{job=\\\"kubelet\\\"}\", \"kube_pod_info{namespace=\\\"default\\\"}\"]",
+		Items:       &jsonschema.Schema{Type: "string"},
+		MinItems:    ptr.To(1),
+	}
+	labelsProperties := timeRangeProperties("consider")
+	labelsProperties["label"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Label name whose values are returned, e.g. namespace or __name__ to list metric names (Optional, label names are returned if not provided)",
+	}
+	labelsProperties["match"] = &jsonschema.Schema{
+		Type:        "array",
+		Description: "Series selectors restricting the series whose labels are considered (Optional)",
+		Items:       &jsonschema.Schema{Type: "string"},
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "prometheus_series",
+			Description: "Find the Prometheus series matching the provided selectors and return their label sets",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: seriesProperties,
+				Required:   []string{"match"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Series",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: prometheusSeries},
+		{Tool: api.Tool{
+			Name: "prometheus_labels",
+			Description: "Discover Prometheus label names, or the values of a label when label is provided. " +
+				"Use label=__name__ to discover metric names",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: labelsProperties,
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Labels",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: prometheusLabels},
+	}
+}
+
+type metadataReport[T any] struct {
+	Total     int  `json:"total"`
+	Truncated bool `json:"truncated,omitempty"`
+	Items     []T  `json:"items"`
+}
+
+func newMetadataReport[T any](items []T, limit int) *metadataReport[T] {
+	return &metadataReport[T]{Total: len(items), Truncated: len(items) > limit, Items: items[:min(len(items), limit)]}
+}
+
+// timeRangeParams extracts the start, end, and limit parameters shared by the metadata tools.
+func timeRangeParams(p *api.Params) (start, end time.Time, limit int, err error) {
+	startParam := p.OptionalString("start", "")
+	endParam := p.OptionalString("end", "")
+	limit = clamp(p.OptionalInt64("limit", defaultMetadataLimit), 1, maxMetadataLimit)
+	if err = p.Err(); err != nil {
+		return
+	}
+	now := time.Now()
+	if start, err = observability.ParseTime(startParam, now); err != nil {
+		return
+	}
+	end, err = observability.ParseTime(endParam, now)
+	return
+}
+
+func prometheusSeries(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	matches := p.OptionalStringArray("match")
+	if p.Err() == nil && len(matches) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus series: match parameter required")), nil
+	}
+	start, end, limit, err := timeRangeParams(p)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus series: %w", err)), nil
+	}
+	client, err := prometheus.NewPrometheus(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus series: %w", err)), nil
+	}
+	series, err := client.Series(params.Context, matches, start, end)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus series: %w", err)), nil
+	}
+	out, err := output.MarshalYaml(newMetadataReport(series, limit))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal prometheus series: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
+
+func prometheusLabels(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	matches := p.OptionalStringArray("match")
+	label := p.OptionalString("label", "")
+	start, end, limit, err := timeRangeParams(p)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus labels: %w", err)), nil
+	}
+	client, err := prometheus.NewPrometheus(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus labels: %w", err)), nil
+	}
+	var labels []string
+	if label == "" {
+		labels, err = client.Labels(params.Context, matches, start, end)
+	} else {
+		labels, err = client.LabelValues(params.Context, label, matches, start, end)
+	}
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get prometheus labels: %w", err)), nil
+	}
+	out, err := output.MarshalYaml(newMetadataReport(labels, limit))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal prometheus labels: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultMaxPoints = 60
+	maxMaxPoints     = 1000
+	defaultMaxSeries = 20
+	maxMaxSeries     = 500
+)
+
+// parseStep parses a query resolution step expressed as a duration ("30s", "5m") or as a number of seconds.
+func parseStep(value string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		if f <= 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, fmt.Errorf("invalid step %q: must be positive", value)
+		}
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	d, err := model.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid step %q: %w", value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid step %q: must be positive", value)
+	}
+	return time.Duration(d), nil
+}
+
+// autoStep returns the smallest whole-second step that yields at most maxPoints points over the range.
+func autoStep(start, end time.Time, maxPoints int) time.Duration {
+	step := time.Duration(math.Ceil(end.Sub(start).Seconds()/float64(max(maxPoints-1, 1)))) * time.Second
+	return max(step, time.Second)
+}
+
+// clamp bounds v to [lo, hi].
+func clamp(v, lo, hi int64) int {
+	return int(min(max(v, lo), hi))
+}
@@ -0,0 +1,61 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+type ParamsSuite struct {
+	suite.Suite
+}
+
+func (s *ParamsSuite) TestParseStep() {
+	step, err := parseStep("5m")
+	s.Require().NoError(err)
+	s.Equal(5*time.Minute, step)
+	step, err = parseStep("30")
+	s.Require().NoError(err)
+	s.Equal(30*time.Second, step)
+	_, err = parseStep("0")
+	s.ErrorContains(err, "must be positive")
+}
+
+func (s *ParamsSuite) TestAutoStep() {
+	start := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	s.Equal(62*time.Second, autoStep(start, start.Add(time.Hour), 60))
+	s.Equal(time.Second, autoStep(start, start.Add(10*time.Second), 60))
+}
+
+func (s *ParamsSuite) TestFilterAlerts() {
+	activeAt := func(minutes int) *time.Time {
+		t := time.Date(2026, 1, 2, 0, minutes, 0, 0, time.UTC)
+		return &t
+	}
+	alerts := []prometheus.Alert{
+		{Labels: map[string]string{"alertname": "Watchdog", "severity": "none"}, State: "firing", ActiveAt: activeAt(0)},
+		{Labels: map[string]string{"alertname": "KubePodCrashLooping", "severity": "warning", "namespace": "ns-1"}, State: "firing", ActiveAt: activeAt(2)},
+		{Labels: map[string]string{"alertname": "KubePodCrashLooping", "severity": "warning", "namespace": "ns-2"}, State: "pending", ActiveAt: activeAt(1)},
+		{Labels: map[string]string{"alertname": "TargetDown", "severity": "critical", "namespace": "ns-1"}, State: "firing", ActiveAt: activeAt(3)},
+	}
+	s.Run("sorts by severity and activation time", func() {
+		filtered := filterAlerts(append([]prometheus.Alert{}, alerts...), "", "", "")
+		s.Require().Len(filtered, 4)
+		s.Equal("TargetDown", filtered[0].Labels["alertname"])
+		s.Equal("ns-2", filtered[1].Labels["namespace"])
+		s.Equal("ns-1", filtered[2].Labels["namespace"])
+		s.Equal("Watchdog", filtered[3].Labels["alertname"])
+	})
+	s.Run("filters by state, alertname, and namespace", func() {
+		s.Len(filterAlerts(append([]prometheus.Alert{}, alerts...), "firing", "", ""), 3)
+		s.Len(filterAlerts(append([]prometheus.Alert{}, alerts...), "", "KubePodCrashLooping", ""), 2)
+		s.Len(filterAlerts(append([]prometheus.Alert{}, alerts...), "firing", "", "ns-1"), 2)
+	})
+}
+
+func TestParams(t *testing.T) {
+	suite.Run(t, new(ParamsSuite))
+}
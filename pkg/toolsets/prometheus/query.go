@@ -0,0 +1,221 @@
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/observability"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+func initQuery() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "prometheus_query",
+			Description: "Evaluate a PromQL instant query against Prometheus and return the resulting vector or scalar. " +
+				"Use aggregations (sum by, topk) to keep results small, only the first max_series series are returned",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL expression to evaluate, e.g. sum by (namespace) (rate(container_cpu_usage_seconds_total[5m]))",
+					},
+					"time": {
+						Type:        "string",
+						Description: "Evaluation time as RFC3339, Unix timestamp, or relative time such as -1h (Optional, defaults to now)",
+					},
+					"max_series": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of series to return (Optional, defaults to %d, max %d)", defaultMaxSeries, maxMaxSeries),
+						Default:     api.ToRawMessage(defaultMaxSeries),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(maxMaxSeries)),
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: prometheusQuery},
+		{Tool: api.Tool{
+			Name: "prometheus_query_range",
+			Description: "Evaluate a PromQL range query against Prometheus and return the resulting time series. " +
+				"Each series is downsampled to at most max_points evenly spaced points and reports the min, max, and last values over the whole range, " +
+				"only the first max_series series are returned",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL expression to evaluate, e.g. sum by (pod) (container_memory_working_set_bytes{namespace=\"default\"})",
+					},
+					"start": {
+						Type:        "string",
+						Description: "Start of the range as RFC3339, Unix timestamp, or relative time such as -1h (Optional, defaults to -1h)",
+						Default:     api.ToRawMessage("-1h"),
+					},
+					"end": {
+						Type:        "string",
+						Description: "End of the range as RFC3339, Unix timestamp, or relative time such as -5m (Optional, defaults to now)",
+						Default:     api.ToRawMessage("now"),
+					},
+					"step": {
+						Type:        "string",
+						Description: "Query resolution step as a duration (30s, 5m) or number of seconds (Optional, computed from the range and max_points if not provided)",
+					},
+					"max_points": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of points returned per series (Optional, defaults to %d, max %d)", defaultMaxPoints, maxMaxPoints),
+						Default:     api.ToRawMessage(defaultMaxPoints),
+						Minimum:     ptr.To(float64(2)),
+						Maximum:     ptr.To(float64(maxMaxPoints)),
+					},
+					"max_series": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of series to return (Optional, defaults to %d, max %d)", defaultMaxSeries, maxMaxSeries),
+						Default:     api.ToRawMessage(defaultMaxSeries),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(maxMaxSeries)),
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Query Range",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: prometheusQueryRange},
+	}
+}
+
+type queryReport struct {
+	ResultType  string     `json:"resultType"`
+	Result      any        `json:"result"`
+	TotalSeries int        `json:"totalSeries,omitempty"`
+	Truncated   bool       `json:"truncated,omitempty"`
+	Start       *time.Time `json:"start,omitempty"`
+	End         *time.Time `json:"end,omitempty"`
+	Step        string     `json:"step,omitempty"`
+	Warnings    []string   `json:"warnings,omitempty"`
+	Hint        string     `json:"hint,omitempty"`
+}
+
+func prometheusQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	query := p.RequiredString("query")
+	evalTime := p.OptionalString("time", "")
+	maxSeries := clamp(p.OptionalInt64("max_series", defaultMaxSeries), 1, maxMaxSeries)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	ts, err := observability.ParseTime(evalTime, time.Now())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	client, err := prometheus.NewPrometheus(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	result, err := client.Query(params.Context, query, ts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	report := &queryReport{ResultType: result.ResultType, Warnings: result.Warnings}
+	switch result.ResultType {
+	case "vector":
+		report.TotalSeries = len(result.Vector)
+		report.Truncated = len(result.Vector) > maxSeries
+		report.Result = result.Vector[:min(len(result.Vector), maxSeries)]
+	case "matrix":
+		// Instant queries of range selectors (e.g. up[5m]) return raw samples
+		report.TotalSeries = len(result.Matrix)
+		report.Truncated = len(result.Matrix) > maxSeries
+		report.Result = downsampleAll(result.Matrix[:min(len(result.Matrix), maxSeries)], defaultMaxPoints)
+	default:
+		report.Result = result.Scalar
+	}
+	return formatQueryReport(report, maxSeries)
+}
+
+func prometheusQueryRange(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	query := p.RequiredString("query")
+	startParam := p.OptionalString("start", "-1h")
+	endParam := p.OptionalString("end", "now")
+	stepParam := p.OptionalString("step", "")
+	maxPoints := clamp(p.OptionalInt64("max_points", defaultMaxPoints), 2, maxMaxPoints)
+	maxSeries := clamp(p.OptionalInt64("max_series", defaultMaxSeries), 1, maxMaxSeries)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	now := time.Now()
+	start, err := observability.ParseTime(startParam, now)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: start: %w", err)), nil
+	}
+	end, err := observability.ParseTime(endParam, now)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: end: %w", err)), nil
+	}
+	if !end.After(start) {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: end (%s) must be after start (%s)", end.Format(time.RFC3339), start.Format(time.RFC3339))), nil
+	}
+	step := autoStep(start, end, maxPoints)
+	if stepParam != "" {
+		if step, err = parseStep(stepParam); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+		}
+	}
+	client, err := prometheus.NewPrometheus(params.Context, params, params.KubernetesClient, params.FilteringProvider)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	result, err := client.QueryRange(params.Context, query, start, end, step)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	report := &queryReport{
+		ResultType:  result.ResultType,
+		TotalSeries: len(result.Matrix),
+		Truncated:   len(result.Matrix) > maxSeries,
+		Start:       &start,
+		End:         &end,
+		Step:        step.String(),
+		Warnings:    result.Warnings,
+		Result:      downsampleAll(result.Matrix[:min(len(result.Matrix), maxSeries)], maxPoints),
+	}
+	return formatQueryReport(report, maxSeries)
+}
+
+func downsampleAll(matrix []observability.RangeSeries, maxPoints int) []observability.DownsampledSeries {
+	ret := make([]observability.DownsampledSeries, 0, len(matrix))
+	for _, series := range matrix {
+		ret = append(ret, observability.Downsample(series, maxPoints))
+	}
+	return ret
+}
+
+func formatQueryReport(report *queryReport, maxSeries int) (*api.ToolCallResult, error) {
+	if report.Truncated {
+		report.Hint = fmt.Sprintf("only the first %d of %d series are shown, refine the query (e.g. topk or sum by) to reduce the number of series", maxSeries, report.TotalSeries)
+	}
+	out, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal query result: %w", err)), nil
+	}
+	return api.NewToolCallResult(out, nil), nil
+}
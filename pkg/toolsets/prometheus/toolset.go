@@ -0,0 +1,44 @@
+package prometheus
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "prometheus"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Prometheus metrics tools (PromQL instant and range queries, series and label discovery, active alerts). Check the [Prometheus documentation](https://github.com/containers/kubernetes-mcp-server/blob/main/docs/PROMETHEUS.md) for more details."
+}
+
+func (t *Toolset) GetTools(_ api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initQuery(),
+		initMetadata(),
+		initAlerts(),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
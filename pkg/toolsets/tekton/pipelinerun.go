@@ -3,10 +3,13 @@ package tekton
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
 	"github.com/google/jsonschema-go/jsonschema"
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -14,9 +17,11 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
 )
 
 type pipelineRunLifecycleAction string
@@ -43,6 +48,32 @@ func pipelineRunTools() []api.ServerTool {
 			},
 			Handler: pipelineRunLifecycle,
 		},
+		{
+			Tool: api.Tool{
+				Name: "tekton_pipelinerun_list",
+				Description: "List Tekton PipelineRuns, most recent first, with a compact summary of each run: status and reason, start time, duration, " +
+					"failed task names and the first error message. Filter by pipeline, status, labels and time window, e.g. status=Failed and since=1h to find what failed in the last hour",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: func() map[string]*jsonschema.Schema {
+						properties := runListProperties("PipelineRun")
+						properties["pipeline"] = &jsonschema.Schema{
+							Type:        "string",
+							Description: "Only list the PipelineRuns of this Pipeline (tekton.dev/pipeline label) (Optional)",
+						}
+						return properties
+					}(),
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Tekton: List PipelineRuns",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(true),
+				},
+			},
+			Handler: listPipelineRuns,
+		},
 		{
 			Tool: api.Tool{
 				Name:        "tekton_pipelinerun_logs",
@@ -176,6 +207,146 @@ func restartPipelineRun(ctx context.Context, dynamicClient dynamic.Interface, na
 	return api.NewToolCallResult(fmt.Sprintf("PipelineRun '%s' restarted as '%s' in namespace '%s'", name, createdName, namespace), nil), nil
 }
 
+func listPipelineRuns(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", params.NamespaceOrDefault(""))
+	pipelineName := p.OptionalString("pipeline", "")
+	labelSelector := p.OptionalString("label_selector", "")
+	status := p.OptionalString("status", "")
+	since := p.OptionalString("since", "")
+	limit := p.OptionalInt64("limit", defaultRunsLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pipeline runs: %w", err)), nil
+	}
+	filter, err := parseRunFilter(status, since, limit)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pipeline runs: %w", err)), nil
+	}
+	selector, err := runListSelector(labelSelector, map[string]string{pipeline.PipelineLabelKey: pipelineName})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pipeline runs: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(pipelineRunGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list PipelineRuns in namespace %s: %w", namespace, err)), nil
+	}
+	now := time.Now()
+	runs := make([]runSummary, 0, len(list.Items))
+	for _, item := range list.Items {
+		var pipelineRun tektonv1.PipelineRun
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &pipelineRun); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to convert PipelineRun %s from unstructured: %w", item.GetName(), err)), nil
+		}
+		runs = append(runs, summarizePipelineRun(&pipelineRun, now))
+	}
+	runs = filter.apply(runs)
+	if len(runs) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No PipelineRuns found in namespace '%s' matching the filters", namespace), nil), nil
+	}
+	taskRuns, err := failedPipelineRunsTaskRuns(params.Context, params.DynamicClient(), namespace, runs)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list TaskRuns of the failed PipelineRuns in namespace %s: %w", namespace, err)), nil
+	}
+	for i := range runs {
+		if runs[i].Status == runStatusFailed {
+			addFailedTasks(&runs[i], taskRuns[types.NamespacedName{Namespace: runs[i].Namespace, Name: runs[i].Name}])
+		}
+	}
+	ret, err := output.MarshalYaml(runs)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pipeline runs: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// summarizePipelineRun returns the compact summary of a PipelineRun, the failed tasks are added by addFailedTasks.
+func summarizePipelineRun(pipelineRun *tektonv1.PipelineRun, now time.Time) runSummary {
+	summary := runSummary{
+		Name:      pipelineRun.Name,
+		Namespace: pipelineRun.Namespace,
+		Pipeline:  pipelineRun.Labels[pipeline.PipelineLabelKey],
+	}
+	if summary.Pipeline == "" && pipelineRun.Spec.PipelineRef != nil {
+		summary.Pipeline = pipelineRun.Spec.PipelineRef.Name
+	}
+	summary.setCondition(pipelineRun.Status.GetCondition(apis.ConditionSucceeded))
+	summary.setTimes(pipelineRun.CreationTimestamp, pipelineRun.Status.StartTime, pipelineRun.Status.CompletionTime, now)
+	return summary
+}
+
+// failedPipelineRunsTaskRuns lists the TaskRuns of the failed PipelineRuns in a single request, grouped by PipelineRun.
+func failedPipelineRunsTaskRuns(ctx context.Context, dynamicClient dynamic.Interface, namespace string, runs []runSummary) (map[types.NamespacedName][]tektonv1.TaskRun, error) {
+	var names []string
+	for _, run := range runs {
+		if run.Status == runStatusFailed && !slices.Contains(names, run.Name) {
+			names = append(names, run.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	slices.Sort(names)
+	requirement, err := labels.NewRequirement(pipeline.PipelineRunLabelKey, selection.In, names)
+	if err != nil {
+		return nil, err
+	}
+	list, err := dynamicClient.Resource(taskRunGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*requirement).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	taskRuns := make([]tektonv1.TaskRun, 0, len(list.Items))
+	for _, item := range list.Items {
+		var taskRun tektonv1.TaskRun
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &taskRun); err != nil {
+			return nil, err
+		}
+		taskRuns = append(taskRuns, taskRun)
+	}
+	return groupTaskRunsByPipelineRun(taskRuns), nil
+}
+
+// groupTaskRunsByPipelineRun groups TaskRuns by the namespace and name of their PipelineRun, read from the owner reference
+// or else from the pipelineRun label, so that the PipelineRuns with the same name in different namespaces are kept apart.
+func groupTaskRunsByPipelineRun(taskRuns []tektonv1.TaskRun) map[types.NamespacedName][]tektonv1.TaskRun {
+	ret := map[types.NamespacedName][]tektonv1.TaskRun{}
+	for _, taskRun := range taskRuns {
+		pipelineRun := types.NamespacedName{Namespace: taskRun.Namespace, Name: taskRun.Labels[pipeline.PipelineRunLabelKey]}
+		for _, owner := range taskRun.OwnerReferences {
+			if owner.Kind == pipeline.PipelineRunControllerName {
+				pipelineRun.Name = owner.Name
+				break
+			}
+		}
+		ret[pipelineRun] = append(ret[pipelineRun], taskRun)
+	}
+	return ret
+}
+
+// addFailedTasks adds the names of the failed TaskRuns of a PipelineRun to its summary, and the error message of the first
+// failed TaskRun, which is more precise than the message of the PipelineRun.
+func addFailedTasks(summary *runSummary, taskRuns []tektonv1.TaskRun) {
+	failed := make([]runSummary, 0, len(taskRuns))
+	for i := range taskRuns {
+		if taskRun := summarizeTaskRun(&taskRuns[i], time.Now()); taskRun.Status == runStatusFailed {
+			failed = append(failed, taskRun)
+		}
+	}
+	slices.SortFunc(failed, func(a, b runSummary) int { return a.started.Compare(b.started) })
+	for _, taskRun := range failed {
+		name := taskRun.PipelineTask
+		if name == "" {
+			name = taskRun.Name
+		}
+		summary.FailedTasks = append(summary.FailedTasks, name)
+	}
+	if len(failed) > 0 && failed[0].Error != "" {
+		summary.Error = summary.FailedTasks[0] + ": " + failed[0].Error
+	}
+}
+
 func getPipelineRunLogs(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	name := p.RequiredString("name")
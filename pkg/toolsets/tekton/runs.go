@@ -0,0 +1,185 @@
+package tekton
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+)
+
+// Status of a PipelineRun or TaskRun, derived from its Succeeded condition
+const (
+	runStatusSucceeded = "Succeeded"
+	runStatusFailed    = "Failed"
+	runStatusCancelled = "Cancelled"
+	runStatusRunning   = "Running"
+	runStatusPending   = "Pending"
+)
+
+// runStatuses are the statuses the list tools filter on
+var runStatuses = []string{runStatusSucceeded, runStatusFailed, runStatusCancelled, runStatusRunning, runStatusPending}
+
+const defaultRunsLimit = 20
+
+// runSummary is the compact summary of a PipelineRun or TaskRun returned by the list tools.
+type runSummary struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	Pipeline     string   `json:"pipeline,omitempty"`
+	PipelineRun  string   `json:"pipelineRun,omitempty"`
+	PipelineTask string   `json:"pipelineTask,omitempty"`
+	Task         string   `json:"task,omitempty"`
+	Status       string   `json:"status"`
+	Reason       string   `json:"reason,omitempty"`
+	StartTime    string   `json:"startTime,omitempty"`
+	Duration     string   `json:"duration,omitempty"`
+	FailedTasks  []string `json:"failedTasks,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	// started is the start time of the run, or its creation time if not started yet, to sort the runs
+	started time.Time
+	// completed is the completion time of the run, zero while the run is pending or running, to filter the runs
+	completed time.Time
+}
+
+// runFilter holds the filters shared by the PipelineRun and TaskRun list tools.
+type runFilter struct {
+	status string
+	since  time.Time
+	limit  int64
+}
+
+// runListProperties returns the input schema properties shared by the PipelineRun and TaskRun list tools.
+func runListProperties(kind string) map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"namespace": {
+			Type:        "string",
+			Description: fmt.Sprintf("Namespace of the %ss (Optional, current namespace if not provided)", kind),
+		},
+		"status": {
+			Type:        "string",
+			Description: fmt.Sprintf("Only list the %ss with this status (Optional)", kind),
+			Enum:        []any{runStatusSucceeded, runStatusFailed, runStatusCancelled, runStatusRunning, runStatusPending},
+		},
+		"label_selector": {
+			Type:        "string",
+			Description: fmt.Sprintf("Only list the %ss matching this label selector, e.g. app=web (Optional)", kind),
+		},
+		"since": {
+			Type:        "string",
+			Description: fmt.Sprintf("Only list the %ss active within this time window, i.e. pending, running or finished within it, e.g. 1h or 30m (Optional)", kind),
+		},
+		"limit": {
+			Type:        "integer",
+			Description: fmt.Sprintf("Maximum number of %ss to return, most recent first (Optional, defaults to %d)", kind, defaultRunsLimit),
+			Default:     api.ToRawMessage(defaultRunsLimit),
+			Minimum:     ptr.To(float64(1)),
+		},
+	}
+}
+
+// runListSelector combines the label selector parameter with the Tekton labels matching the name filters, empty values are ignored.
+func runListSelector(labelSelector string, matchLabels map[string]string) (string, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+	for key, value := range matchLabels {
+		if value == "" {
+			continue
+		}
+		requirement, err := labels.NewRequirement(key, selection.Equals, []string{value})
+		if err != nil {
+			return "", err
+		}
+		selector = selector.Add(*requirement)
+	}
+	return selector.String(), nil
+}
+
+// parseRunFilter reads the status, since and limit parameters of the list tools.
+func parseRunFilter(status, since string, limit int64) (runFilter, error) {
+	filter := runFilter{limit: limit}
+	if status != "" {
+		idx := slices.IndexFunc(runStatuses, func(s string) bool { return strings.EqualFold(s, status) })
+		if idx < 0 {
+			return filter, fmt.Errorf("invalid status %q: must be one of %s", status, strings.Join(runStatuses, ", "))
+		}
+		filter.status = runStatuses[idx]
+	}
+	if since != "" {
+		window, err := time.ParseDuration(since)
+		if err != nil || window <= 0 {
+			return filter, fmt.Errorf("invalid since %q: must be a positive duration, e.g. 1h or 30m", since)
+		}
+		filter.since = time.Now().Add(-window)
+	}
+	return filter, nil
+}
+
+// apply returns the runs matching the filter, most recent first, limited to the filter limit.
+// The runs finished before the time window are filtered out, so that a long run failing within the window is listed.
+func (f runFilter) apply(runs []runSummary) []runSummary {
+	ret := slices.DeleteFunc(runs, func(r runSummary) bool {
+		return (f.status != "" && r.Status != f.status) || (!f.since.IsZero() && !r.completed.IsZero() && r.completed.Before(f.since))
+	})
+	slices.SortFunc(ret, func(a, b runSummary) int { return b.started.Compare(a.started) })
+	if f.limit > 0 && int64(len(ret)) > f.limit {
+		ret = ret[:f.limit]
+	}
+	return ret
+}
+
+// runStatus derives the status of a run from its Succeeded condition.
+func runStatus(succeeded *apis.Condition) string {
+	switch {
+	case succeeded == nil:
+		return runStatusPending
+	case succeeded.IsTrue():
+		return runStatusSucceeded
+	case succeeded.IsFalse() && (succeeded.Reason == tektonv1.PipelineRunReasonCancelled.String() || succeeded.Reason == tektonv1.TaskRunReasonCancelled.String()):
+		return runStatusCancelled
+	case succeeded.IsFalse():
+		return runStatusFailed
+	case succeeded.Reason == tektonv1.PipelineRunReasonPending.String() || succeeded.Reason == tektonv1.TaskRunReasonPending.String():
+		return runStatusPending
+	default:
+		return runStatusRunning
+	}
+}
+
+// setCondition sets the status, reason and error message of a run summary from its Succeeded condition.
+func (r *runSummary) setCondition(succeeded *apis.Condition) {
+	r.Status = runStatus(succeeded)
+	if succeeded == nil {
+		return
+	}
+	r.Reason = succeeded.Reason
+	if succeeded.IsFalse() {
+		r.Error = succeeded.Message
+	}
+}
+
+// setTimes sets the start time and duration of a run summary, the duration of running runs is the time elapsed since they started.
+func (r *runSummary) setTimes(created metav1.Time, start, completion *metav1.Time, now time.Time) {
+	r.started = created.Time
+	if start == nil {
+		return
+	}
+	r.started = start.Time
+	r.StartTime = start.UTC().Format(time.RFC3339)
+	end := now
+	if completion != nil {
+		end = completion.Time
+		r.completed = completion.Time
+	}
+	r.Duration = end.Sub(start.Time).Round(time.Second).String()
+}
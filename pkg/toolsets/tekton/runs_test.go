@@ -0,0 +1,188 @@
+package tekton
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type RunsSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestRuns(t *testing.T) {
+	suite.Run(t, &RunsSuite{now: time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)})
+}
+
+func succeeded(status corev1.ConditionStatus, reason, message string) duckv1.Conditions {
+	return duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status, Reason: reason, Message: message}}
+}
+
+func (s *RunsSuite) taskRun(name, pipelineTask string, start time.Duration, conditions duckv1.Conditions) tektonv1.TaskRun {
+	taskRun := tektonv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ci", Labels: map[string]string{
+			"tekton.dev/pipeline": "build", "tekton.dev/pipelineRun": "build-run", "tekton.dev/pipelineTask": pipelineTask,
+		}},
+		Spec: tektonv1.TaskRunSpec{TaskRef: &tektonv1.TaskRef{Name: "golang-build"}},
+	}
+	taskRun.Status.Conditions = conditions
+	taskRun.Status.StartTime = &metav1.Time{Time: s.now.Add(start)}
+	return taskRun
+}
+
+func (s *RunsSuite) TestRunStatus() {
+	s.Equal(runStatusPending, runStatus(nil))
+	s.Equal(runStatusSucceeded, runStatus(&succeeded(corev1.ConditionTrue, "Succeeded", "")[0]))
+	s.Equal(runStatusFailed, runStatus(&succeeded(corev1.ConditionFalse, "Failed", "")[0]))
+	s.Equal(runStatusFailed, runStatus(&succeeded(corev1.ConditionFalse, "PipelineRunTimeout", "")[0]))
+	s.Equal(runStatusCancelled, runStatus(&succeeded(corev1.ConditionFalse, "Cancelled", "")[0]))
+	s.Equal(runStatusCancelled, runStatus(&succeeded(corev1.ConditionFalse, "TaskRunCancelled", "")[0]))
+	s.Equal(runStatusPending, runStatus(&succeeded(corev1.ConditionUnknown, "PipelineRunPending", "")[0]))
+	s.Equal(runStatusRunning, runStatus(&succeeded(corev1.ConditionUnknown, "Running", "")[0]))
+}
+
+func (s *RunsSuite) TestSummarizePipelineRun() {
+	s.Run("completed run", func() {
+		pipelineRun := &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-run", Namespace: "ci"},
+			Spec:       tektonv1.PipelineRunSpec{PipelineRef: &tektonv1.PipelineRef{Name: "build"}},
+		}
+		pipelineRun.Status.Conditions = succeeded(corev1.ConditionFalse, "Failed", "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0")
+		pipelineRun.Status.StartTime = &metav1.Time{Time: s.now.Add(-10 * time.Minute)}
+		pipelineRun.Status.CompletionTime = &metav1.Time{Time: s.now.Add(-7*time.Minute - 30*time.Second)}
+		s.Equal(runSummary{
+			Name: "build-run", Namespace: "ci", Pipeline: "build",
+			Status: runStatusFailed, Reason: "Failed", StartTime: "2026-05-04T11:50:00Z", Duration: "2m30s",
+			Error:     "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0",
+			started:   s.now.Add(-10 * time.Minute),
+			completed: s.now.Add(-7*time.Minute - 30*time.Second),
+		}, summarizePipelineRun(pipelineRun, s.now))
+	})
+	s.Run("running run", func() {
+		pipelineRun := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build-run", Namespace: "ci", Labels: map[string]string{"tekton.dev/pipeline": "build"}}}
+		pipelineRun.Status.Conditions = succeeded(corev1.ConditionUnknown, "Running", "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0")
+		pipelineRun.Status.StartTime = &metav1.Time{Time: s.now.Add(-90 * time.Second)}
+		summary := summarizePipelineRun(pipelineRun, s.now)
+		s.Equal(runStatusRunning, summary.Status)
+		s.Equal("build", summary.Pipeline)
+		s.Equal("1m30s", summary.Duration)
+		s.Empty(summary.Error)
+	})
+	s.Run("pending run", func() {
+		created := metav1.Time{Time: s.now.Add(-time.Minute)}
+		summary := summarizePipelineRun(&tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build-run", CreationTimestamp: created}}, s.now)
+		s.Equal(runStatusPending, summary.Status)
+		s.Empty(summary.StartTime)
+		s.Empty(summary.Duration)
+		s.Equal(created.Time, summary.started)
+	})
+}
+
+func (s *RunsSuite) TestSummarizeTaskRun() {
+	taskRun := s.taskRun("build-run-compile", "compile", -5*time.Minute, succeeded(corev1.ConditionFalse, "Failed", `"step-build" exited with code 1`))
+	taskRun.Status.CompletionTime = &metav1.Time{Time: s.now.Add(-4 * time.Minute)}
+	s.Equal(runSummary{
+		Name: "build-run-compile", Namespace: "ci", Pipeline: "build", PipelineRun: "build-run", PipelineTask: "compile", Task: "golang-build",
+		Status: runStatusFailed, Reason: "Failed", StartTime: "2026-05-04T11:55:00Z", Duration: "1m0s",
+		Error:     `"step-build" exited with code 1`,
+		started:   s.now.Add(-5 * time.Minute),
+		completed: s.now.Add(-4 * time.Minute),
+	}, summarizeTaskRun(&taskRun, s.now))
+}
+
+func (s *RunsSuite) TestAddFailedTasks() {
+	summary := runSummary{Name: "build-run", Status: runStatusFailed, Error: "Tasks Completed: 3 (Failed: 2, Cancelled 0), Skipped: 0"}
+	addFailedTasks(&summary, []tektonv1.TaskRun{
+		s.taskRun("build-run-lint", "lint", -2*time.Minute, succeeded(corev1.ConditionFalse, "Failed", `"step-lint" exited with code 2`)),
+		s.taskRun("build-run-fetch", "fetch", -5*time.Minute, succeeded(corev1.ConditionTrue, "Succeeded", "All Steps have completed executing")),
+		s.taskRun("build-run-compile", "compile", -4*time.Minute, succeeded(corev1.ConditionFalse, "Failed", `"step-build" exited with code 1`)),
+	})
+	s.Equal([]string{"compile", "lint"}, summary.FailedTasks)
+	s.Equal(`compile: "step-build" exited with code 1`, summary.Error)
+}
+
+func (s *RunsSuite) TestGroupTaskRunsByPipelineRun() {
+	other := s.taskRun("build-run-compile", "compile", -4*time.Minute, nil)
+	other.Namespace = "release"
+	owned := s.taskRun("build-run-retry-compile", "compile", -3*time.Minute, nil)
+	owned.OwnerReferences = []metav1.OwnerReference{{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "build-run-retry"}}
+	taskRuns := groupTaskRunsByPipelineRun([]tektonv1.TaskRun{
+		s.taskRun("build-run-compile", "compile", -4*time.Minute, nil),
+		s.taskRun("build-run-lint", "lint", -2*time.Minute, nil),
+		other,
+		owned,
+	})
+	s.Len(taskRuns, 3)
+	s.Len(taskRuns[types.NamespacedName{Namespace: "ci", Name: "build-run"}], 2)
+	s.Equal([]tektonv1.TaskRun{other}, taskRuns[types.NamespacedName{Namespace: "release", Name: "build-run"}],
+		"expected the PipelineRuns with the same name in another namespace to be kept apart")
+	s.Equal([]tektonv1.TaskRun{owned}, taskRuns[types.NamespacedName{Namespace: "ci", Name: "build-run-retry"}],
+		"expected the owner reference to take precedence over the pipelineRun label")
+}
+
+func (s *RunsSuite) TestRunFilter() {
+	runs := func() []runSummary {
+		return []runSummary{
+			{Name: "old-failure", Status: runStatusFailed, started: time.Now().Add(-3 * time.Hour), completed: time.Now().Add(-170 * time.Minute)},
+			{Name: "recent-success", Status: runStatusSucceeded, started: time.Now().Add(-10 * time.Minute), completed: time.Now().Add(-5 * time.Minute)},
+			{Name: "recent-failure", Status: runStatusFailed, started: time.Now().Add(-20 * time.Minute), completed: time.Now().Add(-15 * time.Minute)},
+			{Name: "long-failure", Status: runStatusFailed, started: time.Now().Add(-2 * time.Hour), completed: time.Now().Add(-10 * time.Minute)},
+			{Name: "long-running", Status: runStatusRunning, started: time.Now().Add(-4 * time.Hour)},
+		}
+	}
+	names := func(runs []runSummary) []string {
+		ret := make([]string, 0, len(runs))
+		for _, r := range runs {
+			ret = append(ret, r.Name)
+		}
+		return ret
+	}
+	s.Run("sorts the runs, most recent first", func() {
+		filter, err := parseRunFilter("", "", defaultRunsLimit)
+		s.Require().NoError(err)
+		s.Equal([]string{"recent-success", "recent-failure", "long-failure", "old-failure", "long-running"}, names(filter.apply(runs())))
+	})
+	s.Run("filters by status and time window", func() {
+		filter, err := parseRunFilter("failed", "1h", defaultRunsLimit)
+		s.Require().NoError(err)
+		s.Equal([]string{"recent-failure", "long-failure"}, names(filter.apply(runs())))
+	})
+	s.Run("keeps the runs finishing or still running within the time window", func() {
+		filter, err := parseRunFilter("", "1h", defaultRunsLimit)
+		s.Require().NoError(err)
+		s.Equal([]string{"recent-success", "recent-failure", "long-failure", "long-running"}, names(filter.apply(runs())))
+	})
+	s.Run("limits the runs", func() {
+		filter, err := parseRunFilter("", "", 1)
+		s.Require().NoError(err)
+		s.Equal([]string{"recent-success"}, names(filter.apply(runs())))
+	})
+	s.Run("rejects invalid status", func() {
+		_, err := parseRunFilter("Broken", "", defaultRunsLimit)
+		s.ErrorContains(err, `invalid status "Broken": must be one of Succeeded, Failed, Cancelled, Running, Pending`)
+	})
+	s.Run("rejects invalid time window", func() {
+		_, err := parseRunFilter("", "yesterday", defaultRunsLimit)
+		s.ErrorContains(err, `invalid since "yesterday": must be a positive duration, e.g. 1h or 30m`)
+	})
+}
+
+func (s *RunsSuite) TestRunListSelector() {
+	s.Run("combines the label selector with the name filters", func() {
+		selector, err := runListSelector("app=web", map[string]string{"tekton.dev/pipeline": "build", "tekton.dev/task": ""})
+		s.Require().NoError(err)
+		s.Equal("app=web,tekton.dev/pipeline=build", selector)
+	})
+	s.Run("rejects invalid label selectors", func() {
+		_, err := runListSelector("app in web", nil)
+		s.ErrorContains(err, `invalid label selector "app in web"`)
+	})
+}
@@ -5,16 +5,20 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
 	"github.com/google/jsonschema-go/jsonschema"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
 )
 
 const maxLogBytesPerContainer = 1 << 20 // 1 MiB
@@ -49,6 +53,36 @@ func taskRunTools() []api.ServerTool {
 			},
 			Handler: restartTaskRun,
 		},
+		{
+			Tool: api.Tool{
+				Name: "tekton_taskrun_list",
+				Description: "List Tekton TaskRuns, most recent first, with a compact summary of each run: status and reason, start time, duration " +
+					"and the first error message. Filter by pipeline, task, status, labels and time window, e.g. status=Failed and since=1h to find what failed in the last hour",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: func() map[string]*jsonschema.Schema {
+						properties := runListProperties("TaskRun")
+						properties["pipeline"] = &jsonschema.Schema{
+							Type:        "string",
+							Description: "Only list the TaskRuns of the PipelineRuns of this Pipeline (tekton.dev/pipeline label) (Optional)",
+						}
+						properties["task"] = &jsonschema.Schema{
+							Type:        "string",
+							Description: "Only list the TaskRuns of this Task (tekton.dev/task label) (Optional)",
+						}
+						return properties
+					}(),
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Tekton: List TaskRuns",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(true),
+				},
+			},
+			Handler: listTaskRuns,
+		},
 		{
 			Tool: api.Tool{
 				Name:        "tekton_taskrun_logs",
@@ -142,6 +176,69 @@ func restartTaskRun(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult(fmt.Sprintf("TaskRun '%s' restarted as '%s' in namespace '%s'", name, createdName, namespace), nil), nil
 }
 
+func listTaskRuns(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", params.NamespaceOrDefault(""))
+	pipelineName := p.OptionalString("pipeline", "")
+	taskName := p.OptionalString("task", "")
+	labelSelector := p.OptionalString("label_selector", "")
+	status := p.OptionalString("status", "")
+	since := p.OptionalString("since", "")
+	limit := p.OptionalInt64("limit", defaultRunsLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list task runs: %w", err)), nil
+	}
+	filter, err := parseRunFilter(status, since, limit)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list task runs: %w", err)), nil
+	}
+	selector, err := runListSelector(labelSelector, map[string]string{pipeline.PipelineLabelKey: pipelineName, pipeline.TaskLabelKey: taskName})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list task runs: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(taskRunGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list TaskRuns in namespace %s: %w", namespace, err)), nil
+	}
+	now := time.Now()
+	runs := make([]runSummary, 0, len(list.Items))
+	for _, item := range list.Items {
+		var taskRun tektonv1.TaskRun
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &taskRun); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to convert TaskRun %s from unstructured: %w", item.GetName(), err)), nil
+		}
+		runs = append(runs, summarizeTaskRun(&taskRun, now))
+	}
+	runs = filter.apply(runs)
+	if len(runs) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No TaskRuns found in namespace '%s' matching the filters", namespace), nil), nil
+	}
+	ret, err := output.MarshalYaml(runs)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list task runs: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// summarizeTaskRun returns the compact summary of a TaskRun.
+func summarizeTaskRun(taskRun *tektonv1.TaskRun, now time.Time) runSummary {
+	summary := runSummary{
+		Name:         taskRun.Name,
+		Namespace:    taskRun.Namespace,
+		Pipeline:     taskRun.Labels[pipeline.PipelineLabelKey],
+		PipelineRun:  taskRun.Labels[pipeline.PipelineRunLabelKey],
+		PipelineTask: taskRun.Labels[pipeline.PipelineTaskLabelKey],
+		Task:         taskRun.Labels[pipeline.TaskLabelKey],
+	}
+	if summary.Task == "" && taskRun.Spec.TaskRef != nil {
+		summary.Task = taskRun.Spec.TaskRef.Name
+	}
+	summary.setCondition(taskRun.Status.GetCondition(apis.ConditionSucceeded))
+	summary.setTimes(taskRun.CreationTimestamp, taskRun.Status.StartTime, taskRun.Status.CompletionTime, now)
+	return summary
+}
+
 func getTaskRunLogs(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	name := p.RequiredString("name")
@@ -0,0 +1,351 @@
+package velero
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	veleroGroup = "velero.io"
+	// defaultVeleroNamespace is the namespace Velero is installed in by default.
+	defaultVeleroNamespace = "velero"
+	// defaultBackupsLimit is the default number of Backups returned by velero_backups_list.
+	defaultBackupsLimit = 20
+)
+
+var backupGVR = schema.GroupVersionResource{Group: veleroGroup, Version: "v1", Resource: "backups"}
+
+// hasVelero returns a filter that hides the tools when the Velero CRDs are not installed.
+func hasVelero(p api.FilteringProvider, kind string) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{{Group: veleroGroup, Version: "v1", Kind: kind}})
+	}
+}
+
+// veleroNamespaceSchema is the schema of the namespace parameter, Velero resources live in the Velero namespace.
+var veleroNamespaceSchema = &jsonschema.Schema{
+	Type:        "string",
+	Description: "Namespace where Velero is installed (Optional, defaults to " + defaultVeleroNamespace + ")",
+	Default:     api.ToRawMessage(defaultVeleroNamespace),
+}
+
+func initBackups(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "velero_backup_create",
+			Description: "Create a Velero Backup of the resources, and optionally the volumes, of the provided namespaces or matching a label selector, " +
+				"for instance before a risky change. The Backup runs asynchronously, use velero_backups_list or velero_describe to follow it",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": veleroNamespaceSchema,
+					"name": {
+						Type:        "string",
+						Description: "Name of the Backup (Optional, generated if not provided)",
+					},
+					"included_namespaces": {
+						Type:        "array",
+						Description: "Namespaces to back up (Optional, all namespaces if not provided)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"excluded_namespaces": {
+						Type:        "array",
+						Description: "Namespaces to exclude from the Backup (Optional)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"label_selector": {
+						Type:        "string",
+						Description: "Only back up the resources matching this label selector, e.g. app=web,tier in (frontend,backend) (Optional)",
+					},
+					"ttl": {
+						Type:        "string",
+						Description: "How long the Backup is kept before it is garbage collected, e.g. 72h (Optional, defaults to the Velero server default, usually 720h)",
+					},
+					"storage_location": {
+						Type:        "string",
+						Description: "Name of the BackupStorageLocation to store the Backup in (Optional, defaults to the default location)",
+					},
+					"snapshot_volumes": {
+						Type:        "boolean",
+						Description: "Take snapshots of the persistent volumes (Optional, defaults to the Velero server default)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Velero: Create Backup",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: backupCreate, TargetCompatibilityFilters: []func() bool{hasVelero(p, "Backup")}},
+		{Tool: api.Tool{
+			Name: "velero_backups_list",
+			Description: "List Velero Backups, most recent first, with their phase, scope, schedule, start and completion time, expiration, progress, " +
+				"and the number of errors and warnings or the failure reason",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": veleroNamespaceSchema,
+					"phase": {
+						Type:        "string",
+						Description: "Only list the Backups in this phase (Optional)",
+						Enum:        []any{"New", "InProgress", "WaitingForPluginOperations", "Finalizing", "Completed", "PartiallyFailed", "Failed", "FailedValidation", "Deleting"},
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of Backups to return (Optional, defaults to %d)", defaultBackupsLimit),
+						Default:     api.ToRawMessage(defaultBackupsLimit),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Velero: List Backups",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: backupsList, TargetCompatibilityFilters: []func() bool{hasVelero(p, "Backup")}},
+	}
+}
+
+// veleroStatus is the status shared by Velero Backups and Restores.
+type veleroStatus struct {
+	Phase               string       `json:"phase,omitempty"`
+	StartTimestamp      *metav1.Time `json:"startTimestamp,omitempty"`
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+	Errors              int          `json:"errors,omitempty"`
+	Warnings            int          `json:"warnings,omitempty"`
+	FailureReason       string       `json:"failureReason,omitempty"`
+	ValidationErrors    []string     `json:"validationErrors,omitempty"`
+}
+
+// backup is the subset of a Velero Backup inspected by this toolset.
+type backup struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		IncludedNamespaces []string              `json:"includedNamespaces,omitempty"`
+		ExcludedNamespaces []string              `json:"excludedNamespaces,omitempty"`
+		LabelSelector      *metav1.LabelSelector `json:"labelSelector,omitempty"`
+		StorageLocation    string                `json:"storageLocation,omitempty"`
+		TTL                string                `json:"ttl,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		veleroStatus `json:",inline"`
+		Expiration   *metav1.Time `json:"expiration,omitempty"`
+		Progress     *struct {
+			TotalItems    int `json:"totalItems"`
+			ItemsBackedUp int `json:"itemsBackedUp"`
+		} `json:"progress,omitempty"`
+		VolumeSnapshotsAttempted int `json:"volumeSnapshotsAttempted,omitempty"`
+		VolumeSnapshotsCompleted int `json:"volumeSnapshotsCompleted,omitempty"`
+	} `json:"status"`
+}
+
+type backupReport struct {
+	Name               string   `json:"name"`
+	Phase              string   `json:"phase"`
+	Schedule           string   `json:"schedule,omitempty"`
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	LabelSelector      string   `json:"labelSelector,omitempty"`
+	StorageLocation    string   `json:"storageLocation,omitempty"`
+	Started            string   `json:"started,omitempty"`
+	Completed          string   `json:"completed,omitempty"`
+	Expiration         string   `json:"expiration,omitempty"`
+	Progress           string   `json:"progress,omitempty"`
+	VolumeSnapshots    string   `json:"volumeSnapshots,omitempty"`
+	Errors             int      `json:"errors,omitempty"`
+	Warnings           int      `json:"warnings,omitempty"`
+	FailureReason      string   `json:"failureReason,omitempty"`
+	ValidationErrors   []string `json:"validationErrors,omitempty"`
+}
+
+func backupCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", defaultVeleroNamespace)
+	name := p.OptionalString("name", "")
+	labelSelector := p.OptionalString("label_selector", "")
+	ttl := p.OptionalString("ttl", "")
+	storageLocation := p.OptionalString("storage_location", "")
+	included := p.OptionalStringArray("included_namespaces")
+	excluded := p.OptionalStringArray("excluded_namespaces")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create backup: %w", err)), nil
+	}
+	var snapshotVolumes *bool
+	if _, ok := params.GetArguments()["snapshot_volumes"]; ok {
+		snapshotVolumes = ptr.To(p.OptionalBool("snapshot_volumes", false))
+	}
+
+	spec := map[string]any{}
+	if len(included) > 0 {
+		spec["includedNamespaces"] = toAnySlice(included)
+	}
+	if len(excluded) > 0 {
+		spec["excludedNamespaces"] = toAnySlice(excluded)
+	}
+	if labelSelector != "" {
+		selector, err := labelSelectorObject(labelSelector)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create backup: %w", err)), nil
+		}
+		spec["labelSelector"] = selector
+	}
+	if ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create backup: invalid ttl %q: %w", ttl, err)), nil
+		}
+		spec["ttl"] = duration.String()
+	}
+	if storageLocation != "" {
+		spec["storageLocation"] = storageLocation
+	}
+	if snapshotVolumes != nil {
+		spec["snapshotVolumes"] = *snapshotVolumes
+	}
+	obj := veleroObject("Backup", namespace, name, "backup-", spec)
+
+	created, err := params.DynamicClient().Resource(backupGVR).Namespace(namespace).Create(params.Context, obj, metav1.CreateOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create backup: %w", err)), nil
+	}
+	scope := "all namespaces"
+	if len(included) > 0 {
+		scope = fmt.Sprintf("namespaces %v", included)
+	}
+	if labelSelector != "" {
+		scope += fmt.Sprintf(" (resources matching %s)", labelSelector)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Backup '%s' of %s created in namespace '%s', use velero_describe to follow it",
+		created.GetName(), scope, namespace), nil), nil
+}
+
+func backupsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", defaultVeleroNamespace)
+	phase := p.OptionalString("phase", "")
+	limit := p.OptionalInt64("limit", defaultBackupsLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list backups: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(backupGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list backups in namespace %s: %w", namespace, err)), nil
+	}
+	backups := make([]backup, 0, len(list.Items))
+	for _, item := range list.Items {
+		b := backup{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &b); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode backup %s: %w", item.GetName(), err)), nil
+		}
+		if phase == "" || b.Status.Phase == phase {
+			backups = append(backups, b)
+		}
+	}
+	if len(backups) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No Backups found in namespace %s", namespace), nil), nil
+	}
+	slices.SortFunc(backups, func(a, b backup) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+	ret := ""
+	if int64(len(backups)) > limit {
+		ret = fmt.Sprintf("# %d Backups found, only the %d most recent are returned\n", len(backups), limit)
+		backups = backups[:limit]
+	}
+	reports := make([]backupReport, 0, len(backups))
+	for i := range backups {
+		reports = append(reports, summarizeBackup(&backups[i]))
+	}
+	yaml, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list backups: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret+yaml, nil), nil
+}
+
+func summarizeBackup(b *backup) backupReport {
+	report := backupReport{
+		Name:               b.Name,
+		Phase:              b.Status.Phase,
+		Schedule:           b.Labels["velero.io/schedule-name"],
+		IncludedNamespaces: b.Spec.IncludedNamespaces,
+		ExcludedNamespaces: b.Spec.ExcludedNamespaces,
+		StorageLocation:    b.Spec.StorageLocation,
+		Started:            formatTime(b.Status.StartTimestamp),
+		Completed:          formatTime(b.Status.CompletionTimestamp),
+		Expiration:         formatTime(b.Status.Expiration),
+		Errors:             b.Status.Errors,
+		Warnings:           b.Status.Warnings,
+		FailureReason:      b.Status.FailureReason,
+		ValidationErrors:   b.Status.ValidationErrors,
+	}
+	if report.Phase == "" {
+		report.Phase = "New"
+	}
+	if b.Spec.LabelSelector != nil {
+		report.LabelSelector = metav1.FormatLabelSelector(b.Spec.LabelSelector)
+	}
+	if b.Status.Progress != nil {
+		report.Progress = fmt.Sprintf("%d/%d items backed up", b.Status.Progress.ItemsBackedUp, b.Status.Progress.TotalItems)
+	}
+	if b.Status.VolumeSnapshotsAttempted > 0 {
+		report.VolumeSnapshots = fmt.Sprintf("%d/%d completed", b.Status.VolumeSnapshotsCompleted, b.Status.VolumeSnapshotsAttempted)
+	}
+	return report
+}
+
+// veleroObject returns a Velero object of the provided kind, its name is generated from generateName when name is empty.
+func veleroObject(kind, namespace, name, generateName string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetAPIVersion(veleroGroup + "/v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	if name != "" {
+		obj.SetName(name)
+	} else {
+		obj.SetGenerateName(generateName)
+	}
+	return obj
+}
+
+// labelSelectorObject parses a label selector into its unstructured metav1.LabelSelector representation.
+func labelSelectorObject(selector string) (map[string]any, error) {
+	parsed, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(parsed)
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func toAnySlice(values []string) []any {
+	ret := make([]any, 0, len(values))
+	for _, v := range values {
+		ret = append(ret, v)
+	}
+	return ret
+}
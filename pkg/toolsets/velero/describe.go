@@ -0,0 +1,225 @@
+package velero
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// downloadTimeout bounds how long the results of a Backup or Restore are waited for and downloaded.
+	downloadTimeout = 30 * time.Second
+	// downloadPollInterval is the interval used to wait for Velero to process a DownloadRequest.
+	downloadPollInterval = time.Second
+	// maxResultEntries bounds the number of errors and warnings returned.
+	maxResultEntries = 50
+)
+
+var downloadRequestGVR = schema.GroupVersionResource{Group: veleroGroup, Version: "v1", Resource: "downloadrequests"}
+
+func initDescribe(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "velero_describe",
+			Description: "Describe a Velero Backup or Restore: its phase, scope, progress and failure reason, and for partially failed ones the detailed errors and warnings " +
+				"per namespace, downloaded from the backup storage location through a DownloadRequest",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": veleroNamespaceSchema,
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the Velero resource to describe",
+						Enum:        []any{"Backup", "Restore"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Backup or Restore",
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Velero: Describe Backup or Restore",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: describe, TargetCompatibilityFilters: []func() bool{hasVelero(p, "Backup")}},
+	}
+}
+
+type describeReport struct {
+	Backup   *backupReport  `json:"backup,omitempty"`
+	Restore  *restoreReport `json:"restore,omitempty"`
+	Errors   []string       `json:"errors,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+	// ResultsUnavailable explains why the detailed errors and warnings could not be retrieved.
+	ResultsUnavailable string `json:"resultsUnavailable,omitempty"`
+}
+
+// veleroResult is the format of the errors and warnings that Velero stores for each Backup and Restore.
+type veleroResult struct {
+	Velero     []string            `json:"velero,omitempty"`
+	Cluster    []string            `json:"cluster,omitempty"`
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+}
+
+func describe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", defaultVeleroNamespace)
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe velero resource: %w", err)), nil
+	}
+
+	report := describeReport{}
+	var resultsKind string
+	var errorCount, warningCount int
+	switch kind {
+	case "Backup":
+		item, err := params.DynamicClient().Resource(backupGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get backup %s/%s: %w", namespace, name, err)), nil
+		}
+		b := &backup{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, b); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode backup %s/%s: %w", namespace, name, err)), nil
+		}
+		report.Backup = ptr.To(summarizeBackup(b))
+		resultsKind, errorCount, warningCount = "BackupResults", b.Status.Errors, b.Status.Warnings
+	case "Restore":
+		item, err := params.DynamicClient().Resource(restoreGVR).Namespace(namespace).Get(params.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get restore %s/%s: %w", namespace, name, err)), nil
+		}
+		r := &restore{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, r); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode restore %s/%s: %w", namespace, name, err)), nil
+		}
+		report.Restore = ptr.To(summarizeRestore(r))
+		resultsKind, errorCount, warningCount = "RestoreResults", r.Status.Errors, r.Status.Warnings
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe velero resource: unsupported kind %q, must be Backup or Restore", kind)), nil
+	}
+
+	if errorCount > 0 || warningCount > 0 {
+		results, err := downloadResults(params, namespace, resultsKind, name)
+		if err != nil {
+			report.ResultsUnavailable = fmt.Sprintf("failed to download the %s: %v, check the Velero server logs", resultsKind, err)
+		} else {
+			report.Errors = flattenResult(results["errors"])
+			report.Warnings = flattenResult(results["warnings"])
+		}
+	}
+	ret, err := output.MarshalYaml(report)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe velero resource: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// downloadResults creates a DownloadRequest for the errors and warnings of a Backup or Restore, waits for Velero to sign its URL, and downloads them.
+func downloadResults(params api.ToolHandlerParams, namespace, targetKind, name string) (map[string]veleroResult, error) {
+	ctx, cancel := context.WithTimeout(params.Context, downloadTimeout)
+	defer cancel()
+
+	requests := params.DynamicClient().Resource(downloadRequestGVR).Namespace(namespace)
+	request := veleroObject("DownloadRequest", namespace, "", name+"-", map[string]any{
+		"target": map[string]any{"kind": targetKind, "name": name},
+	})
+	request, err := requests.Create(ctx, request, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create downloadrequest: %w", err)
+	}
+	defer func() {
+		// Velero garbage collects expired DownloadRequests, deleting it right away is a best effort
+		_ = requests.Delete(params.Context, request.GetName(), metav1.DeleteOptions{})
+	}()
+
+	var url string
+	for url == "" {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("downloadrequest %s was not processed: %w", request.GetName(), ctx.Err())
+		case <-time.After(downloadPollInterval):
+		}
+		latest, err := requests.Get(ctx, request.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get downloadrequest %s: %w", request.GetName(), err)
+		}
+		if phase, _, _ := unstructured.NestedString(latest.Object, "status", "phase"); phase == "Processed" {
+			url, _, _ = unstructured.NestedString(latest.Object, "status", "downloadURL")
+		}
+	}
+	if params.IsRequireTLS() && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("the download URL of the backup storage location does not use HTTPS and TLS is required")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s downloading the results", resp.Status)
+	}
+	return decodeResults(resp.Body)
+}
+
+// decodeResults decodes the gzipped JSON errors and warnings of a Backup or Restore.
+func decodeResults(r io.Reader) (map[string]veleroResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+	results := map[string]veleroResult{}
+	if err = json.NewDecoder(gz).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// flattenResult lists the Velero, cluster and per namespace entries of a result, truncated to maxResultEntries.
+func flattenResult(result veleroResult) []string {
+	var ret []string
+	for _, e := range result.Velero {
+		ret = append(ret, "velero: "+e)
+	}
+	for _, e := range result.Cluster {
+		ret = append(ret, "cluster: "+e)
+	}
+	for _, ns := range slices.Sorted(maps.Keys(result.Namespaces)) {
+		for _, e := range result.Namespaces[ns] {
+			ret = append(ret, fmt.Sprintf("namespace %s: %s", ns, e))
+		}
+	}
+	if len(ret) > maxResultEntries {
+		ret = append(ret[:maxResultEntries], fmt.Sprintf("... and %d more", len(ret)-maxResultEntries))
+	}
+	return ret
+}
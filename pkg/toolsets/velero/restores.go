@@ -0,0 +1,210 @@
+package velero
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+var restoreGVR = schema.GroupVersionResource{Group: veleroGroup, Version: "v1", Resource: "restores"}
+
+func initRestores(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "velero_restore_create",
+			Description: "Create a Velero Restore from a completed or partially failed Backup, optionally restoring only some namespaces or labeled resources, " +
+				"and mapping the backed up namespaces to new namespaces (e.g. to restore a copy next to the original). " +
+				"Existing resources are not overwritten. The Restore runs asynchronously, use velero_describe to follow it",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": veleroNamespaceSchema,
+					"backup_name": {
+						Type:        "string",
+						Description: "Name of the Backup to restore",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Restore (Optional, generated from the Backup name if not provided)",
+					},
+					"included_namespaces": {
+						Type:        "array",
+						Description: "Backed up namespaces to restore (Optional, all the namespaces of the Backup if not provided)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"namespace_mapping": {
+						Type:                 "object",
+						Description:          "Map of backed up namespace to the namespace to restore it into, e.g. {\"shop\": \"shop-restored\"} (Optional)",
+						Properties:           make(map[string]*jsonschema.Schema),
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"label_selector": {
+						Type:        "string",
+						Description: "Only restore the resources matching this label selector, e.g. app=web (Optional)",
+					},
+					"restore_pvs": {
+						Type:        "boolean",
+						Description: "Restore the persistent volumes from their snapshots (Optional, defaults to the Velero server default)",
+					},
+				},
+				Required: []string{"backup_name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Velero: Create Restore",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: restoreCreate, TargetCompatibilityFilters: []func() bool{hasVelero(p, "Restore")}},
+	}
+}
+
+// restore is the subset of a Velero Restore inspected by this toolset.
+type restore struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		BackupName         string                `json:"backupName"`
+		IncludedNamespaces []string              `json:"includedNamespaces,omitempty"`
+		NamespaceMapping   map[string]string     `json:"namespaceMapping,omitempty"`
+		LabelSelector      *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		veleroStatus `json:",inline"`
+		Progress     *struct {
+			TotalItems    int `json:"totalItems"`
+			ItemsRestored int `json:"itemsRestored"`
+		} `json:"progress,omitempty"`
+	} `json:"status"`
+}
+
+type restoreReport struct {
+	Name               string   `json:"name"`
+	Backup             string   `json:"backup"`
+	Phase              string   `json:"phase"`
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	NamespaceMapping   []string `json:"namespaceMapping,omitempty"`
+	LabelSelector      string   `json:"labelSelector,omitempty"`
+	Started            string   `json:"started,omitempty"`
+	Completed          string   `json:"completed,omitempty"`
+	Progress           string   `json:"progress,omitempty"`
+	Errors             int      `json:"errors,omitempty"`
+	Warnings           int      `json:"warnings,omitempty"`
+	FailureReason      string   `json:"failureReason,omitempty"`
+	ValidationErrors   []string `json:"validationErrors,omitempty"`
+}
+
+func restoreCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", defaultVeleroNamespace)
+	backupName := p.RequiredString("backup_name")
+	name := p.OptionalString("name", "")
+	labelSelector := p.OptionalString("label_selector", "")
+	included := p.OptionalStringArray("included_namespaces")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create restore: %w", err)), nil
+	}
+	mapping, err := optionalStringMap(params, "namespace_mapping")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create restore: %w", err)), nil
+	}
+	var restorePVs *bool
+	if _, ok := params.GetArguments()["restore_pvs"]; ok {
+		restorePVs = ptr.To(p.OptionalBool("restore_pvs", false))
+	}
+
+	item, err := params.DynamicClient().Resource(backupGVR).Namespace(namespace).Get(params.Context, backupName, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get backup %s/%s: %w", namespace, backupName, err)), nil
+	}
+	if phase, _, _ := unstructured.NestedString(item.Object, "status", "phase"); phase != "Completed" && phase != "PartiallyFailed" {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create restore: backup %s is in phase %q, only Completed or PartiallyFailed Backups can be restored", backupName, phase)), nil
+	}
+
+	spec := map[string]any{"backupName": backupName}
+	if len(included) > 0 {
+		spec["includedNamespaces"] = toAnySlice(included)
+	}
+	if len(mapping) > 0 {
+		namespaceMapping := make(map[string]any, len(mapping))
+		for source, target := range mapping {
+			namespaceMapping[source] = target
+		}
+		spec["namespaceMapping"] = namespaceMapping
+	}
+	if labelSelector != "" {
+		selector, err := labelSelectorObject(labelSelector)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create restore: %w", err)), nil
+		}
+		spec["labelSelector"] = selector
+	}
+	if restorePVs != nil {
+		spec["restorePVs"] = *restorePVs
+	}
+	obj := veleroObject("Restore", namespace, name, backupName+"-", spec)
+
+	created, err := params.DynamicClient().Resource(restoreGVR).Namespace(namespace).Create(params.Context, obj, metav1.CreateOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create restore: %w", err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Restore '%s' of Backup '%s' created in namespace '%s', use velero_describe to follow it",
+		created.GetName(), backupName, namespace), nil), nil
+}
+
+func summarizeRestore(r *restore) restoreReport {
+	report := restoreReport{
+		Name:               r.Name,
+		Backup:             r.Spec.BackupName,
+		Phase:              r.Status.Phase,
+		IncludedNamespaces: r.Spec.IncludedNamespaces,
+		Started:            formatTime(r.Status.StartTimestamp),
+		Completed:          formatTime(r.Status.CompletionTimestamp),
+		Errors:             r.Status.Errors,
+		Warnings:           r.Status.Warnings,
+		FailureReason:      r.Status.FailureReason,
+		ValidationErrors:   r.Status.ValidationErrors,
+	}
+	if report.Phase == "" {
+		report.Phase = "New"
+	}
+	for _, source := range slices.Sorted(maps.Keys(r.Spec.NamespaceMapping)) {
+		report.NamespaceMapping = append(report.NamespaceMapping, source+" -> "+r.Spec.NamespaceMapping[source])
+	}
+	if r.Spec.LabelSelector != nil {
+		report.LabelSelector = metav1.FormatLabelSelector(r.Spec.LabelSelector)
+	}
+	if r.Status.Progress != nil {
+		report.Progress = fmt.Sprintf("%d/%d items restored", r.Status.Progress.ItemsRestored, r.Status.Progress.TotalItems)
+	}
+	return report
+}
+
+func optionalStringMap(params api.ToolHandlerParams, key string) (map[string]string, error) {
+	val, ok := params.GetArguments()[key]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	items, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s parameter must be an object of strings", key)
+	}
+	ret := make(map[string]string, len(items))
+	for k, v := range items {
+		s, ok := v.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			return nil, fmt.Errorf("%s parameter must be an object of strings, invalid value for %q", key, k)
+		}
+		ret[k] = s
+	}
+	return ret, nil
+}
@@ -0,0 +1,136 @@
+package velero
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type VeleroSuite struct {
+	suite.Suite
+}
+
+func TestVelero(t *testing.T) {
+	suite.Run(t, new(VeleroSuite))
+}
+
+func (s *VeleroSuite) decode(obj map[string]any, into any) {
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, into))
+}
+
+func (s *VeleroSuite) TestVeleroObject() {
+	s.Run("uses the provided name", func() {
+		obj := veleroObject("Backup", "velero", "before-upgrade", "backup-", map[string]any{"includedNamespaces": []any{"shop"}})
+		s.Equal("velero.io/v1", obj.GetAPIVersion())
+		s.Equal("Backup", obj.GetKind())
+		s.Equal("velero", obj.GetNamespace())
+		s.Equal("before-upgrade", obj.GetName())
+		s.Empty(obj.GetGenerateName())
+	})
+	s.Run("generates the name", func() {
+		obj := veleroObject("Restore", "velero", "", "before-upgrade-", map[string]any{"backupName": "before-upgrade"})
+		s.Empty(obj.GetName())
+		s.Equal("before-upgrade-", obj.GetGenerateName())
+	})
+}
+
+func (s *VeleroSuite) TestLabelSelectorObject() {
+	selector, err := labelSelectorObject("app=web,tier notin (cache)")
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"app": "web"}, selector["matchLabels"])
+	s.Equal([]any{map[string]any{"key": "tier", "operator": "NotIn", "values": []any{"cache"}}}, selector["matchExpressions"])
+
+	_, err = labelSelectorObject("app in web")
+	s.ErrorContains(err, `invalid label selector "app in web"`)
+}
+
+func (s *VeleroSuite) TestSummarizeBackup() {
+	b := &backup{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "nightly-20260101", "labels": map[string]any{"velero.io/schedule-name": "nightly"}},
+		"spec": map[string]any{
+			"includedNamespaces": []any{"shop"},
+			"labelSelector":      map[string]any{"matchLabels": map[string]any{"app": "web"}},
+			"storageLocation":    "default",
+		},
+		"status": map[string]any{
+			"phase":                    "PartiallyFailed",
+			"startTimestamp":           "2026-01-01T01:00:00Z",
+			"completionTimestamp":      "2026-01-01T01:05:00Z",
+			"expiration":               "2026-01-31T01:00:00Z",
+			"progress":                 map[string]any{"totalItems": int64(120), "itemsBackedUp": int64(118)},
+			"volumeSnapshotsAttempted": int64(2),
+			"volumeSnapshotsCompleted": int64(1),
+			"errors":                   int64(2),
+			"warnings":                 int64(1),
+		},
+	}, b)
+	s.Equal(backupReport{
+		Name:               "nightly-20260101",
+		Phase:              "PartiallyFailed",
+		Schedule:           "nightly",
+		IncludedNamespaces: []string{"shop"},
+		LabelSelector:      "app=web",
+		StorageLocation:    "default",
+		Started:            "2026-01-01T01:00:00Z",
+		Completed:          "2026-01-01T01:05:00Z",
+		Expiration:         "2026-01-31T01:00:00Z",
+		Progress:           "118/120 items backed up",
+		VolumeSnapshots:    "1/2 completed",
+		Errors:             2,
+		Warnings:           1,
+	}, summarizeBackup(b))
+}
+
+func (s *VeleroSuite) TestSummarizeRestore() {
+	r := &restore{}
+	s.decode(map[string]any{
+		"metadata": map[string]any{"name": "nightly-20260101-abcde"},
+		"spec": map[string]any{
+			"backupName":       "nightly-20260101",
+			"namespaceMapping": map[string]any{"shop": "shop-restored", "db": "db-restored"},
+		},
+		"status": map[string]any{
+			"phase":            "FailedValidation",
+			"validationErrors": []any{"backup nightly-20260101 not found"},
+		},
+	}, r)
+	report := summarizeRestore(r)
+	s.Equal("nightly-20260101", report.Backup)
+	s.Equal("FailedValidation", report.Phase)
+	s.Equal([]string{"db -> db-restored", "shop -> shop-restored"}, report.NamespaceMapping)
+	s.Equal([]string{"backup nightly-20260101 not found"}, report.ValidationErrors)
+}
+
+func (s *VeleroSuite) TestDecodeResults() {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, err := gz.Write([]byte(`{
+		"errors": {"namespaces": {"shop": ["error restoring pods/shop/web-0: admission webhook denied the request"]}, "velero": ["timed out waiting for volumes"]},
+		"warnings": {"cluster": ["could not restore, CustomResourceDefinition \"widgets.example.com\" already exists"]}
+	}`))
+	s.Require().NoError(err)
+	s.Require().NoError(gz.Close())
+
+	results, err := decodeResults(buf)
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"velero: timed out waiting for volumes",
+		"namespace shop: error restoring pods/shop/web-0: admission webhook denied the request",
+	}, flattenResult(results["errors"]))
+	s.Equal([]string{`cluster: could not restore, CustomResourceDefinition "widgets.example.com" already exists`}, flattenResult(results["warnings"]))
+}
+
+func (s *VeleroSuite) TestFlattenResultTruncates() {
+	result := veleroResult{Namespaces: map[string][]string{}}
+	for i := 0; i < maxResultEntries+5; i++ {
+		result.Namespaces["shop"] = append(result.Namespaces["shop"], fmt.Sprintf("error %d", i))
+	}
+	entries := flattenResult(result)
+	s.Len(entries, maxResultEntries+1)
+	s.Equal("... and 5 more", entries[maxResultEntries])
+}
@@ -0,0 +1,199 @@
+package volumesnapshot
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+func initRestore(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "volumesnapshot_restore",
+			Description: "Create a new PersistentVolumeClaim from a ready to use CSI VolumeSnapshot, for instance to roll back a database. " +
+				"The storage class, access modes and volume mode default to the ones of the snapshotted PersistentVolumeClaim and the size to the snapshot restore size. " +
+				"The original PersistentVolumeClaim is not modified: point the workload to the new claim to use the restored data",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the VolumeSnapshot, the PersistentVolumeClaim is created in the same namespace (Optional, current namespace if not provided)",
+					},
+					"snapshot": {
+						Type:        "string",
+						Description: "Name of the VolumeSnapshot to restore",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the PersistentVolumeClaim to create",
+					},
+					"storage_class": {
+						Type:        "string",
+						Description: "StorageClass of the new PersistentVolumeClaim, its provisioner must be the CSI driver of the snapshot (Optional)",
+					},
+					"size": {
+						Type:        "string",
+						Description: "Requested size of the new PersistentVolumeClaim, at least the snapshot restore size, e.g. 20Gi (Optional)",
+					},
+					"access_modes": {
+						Type:        "array",
+						Description: "Access modes of the new PersistentVolumeClaim, e.g. [ReadWriteOnce] (Optional)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"snapshot", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "VolumeSnapshot: Restore to PersistentVolumeClaim",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: snapshotRestore, TargetCompatibilityFilters: []func() bool{hasVolumeSnapshots(p)}},
+	}
+}
+
+type restoreOptions struct {
+	storageClass string
+	size         string
+	accessModes  []string
+}
+
+func snapshotRestore(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	snapshotName := p.RequiredString("snapshot")
+	name := p.RequiredString("name")
+	opts := restoreOptions{
+		storageClass: p.OptionalString("storage_class", ""),
+		size:         p.OptionalString("size", ""),
+		accessModes:  p.OptionalStringArray("access_modes"),
+	}
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restore volumesnapshot: %w", err)), nil
+	}
+
+	item, err := params.DynamicClient().Resource(volumeSnapshotGVR).Namespace(namespace).Get(params.Context, snapshotName, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get volumesnapshot %s/%s: %w", namespace, snapshotName, err)), nil
+	}
+	snapshot := &volumeSnapshot{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, snapshot); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to decode volumesnapshot %s/%s: %w", namespace, snapshotName, err)), nil
+	}
+
+	// The snapshotted claim provides the defaults, it may have been deleted since
+	var source *v1.PersistentVolumeClaim
+	if pvcName := ptr.Deref(snapshot.Spec.Source.PersistentVolumeClaimName, ""); pvcName != "" {
+		source, err = params.CoreV1().PersistentVolumeClaims(namespace).Get(params.Context, pvcName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			source = nil
+		} else if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get persistentvolumeclaim %s/%s: %w", namespace, pvcName, err)), nil
+		}
+	}
+
+	pvc, err := restorePVC(namespace, name, snapshot, source, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restore volumesnapshot: %w", err)), nil
+	}
+	if err = checkStorageClassDriver(params, snapshot, pvc.Spec.StorageClassName); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restore volumesnapshot: %w", err)), nil
+	}
+	created, err := params.CoreV1().PersistentVolumeClaims(namespace).Create(params.Context, pvc, metav1.CreateOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create persistentvolumeclaim %s/%s: %w", namespace, name, err)), nil
+	}
+	size := created.Spec.Resources.Requests[v1.ResourceStorage]
+	return api.NewToolCallResult(fmt.Sprintf("PersistentVolumeClaim '%s' of %s created in namespace '%s' from VolumeSnapshot '%s' (storage class: %s), "+
+		"it is bound once the CSI driver provisions the volume, which may wait for the first Pod using it depending on the storage class binding mode",
+		created.Name, size.String(), namespace, snapshotName, ptr.Deref(created.Spec.StorageClassName, "default")), nil), nil
+}
+
+// restorePVC returns the PersistentVolumeClaim restoring a VolumeSnapshot, source is the snapshotted claim if it still exists.
+func restorePVC(namespace, name string, snapshot *volumeSnapshot, source *v1.PersistentVolumeClaim, opts restoreOptions) (*v1.PersistentVolumeClaim, error) {
+	if snapshot.Status == nil || !ptr.Deref(snapshot.Status.ReadyToUse, false) {
+		reason := "the snapshot is still being taken"
+		if snapshot.Status != nil && snapshot.Status.Error != nil {
+			reason = ptr.Deref(snapshot.Status.Error.Message, reason)
+		}
+		return nil, fmt.Errorf("volumesnapshot %s is not ready to use: %s", snapshot.Name, reason)
+	}
+
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1.PersistentVolumeClaimSpec{
+			DataSource: &v1.TypedLocalObjectReference{APIGroup: ptr.To(snapshotGroup), Kind: "VolumeSnapshot", Name: snapshot.Name},
+		},
+	}
+	var size resource.Quantity
+	if source != nil {
+		pvc.Spec.StorageClassName = source.Spec.StorageClassName
+		pvc.Spec.AccessModes = source.Spec.AccessModes
+		pvc.Spec.VolumeMode = source.Spec.VolumeMode
+		size = source.Spec.Resources.Requests[v1.ResourceStorage]
+	}
+	if opts.storageClass != "" {
+		pvc.Spec.StorageClassName = ptr.To(opts.storageClass)
+	}
+	if len(opts.accessModes) > 0 {
+		pvc.Spec.AccessModes = nil
+		for _, mode := range opts.accessModes {
+			pvc.Spec.AccessModes = append(pvc.Spec.AccessModes, v1.PersistentVolumeAccessMode(mode))
+		}
+	}
+	if len(pvc.Spec.AccessModes) == 0 {
+		pvc.Spec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}
+	}
+
+	if restoreSize := snapshot.Status.RestoreSize; restoreSize != nil && restoreSize.Cmp(size) > 0 {
+		size = *restoreSize
+	}
+	if opts.size != "" {
+		requested, err := resource.ParseQuantity(opts.size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q: %w", opts.size, err)
+		}
+		if restoreSize := snapshot.Status.RestoreSize; restoreSize != nil && requested.Cmp(*restoreSize) < 0 {
+			return nil, fmt.Errorf("size %s is smaller than the snapshot restore size %s", requested.String(), restoreSize.String())
+		}
+		size = requested
+	}
+	if size.IsZero() {
+		return nil, fmt.Errorf("volumesnapshot %s does not report its restore size, provide the size", snapshot.Name)
+	}
+	pvc.Spec.Resources.Requests = v1.ResourceList{v1.ResourceStorage: size}
+	return pvc, nil
+}
+
+// checkStorageClassDriver checks that the storage class of the restored claim is provisioned by the CSI driver of the snapshot.
+func checkStorageClassDriver(params api.ToolHandlerParams, snapshot *volumeSnapshot, storageClass *string) error {
+	content := ptr.Deref(snapshot.Status.BoundVolumeSnapshotContentName, "")
+	if storageClass == nil || *storageClass == "" || content == "" {
+		return nil
+	}
+	item, err := params.DynamicClient().Resource(volumeSnapshotContentGVR).Get(params.Context, content, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get volumesnapshotcontent %s: %w", content, err)
+	}
+	driver, _, _ := unstructured.NestedString(item.Object, "spec", "driver")
+	sc, err := params.StorageV1().StorageClasses().Get(params.Context, *storageClass, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get storageclass %s: %w", *storageClass, err)
+	}
+	if driver != "" && sc.Provisioner != driver {
+		return fmt.Errorf("storageclass %s is provisioned by %s but the snapshot was taken by CSI driver %s, choose a storage class of that driver", sc.Name, sc.Provisioner, driver)
+	}
+	return nil
+}
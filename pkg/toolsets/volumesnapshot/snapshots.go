@@ -0,0 +1,327 @@
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	snapshotGroup = "snapshot.storage.k8s.io"
+	// defaultClassAnnotation marks the default VolumeSnapshotClass of a CSI driver.
+	defaultClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+)
+
+var (
+	volumeSnapshotGVR        = schema.GroupVersionResource{Group: snapshotGroup, Version: "v1", Resource: "volumesnapshots"}
+	volumeSnapshotClassGVR   = schema.GroupVersionResource{Group: snapshotGroup, Version: "v1", Resource: "volumesnapshotclasses"}
+	volumeSnapshotContentGVR = schema.GroupVersionResource{Group: snapshotGroup, Version: "v1", Resource: "volumesnapshotcontents"}
+)
+
+// hasVolumeSnapshots returns a filter that hides the tools when the CSI snapshot CRDs are not installed.
+func hasVolumeSnapshots(p api.FilteringProvider) func() bool {
+	return func() bool {
+		return p.AnyTargetHasGVKs(context.TODO(), []schema.GroupVersionKind{
+			{Group: snapshotGroup, Version: "v1", Kind: "VolumeSnapshot"},
+		})
+	}
+}
+
+func initSnapshots(p api.FilteringProvider) []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "volumesnapshot_create",
+			Description: "Create a CSI VolumeSnapshot of a PersistentVolumeClaim, for instance before a risky database change. " +
+				"Checks that the PersistentVolumeClaim is bound to a CSI volume and that a VolumeSnapshotClass exists for its CSI driver, using the driver default class unless a class is provided",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the PersistentVolumeClaim (Optional, current namespace if not provided)",
+					},
+					"pvc": {
+						Type:        "string",
+						Description: "Name of the PersistentVolumeClaim to snapshot",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the VolumeSnapshot (Optional, generated from the PersistentVolumeClaim name if not provided)",
+					},
+					"snapshot_class": {
+						Type:        "string",
+						Description: "Name of the VolumeSnapshotClass to use (Optional, defaults to the default VolumeSnapshotClass of the CSI driver)",
+					},
+				},
+				Required: []string{"pvc"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "VolumeSnapshot: Create",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: snapshotCreate, TargetCompatibilityFilters: []func() bool{hasVolumeSnapshots(p)}},
+		{Tool: api.Tool{
+			Name: "volumesnapshots_list",
+			Description: "List CSI VolumeSnapshots, most recent first, with their source PersistentVolumeClaim, VolumeSnapshotClass, readyToUse, restoreSize, " +
+				"creation time, and the snapshot error if any",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the VolumeSnapshots (Optional, all namespaces if not provided)",
+					},
+					"pvc": {
+						Type:        "string",
+						Description: "Only list the VolumeSnapshots of this PersistentVolumeClaim (Optional)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "VolumeSnapshot: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: snapshotsList, TargetCompatibilityFilters: []func() bool{hasVolumeSnapshots(p)}},
+	}
+}
+
+// volumeSnapshot is the subset of a VolumeSnapshot inspected by this toolset.
+type volumeSnapshot struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Source struct {
+			PersistentVolumeClaimName *string `json:"persistentVolumeClaimName,omitempty"`
+		} `json:"source"`
+		VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+	} `json:"spec"`
+	Status *struct {
+		BoundVolumeSnapshotContentName *string            `json:"boundVolumeSnapshotContentName,omitempty"`
+		CreationTime                   *metav1.Time       `json:"creationTime,omitempty"`
+		ReadyToUse                     *bool              `json:"readyToUse,omitempty"`
+		RestoreSize                    *resource.Quantity `json:"restoreSize,omitempty"`
+		Error                          *struct {
+			Message *string `json:"message,omitempty"`
+		} `json:"error,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// volumeSnapshotClass is the subset of a VolumeSnapshotClass inspected by this toolset.
+type volumeSnapshotClass struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Driver            string `json:"driver"`
+	DeletionPolicy    string `json:"deletionPolicy"`
+}
+
+type snapshotReport struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	PVC           string `json:"pvc,omitempty"`
+	SnapshotClass string `json:"snapshotClass,omitempty"`
+	ReadyToUse    bool   `json:"readyToUse"`
+	RestoreSize   string `json:"restoreSize,omitempty"`
+	Created       string `json:"created,omitempty"`
+	Content       string `json:"content,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+func snapshotCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	pvcName := p.RequiredString("pvc")
+	name := p.OptionalString("name", "")
+	className := p.OptionalString("snapshot_class", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create volumesnapshot: %w", err)), nil
+	}
+
+	pvc, err := params.CoreV1().PersistentVolumeClaims(namespace).Get(params.Context, pvcName, metav1.GetOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get persistentvolumeclaim %s/%s: %w", namespace, pvcName, err)), nil
+	}
+	driver, err := csiDriver(params.Context, params.KubernetesClient, pvc)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create volumesnapshot: %w", err)), nil
+	}
+	classes, err := listSnapshotClasses(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list volumesnapshotclasses: %w", err)), nil
+	}
+	class, err := selectSnapshotClass(classes, driver, className)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create volumesnapshot: %w", err)), nil
+	}
+
+	snapshot := snapshotObject(namespace, name, pvcName, class)
+	created, err := params.DynamicClient().Resource(volumeSnapshotGVR).Namespace(namespace).Create(params.Context, snapshot, metav1.CreateOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create volumesnapshot: %w", err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("VolumeSnapshot '%s' of PersistentVolumeClaim '%s' created in namespace '%s' with VolumeSnapshotClass '%s' (CSI driver %s), "+
+		"use volumesnapshots_list to check when it is ready to use", created.GetName(), pvcName, namespace, class, driver), nil), nil
+}
+
+// csiDriver returns the CSI driver of the volume bound to a PersistentVolumeClaim.
+func csiDriver(ctx context.Context, client api.KubernetesClient, pvc *v1.PersistentVolumeClaim) (string, error) {
+	if pvc.Status.Phase != v1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return "", fmt.Errorf("persistentvolumeclaim %s/%s is %s, only bound claims can be snapshotted", pvc.Namespace, pvc.Name, pvc.Status.Phase)
+	}
+	pv, err := client.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get persistentvolume %s: %w", pvc.Spec.VolumeName, err)
+	}
+	if pv.Spec.CSI == nil {
+		return "", fmt.Errorf("persistentvolume %s is not provisioned by a CSI driver, only CSI volumes can be snapshotted", pv.Name)
+	}
+	return pv.Spec.CSI.Driver, nil
+}
+
+func listSnapshotClasses(params api.ToolHandlerParams) ([]volumeSnapshotClass, error) {
+	list, err := params.DynamicClient().Resource(volumeSnapshotClassGVR).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	classes := make([]volumeSnapshotClass, len(list.Items))
+	for i := range list.Items {
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &classes[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode volumesnapshotclass %s: %w", list.Items[i].GetName(), err)
+		}
+	}
+	return classes, nil
+}
+
+// selectSnapshotClass returns the requested VolumeSnapshotClass, or the default one for the CSI driver,
+// failing when no class handles the driver.
+func selectSnapshotClass(classes []volumeSnapshotClass, driver, requested string) (string, error) {
+	var candidates, defaults []string
+	for _, c := range classes {
+		if requested != "" && c.Name == requested {
+			if c.Driver != driver {
+				return "", fmt.Errorf("volumesnapshotclass %s is for CSI driver %s, the volume is provisioned by %s", c.Name, c.Driver, driver)
+			}
+			return c.Name, nil
+		}
+		if c.Driver != driver {
+			continue
+		}
+		candidates = append(candidates, c.Name)
+		if c.Annotations[defaultClassAnnotation] == "true" {
+			defaults = append(defaults, c.Name)
+		}
+	}
+	switch {
+	case requested != "":
+		return "", fmt.Errorf("volumesnapshotclass %s not found", requested)
+	case len(candidates) == 0:
+		return "", fmt.Errorf("no VolumeSnapshotClass found for CSI driver %s, create one or check that the driver supports snapshots", driver)
+	case len(defaults) == 1:
+		return defaults[0], nil
+	case len(candidates) == 1:
+		return candidates[0], nil
+	default:
+		slices.Sort(candidates)
+		return "", fmt.Errorf("several VolumeSnapshotClasses found for CSI driver %s and none is the default, choose one of: %s", driver, strings.Join(candidates, ", "))
+	}
+}
+
+// snapshotObject returns the VolumeSnapshot of a PersistentVolumeClaim, its name is generated when name is empty.
+func snapshotObject(namespace, name, pvc, class string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"volumeSnapshotClassName": class,
+			"source":                  map[string]any{"persistentVolumeClaimName": pvc},
+		},
+	}}
+	obj.SetAPIVersion(snapshotGroup + "/v1")
+	obj.SetKind("VolumeSnapshot")
+	obj.SetNamespace(namespace)
+	if name != "" {
+		obj.SetName(name)
+	} else {
+		obj.SetGenerateName(pvc + "-snapshot-")
+	}
+	return obj
+}
+
+func snapshotsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	pvc := p.OptionalString("pvc", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list volumesnapshots: %w", err)), nil
+	}
+
+	list, err := params.DynamicClient().Resource(volumeSnapshotGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list volumesnapshots: %w", err)), nil
+	}
+	snapshots := make([]volumeSnapshot, 0, len(list.Items))
+	for _, item := range list.Items {
+		snapshot := volumeSnapshot{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &snapshot); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decode volumesnapshot %s/%s: %w", item.GetNamespace(), item.GetName(), err)), nil
+		}
+		if pvc == "" || ptr.Deref(snapshot.Spec.Source.PersistentVolumeClaimName, "") == pvc {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	if len(snapshots) == 0 {
+		return api.NewToolCallResult("No VolumeSnapshots found", nil), nil
+	}
+	slices.SortFunc(snapshots, func(a, b volumeSnapshot) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+	reports := make([]snapshotReport, 0, len(snapshots))
+	for i := range snapshots {
+		reports = append(reports, summarizeSnapshot(&snapshots[i]))
+	}
+	ret, err := output.MarshalYaml(reports)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list volumesnapshots: %w", err)), nil
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func summarizeSnapshot(s *volumeSnapshot) snapshotReport {
+	report := snapshotReport{
+		Name:          s.Name,
+		Namespace:     s.Namespace,
+		PVC:           ptr.Deref(s.Spec.Source.PersistentVolumeClaimName, ""),
+		SnapshotClass: ptr.Deref(s.Spec.VolumeSnapshotClassName, ""),
+	}
+	if s.Status == nil {
+		report.Error = "the snapshot controller did not process the VolumeSnapshot yet, check that the CSI snapshot controller is running"
+		return report
+	}
+	report.ReadyToUse = ptr.Deref(s.Status.ReadyToUse, false)
+	if s.Status.RestoreSize != nil {
+		report.RestoreSize = s.Status.RestoreSize.String()
+	}
+	if s.Status.CreationTime != nil {
+		report.Created = s.Status.CreationTime.UTC().Format(time.RFC3339)
+	}
+	report.Content = ptr.Deref(s.Status.BoundVolumeSnapshotContentName, "")
+	if s.Status.Error != nil {
+		report.Error = ptr.Deref(s.Status.Error.Message, "")
+	}
+	return report
+}
@@ -0,0 +1,44 @@
+package volumesnapshot
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+// Toolset provides CSI VolumeSnapshot tools.
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "volumesnapshot"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "CSI VolumeSnapshot tools to snapshot PersistentVolumeClaims, list VolumeSnapshots with their readiness and restore size, and restore a snapshot into a new PersistentVolumeClaim"
+}
+
+func (t *Toolset) GetTools(p api.FilteringProvider) []api.ServerTool {
+	return slices.Concat(
+		initSnapshots(p),
+		initRestore(p),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	return nil
+}
+
+func (t *Toolset) GetResources() []api.ServerResource {
+	return nil
+}
+
+func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
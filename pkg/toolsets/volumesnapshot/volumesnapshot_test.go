@@ -0,0 +1,165 @@
+package volumesnapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+type VolumeSnapshotSuite struct {
+	suite.Suite
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	suite.Run(t, new(VolumeSnapshotSuite))
+}
+
+func (s *VolumeSnapshotSuite) snapshot(status map[string]any) *volumeSnapshot {
+	snapshot := &volumeSnapshot{}
+	obj := map[string]any{
+		"metadata": map[string]any{"name": "db-snapshot", "namespace": "shop"},
+		"spec": map[string]any{
+			"source":                  map[string]any{"persistentVolumeClaimName": "db"},
+			"volumeSnapshotClassName": "csi-rbd",
+		},
+	}
+	if status != nil {
+		obj["status"] = status
+	}
+	s.Require().NoError(runtime.DefaultUnstructuredConverter.FromUnstructured(obj, snapshot))
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestSelectSnapshotClass() {
+	class := func(name, driver string, isDefault bool) volumeSnapshotClass {
+		c := volumeSnapshotClass{Driver: driver}
+		c.Name = name
+		if isDefault {
+			c.Annotations = map[string]string{defaultClassAnnotation: "true"}
+		}
+		return c
+	}
+	classes := []volumeSnapshotClass{
+		class("csi-rbd", "rbd.csi.ceph.com", false),
+		class("csi-rbd-retain", "rbd.csi.ceph.com", true),
+		class("ebs", "ebs.csi.aws.com", false),
+		class("ebs-retain", "ebs.csi.aws.com", false),
+		class("hostpath", "hostpath.csi.k8s.io", false),
+	}
+	s.Run("selects the default class of the driver", func() {
+		c, err := selectSnapshotClass(classes, "rbd.csi.ceph.com", "")
+		s.Require().NoError(err)
+		s.Equal("csi-rbd-retain", c)
+	})
+	s.Run("selects the only class of the driver", func() {
+		c, err := selectSnapshotClass(classes, "hostpath.csi.k8s.io", "")
+		s.Require().NoError(err)
+		s.Equal("hostpath", c)
+	})
+	s.Run("selects the requested class", func() {
+		c, err := selectSnapshotClass(classes, "rbd.csi.ceph.com", "csi-rbd")
+		s.Require().NoError(err)
+		s.Equal("csi-rbd", c)
+	})
+	s.Run("rejects a requested class of another driver", func() {
+		_, err := selectSnapshotClass(classes, "rbd.csi.ceph.com", "ebs")
+		s.ErrorContains(err, "volumesnapshotclass ebs is for CSI driver ebs.csi.aws.com, the volume is provisioned by rbd.csi.ceph.com")
+	})
+	s.Run("rejects a missing requested class", func() {
+		_, err := selectSnapshotClass(classes, "rbd.csi.ceph.com", "missing")
+		s.ErrorContains(err, "volumesnapshotclass missing not found")
+	})
+	s.Run("fails without class for the driver", func() {
+		_, err := selectSnapshotClass(classes, "nfs.csi.k8s.io", "")
+		s.ErrorContains(err, "no VolumeSnapshotClass found for CSI driver nfs.csi.k8s.io")
+	})
+	s.Run("fails with several classes and no default", func() {
+		_, err := selectSnapshotClass(classes, "ebs.csi.aws.com", "")
+		s.ErrorContains(err, "choose one of: ebs, ebs-retain")
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestSnapshotObject() {
+	obj := snapshotObject("shop", "", "db", "csi-rbd")
+	s.Equal("snapshot.storage.k8s.io/v1", obj.GetAPIVersion())
+	s.Equal("VolumeSnapshot", obj.GetKind())
+	s.Equal("db-snapshot-", obj.GetGenerateName())
+	s.Equal(map[string]any{
+		"volumeSnapshotClassName": "csi-rbd",
+		"source":                  map[string]any{"persistentVolumeClaimName": "db"},
+	}, obj.Object["spec"])
+}
+
+func (s *VolumeSnapshotSuite) TestSummarizeSnapshot() {
+	s.Run("reports ready snapshots", func() {
+		report := summarizeSnapshot(s.snapshot(map[string]any{
+			"boundVolumeSnapshotContentName": "snapcontent-1234",
+			"creationTime":                   "2026-01-02T03:04:05Z",
+			"readyToUse":                     true,
+			"restoreSize":                    "10Gi",
+		}))
+		s.Equal(snapshotReport{
+			Name: "db-snapshot", Namespace: "shop", PVC: "db", SnapshotClass: "csi-rbd",
+			ReadyToUse: true, RestoreSize: "10Gi", Created: "2026-01-02T03:04:05Z", Content: "snapcontent-1234",
+		}, report)
+	})
+	s.Run("reports snapshot errors", func() {
+		report := summarizeSnapshot(s.snapshot(map[string]any{
+			"readyToUse": false,
+			"error":      map[string]any{"message": "failed to take snapshot: rpc error: code = Internal"},
+		}))
+		s.False(report.ReadyToUse)
+		s.Equal("failed to take snapshot: rpc error: code = Internal", report.Error)
+	})
+	s.Run("reports unprocessed snapshots", func() {
+		report := summarizeSnapshot(s.snapshot(nil))
+		s.Contains(report.Error, "check that the CSI snapshot controller is running")
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestRestorePVC() {
+	ready := map[string]any{"readyToUse": true, "restoreSize": "10Gi"}
+	source := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("ceph-rbd"),
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOncePod},
+			VolumeMode:       ptr.To(v1.PersistentVolumeBlock),
+			Resources:        v1.VolumeResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("20Gi")}},
+		},
+	}
+	s.Run("defaults to the snapshotted claim", func() {
+		pvc, err := restorePVC("shop", "db-restored", s.snapshot(ready), source, restoreOptions{})
+		s.Require().NoError(err)
+		s.Equal("db-restored", pvc.Name)
+		s.Equal(&v1.TypedLocalObjectReference{APIGroup: ptr.To("snapshot.storage.k8s.io"), Kind: "VolumeSnapshot", Name: "db-snapshot"}, pvc.Spec.DataSource)
+		s.Equal("ceph-rbd", *pvc.Spec.StorageClassName)
+		s.Equal([]v1.PersistentVolumeAccessMode{v1.ReadWriteOncePod}, pvc.Spec.AccessModes)
+		s.Equal(v1.PersistentVolumeBlock, *pvc.Spec.VolumeMode)
+		size := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		s.Equal("20Gi", size.String())
+	})
+	s.Run("defaults to the restore size without claim", func() {
+		pvc, err := restorePVC("shop", "db-restored", s.snapshot(ready), nil, restoreOptions{storageClass: "fast", accessModes: []string{"ReadWriteMany"}})
+		s.Require().NoError(err)
+		s.Equal("fast", *pvc.Spec.StorageClassName)
+		s.Equal([]v1.PersistentVolumeAccessMode{v1.ReadWriteMany}, pvc.Spec.AccessModes)
+		size := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		s.Equal("10Gi", size.String())
+	})
+	s.Run("rejects sizes smaller than the restore size", func() {
+		_, err := restorePVC("shop", "db-restored", s.snapshot(ready), nil, restoreOptions{size: "5Gi"})
+		s.ErrorContains(err, "size 5Gi is smaller than the snapshot restore size 10Gi")
+	})
+	s.Run("rejects snapshots not ready to use", func() {
+		_, err := restorePVC("shop", "db-restored", s.snapshot(map[string]any{
+			"readyToUse": false, "error": map[string]any{"message": "snapshot timed out"},
+		}), source, restoreOptions{})
+		s.ErrorContains(err, "volumesnapshot db-snapshot is not ready to use: snapshot timed out")
+	})
+}